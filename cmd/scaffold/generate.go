@@ -0,0 +1,456 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"strings"
+)
+
+func (k fieldKind) schemaType() string {
+	switch k {
+	case kindInt:
+		return "schema.TypeInt"
+	case kindBool:
+		return "schema.TypeBool"
+	case kindFloat:
+		return "schema.TypeFloat"
+	case kindLocalizedString:
+		return "TypeLocalizedString"
+	case kindStringList, kindEnumList:
+		return "schema.TypeList"
+	}
+	return "schema.TypeString"
+}
+
+// generateResource returns the source of the resource file
+func generateResource(m *model) ([]byte, error) {
+	w := &writer{}
+
+	w.line("package commercetools")
+	w.line("")
+	w.line("import (")
+	w.line(`"context"`)
+	w.line(`"log"`)
+	w.line(`"time"`)
+	w.line("")
+	w.line(`"github.com/hashicorp/terraform-plugin-sdk/helper/resource"`)
+	w.line(`"github.com/hashicorp/terraform-plugin-sdk/helper/schema"`)
+	w.line(`"github.com/labd/commercetools-go-sdk/commercetools"`)
+	w.line(")")
+	w.line("")
+
+	generateSchema(w, m)
+	generateCreate(w, m)
+	generateRead(w, m)
+	generateUpdate(w, m)
+	generateDelete(w, m)
+
+	return format.Source(w.Bytes())
+}
+
+func generateSchema(w *writer, m *model) {
+	w.line("func resource%s() *schema.Resource {", m.Name)
+	w.line("return &schema.Resource{")
+	w.line("Create: resource%sCreate,", m.Name)
+	w.line("Read:   resource%sRead,", m.Name)
+	w.line("Update: resource%sUpdate,", m.Name)
+	w.line("Delete: resource%sDelete,", m.Name)
+	w.line("Importer: &schema.ResourceImporter{")
+	w.line("State: schema.ImportStatePassthrough,")
+	w.line("},")
+	w.line("Schema: map[string]*schema.Schema{")
+	for _, f := range m.Fields {
+		if f.Kind == kindUnsupported {
+			w.line("// TODO: %s (%s) is not supported by the scaffold", f.Name, f.DraftType)
+			continue
+		}
+		w.line("%q: {", f.Attribute)
+		w.line("Type: %s,", f.Kind.schemaType())
+		if f.Required {
+			w.line("Required: true,")
+		} else {
+			w.line("Optional: true,")
+		}
+		if f.Action == "" {
+			w.line("ForceNew: true,")
+		}
+		if f.Kind == kindStringList || f.Kind == kindEnumList {
+			w.line("Elem: &schema.Schema{Type: schema.TypeString},")
+		}
+		w.line("},")
+	}
+	if m.HasCustom {
+		w.line(`"custom": customFieldSchema(),`)
+	}
+	w.line(`"version": {`)
+	w.line("Type:     schema.TypeInt,")
+	w.line("Computed: true,")
+	w.line("},")
+	w.line("},")
+	w.line("}")
+	w.line("}")
+	w.line("")
+}
+
+func generateCreate(w *writer, m *model) {
+	w.line("func resource%sCreate(d *schema.ResourceData, m interface{}) error {", m.Name)
+	w.line("client := getClient(m)")
+	w.line("var %s *commercetools.%s", lowerFirst(m.Name), m.Name)
+	w.line("")
+
+	for _, f := range m.Fields {
+		switch f.Kind {
+		case kindLocalizedString:
+			w.line("%s := %s", lowerFirst(f.Name), expandValue(f))
+		case kindEnumList:
+			w.line("%s := %s", lowerFirst(f.Name), expandValue(f))
+		}
+	}
+
+	customFields := m.HasCustom && m.CustomDraft == "*CustomFields"
+	if customFields {
+		w.line("custom, err := expandCustomFields(d, client)")
+		w.line("if err != nil {")
+		w.line("return err")
+		w.line("}")
+	}
+
+	w.line("")
+	w.line("draft := &commercetools.%sDraft{", m.Name)
+	for _, f := range m.Fields {
+		switch f.Kind {
+		case kindString, kindInt, kindBool, kindFloat, kindEnum, kindStringList:
+			w.line("%s: %s,", f.Name, expandValue(f))
+		case kindLocalizedString:
+			w.line("%s: %s%s,", f.Name, addressOf(f.Pointer), lowerFirst(f.Name))
+		case kindEnumList:
+			w.line("%s: %s,", f.Name, lowerFirst(f.Name))
+		}
+	}
+	if customFields {
+		w.line("Custom: custom,")
+	} else if m.HasCustom {
+		w.line("Custom: expandCustomFieldsDraft(d),")
+	}
+	w.line("}")
+	w.line("")
+
+	for _, f := range m.Fields {
+		switch f.Kind {
+		case kindReference:
+			w.line("if val := d.Get(%q).(string); val != \"\" {", f.Attribute)
+			w.line("draft.%s = &commercetools.%s{ID: val}", f.Name, f.Elem)
+			w.line("}")
+		case kindDate:
+			w.line("if val := d.Get(%q).(string); len(val) > 0 {", f.Attribute)
+			w.line("%s, err := expandDate(val)", lowerFirst(f.Name))
+			w.line("if err != nil {")
+			w.line("return err")
+			w.line("}")
+			w.line("draft.%s = %s%s", f.Name, addressOf(f.Pointer), lowerFirst(f.Name))
+			w.line("}")
+		}
+	}
+
+	w.line("")
+	if customFields {
+		w.line("err = resource.Retry(1*time.Minute, func() *resource.RetryError {")
+	} else {
+		w.line("err := resource.Retry(1*time.Minute, func() *resource.RetryError {")
+	}
+	w.line("var err error")
+	w.line("")
+	w.line("%s, err = client.%sCreate(context.Background(), draft)", lowerFirst(m.Name), m.Name)
+	w.line("if err != nil {")
+	w.line("return handleCommercetoolsError(err)")
+	w.line("}")
+	w.line("return nil")
+	w.line("})")
+	w.line("")
+	w.line("if err != nil {")
+	w.line("return err")
+	w.line("}")
+	w.line("")
+	w.line("d.SetId(%s.ID)", lowerFirst(m.Name))
+	w.line("d.Set(\"version\", %s.Version)", lowerFirst(m.Name))
+	w.line("")
+	w.line("return resource%sRead(d, m)", m.Name)
+	w.line("}")
+	w.line("")
+}
+
+func generateRead(w *writer, m *model) {
+	obj := lowerFirst(m.Name)
+
+	w.line("func resource%sRead(d *schema.ResourceData, m interface{}) error {", m.Name)
+	w.line("log.Printf(\"[DEBUG] Reading %s from commercetools, with id: %%s\", d.Id())", humanize(m.Name))
+	w.line("")
+	w.line("client := getClient(m)")
+	w.line("")
+	if m.HasCustom {
+		w.line("%s, err := client.%sGetWithID(context.Background(), d.Id(), customFieldsReadOption)", obj, m.Name)
+	} else {
+		w.line("%s, err := client.%sGetWithID(context.Background(), d.Id())", obj, m.Name)
+	}
+	w.line("")
+	w.line("if err != nil {")
+	w.line("if ctErr, ok := err.(commercetools.ErrorResponse); ok {")
+	w.line("if ctErr.StatusCode == 404 {")
+	w.line("d.SetId(\"\")")
+	w.line("return nil")
+	w.line("}")
+	w.line("}")
+	w.line("return err")
+	w.line("}")
+	w.line("")
+	w.line("log.Print(\"[DEBUG] Found following %s:\")", humanize(m.Name))
+	w.line("log.Print(stringFormatObject(%s))", obj)
+	w.line("")
+	w.line("d.Set(\"version\", %s.Version)", obj)
+
+	for _, f := range m.Fields {
+		if f.Kind == kindUnsupported {
+			continue
+		}
+		value := fmt.Sprintf("%s.%s", obj, f.Name)
+		if f.ObjectType == "" {
+			w.line("// TODO: %s is not returned by commercetools as %s", f.Name, f.Name)
+			continue
+		}
+		pointer := strings.HasPrefix(f.ObjectType, "*")
+
+		switch f.Kind {
+		case kindLocalizedString:
+			if pointer {
+				w.line("if %s != nil {", value)
+				w.line("d.Set(%q, *%s)", f.Attribute, value)
+				w.line("}")
+			} else {
+				w.line("d.Set(%q, %s)", f.Attribute, value)
+			}
+		case kindReference:
+			w.line("if %s != nil {", value)
+			w.line("d.Set(%q, %s.ID)", f.Attribute, value)
+			w.line("}")
+		case kindDate:
+			if pointer {
+				w.line("if %s != nil {", value)
+				w.line("d.Set(%q, %s.Format(time.RFC3339))", f.Attribute, value)
+				w.line("}")
+			} else {
+				w.line("d.Set(%q, %s.Format(time.RFC3339))", f.Attribute, value)
+			}
+		default:
+			w.line("d.Set(%q, %s)", f.Attribute, value)
+		}
+	}
+	if m.HasCustom {
+		w.line("d.Set(\"custom\", flattenCustomFields(%s.Custom))", obj)
+	}
+	w.line("return nil")
+	w.line("}")
+	w.line("")
+}
+
+func generateUpdate(w *writer, m *model) {
+	w.line("func resource%sUpdate(d *schema.ResourceData, m interface{}) error {", m.Name)
+	w.line("client := getClient(m)")
+	w.line("")
+	w.line("input := &commercetools.%sUpdateWithIDInput{", m.Name)
+	w.line("ID:      d.Id(),")
+	w.line("Version: d.Get(\"version\").(int),")
+	w.line("Actions: []commercetools.%sUpdateAction{},", m.Name)
+	w.line("}")
+
+	for _, f := range m.Fields {
+		if f.Action == "" {
+			continue
+		}
+		newValue := "new" + f.Name
+		w.line("")
+		w.line("if d.HasChange(%q) {", f.Attribute)
+		switch f.Kind {
+		case kindReference:
+			w.line("%s := &commercetools.%s{ID: d.Get(%q).(string)}", newValue, f.Elem, f.Attribute)
+		case kindDate:
+			w.line("%s, err := expandDate(d.Get(%q).(string))", newValue, f.Attribute)
+			w.line("if err != nil {")
+			w.line("return err")
+			w.line("}")
+			if f.Pointer {
+				newValue = "&" + newValue
+			}
+		default:
+			w.line("%s := %s", newValue, expandValue(f))
+			if f.Kind == kindLocalizedString && f.Pointer {
+				newValue = "&" + newValue
+			}
+		}
+		w.line("input.Actions = append(")
+		w.line("input.Actions,")
+		w.line("&commercetools.%s{%s: %s})", f.Action, f.ActionField, newValue)
+		w.line("}")
+	}
+
+	if m.HasCustom {
+		w.line("")
+		w.line("if changes := resourceCustomFieldChanges(d); changes != nil {")
+		w.line("// TODO: add the %sSetCustomTypeAction and %sSetCustomFieldAction actions", m.Name, m.Name)
+		w.line("}")
+	}
+
+	w.line("")
+	w.line("log.Printf(")
+	w.line("\"[DEBUG] Will perform update operation with the following actions:\\n%%s\",")
+	w.line("stringFormatActions(input.Actions))")
+	w.line("")
+	w.line("_, err := client.%sUpdateWithID(context.Background(), input)", m.Name)
+	w.line("if err != nil {")
+	w.line("if ctErr, ok := err.(commercetools.ErrorResponse); ok {")
+	w.line("log.Printf(\"[DEBUG] %%v: %%v\", ctErr, stringFormatErrorExtras(ctErr))")
+	w.line("}")
+	w.line("return err")
+	w.line("}")
+	w.line("")
+	w.line("return resource%sRead(d, m)", m.Name)
+	w.line("}")
+	w.line("")
+}
+
+func generateDelete(w *writer, m *model) {
+	w.line("func resource%sDelete(d *schema.ResourceData, m interface{}) error {", m.Name)
+	w.line("client := getClient(m)")
+	w.line("version := d.Get(\"version\").(int)")
+	w.line("_, err := client.%sDeleteWithID(context.Background(), d.Id(), version)", m.Name)
+	w.line("if err != nil {")
+	w.line("return err")
+	w.line("}")
+	w.line("")
+	w.line("return nil")
+	w.line("}")
+}
+
+// generateTest returns the source of an acceptance test which creates the
+// resource with all required fields
+func generateTest(m *model) ([]byte, error) {
+	w := &writer{}
+	name := fmt.Sprintf("commercetools_%s.acctest", m.Resource)
+
+	w.line("package commercetools")
+	w.line("")
+	w.line("import (")
+	w.line(`"testing"`)
+	w.line("")
+	w.line(`"github.com/hashicorp/terraform-plugin-sdk/helper/resource"`)
+	w.line(`"github.com/hashicorp/terraform-plugin-sdk/terraform"`)
+	w.line(")")
+	w.line("")
+	w.line("func TestAcc%s_create(t *testing.T) {", m.Name)
+	w.line("resource.Test(t, resource.TestCase{")
+	w.line("PreCheck:     func() { testAccPreCheck(t) },")
+	w.line("Providers:    testAccProviders,")
+	w.line("CheckDestroy: testAccCheck%sDestroy,", m.Name)
+	w.line("Steps: []resource.TestStep{")
+	w.line("{")
+	w.line("Config: testAcc%sConfig(),", m.Name)
+	w.line("Check: resource.ComposeTestCheckFunc(")
+	w.line("resource.TestCheckResourceAttrSet(%q, \"version\"),", name)
+	w.line("),")
+	w.line("},")
+	w.line("},")
+	w.line("})")
+	w.line("}")
+	w.line("")
+	w.line("func testAcc%sConfig() string {", m.Name)
+	w.line("return `")
+	w.line("resource %q %q {", "commercetools_"+m.Resource, "acctest")
+	for _, f := range m.Fields {
+		if !f.Required || f.Kind == kindUnsupported {
+			continue
+		}
+		switch f.Kind {
+		case kindLocalizedString:
+			w.line("%s = {", f.Attribute)
+			w.line("en = \"TODO\"")
+			w.line("}")
+		case kindInt, kindFloat:
+			w.line("%s = 0", f.Attribute)
+		case kindBool:
+			w.line("%s = false", f.Attribute)
+		case kindStringList, kindEnumList:
+			w.line("%s = []", f.Attribute)
+		default:
+			w.line("%s = \"TODO\"", f.Attribute)
+		}
+	}
+	w.line("}")
+	w.line("`")
+	w.line("}")
+	w.line("")
+	w.line("func testAccCheck%sDestroy(s *terraform.State) error {", m.Name)
+	w.line("return nil")
+	w.line("}")
+
+	return format.Source(w.Bytes())
+}
+
+// expandValue returns the expression which converts the value in the
+// terraform state to the type used in the draft
+func expandValue(f field) string {
+	get := fmt.Sprintf("d.Get(%q)", f.Attribute)
+	switch f.Kind {
+	case kindString:
+		return get + ".(string)"
+	case kindInt:
+		return get + ".(int)"
+	case kindBool:
+		return get + ".(bool)"
+	case kindFloat:
+		return get + ".(float64)"
+	case kindEnum:
+		return fmt.Sprintf("commercetools.%s(%s.(string))", f.Elem, get)
+	case kindStringList:
+		return fmt.Sprintf("expandStringArray(%s.([]interface{}))", get)
+	case kindEnumList:
+		return fmt.Sprintf(
+			"func() []commercetools.%[1]s {\n"+
+				"result := []commercetools.%[1]s{}\n"+
+				"for _, value := range expandStringArray(%[2]s.([]interface{})) {\n"+
+				"result = append(result, commercetools.%[1]s(value))\n"+
+				"}\n"+
+				"return result\n"+
+				"}()", f.Elem, get)
+	case kindLocalizedString:
+		return fmt.Sprintf(
+			"commercetools.LocalizedString(\nexpandStringMap(%s.(map[string]interface{})))", get)
+	}
+	return ""
+}
+
+func addressOf(pointer bool) string {
+	if pointer {
+		return "&"
+	}
+	return ""
+}
+
+func lowerFirst(name string) string {
+	if name == "" {
+		return name
+	}
+	return strings.ToLower(name[:1]) + name[1:]
+}
+
+func humanize(name string) string {
+	return strings.Replace(toSnakeCase(name), "_", " ", -1)
+}
+
+type writer struct {
+	bytes.Buffer
+}
+
+func (w *writer) line(format string, args ...interface{}) {
+	fmt.Fprintf(w, format, args...)
+	w.WriteString("\n")
+}
@@ -0,0 +1,89 @@
+// Command scaffold generates the scaffolding of a new resource from the models
+// in the commercetools SDK. It creates the schema, the expand and flatten code
+// for all fields with a supported type, the update actions which could be
+// matched to a field, and an acceptance test.
+//
+// The generated code is a starting point: unsupported fields are marked with
+// TODO comments and need to be implemented by hand, as do the documentation
+// and the registration of the resource in the provider.
+//
+// It is meant to be used via go generate from the commercetools package:
+//
+//	//go:generate go run ../cmd/scaffold -type ProductDiscount
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+const sdkModulePath = "github.com/labd/commercetools-go-sdk"
+
+func main() {
+	typeName := flag.String("type", "", "name of the SDK type of the resource, for example ProductDiscount")
+	sdkDir := flag.String("sdk", "", "directory of the commercetools package of the SDK, defaults to the module dependency")
+	outDir := flag.String("out", ".", "directory to write the generated files to")
+	flag.Parse()
+
+	if *typeName == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if *sdkDir == "" {
+		dir, err := sdkModuleDir()
+		if err != nil {
+			log.Fatal(err)
+		}
+		*sdkDir = filepath.Join(dir, "commercetools")
+	}
+
+	pkg, err := loadPackage(*sdkDir)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	m, err := pkg.resourceModel(*typeName)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	resourceSource, err := generateResource(m)
+	if err != nil {
+		log.Fatal(err)
+	}
+	testSource, err := generateTest(m)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	files := map[string][]byte{
+		fmt.Sprintf("resource_%s.go", m.Resource):      resourceSource,
+		fmt.Sprintf("resource_%s_test.go", m.Resource): testSource,
+	}
+	for name, source := range files {
+		path := filepath.Join(*outDir, name)
+		if _, err := os.Stat(path); err == nil {
+			log.Printf("Skipping %s, the file already exists", path)
+			continue
+		}
+		if err := ioutil.WriteFile(path, source, 0644); err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("Generated %s", path)
+	}
+}
+
+func sdkModuleDir() (string, error) {
+	output, err := exec.Command("go", "list", "-m", "-f", "{{.Dir}}", sdkModulePath).Output()
+	if err != nil {
+		return "", fmt.Errorf("unable to find the directory of %s: %s", sdkModulePath, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
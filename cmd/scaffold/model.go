@@ -0,0 +1,293 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+type fieldKind int
+
+const (
+	kindUnsupported fieldKind = iota
+	kindString
+	kindInt
+	kindBool
+	kindFloat
+	kindEnum
+	kindStringList
+	kindEnumList
+	kindLocalizedString
+	kindReference
+	kindDate
+	kindCustom
+)
+
+// field describes a single field of the draft of a resource
+type field struct {
+	Name      string
+	Attribute string
+	Kind      fieldKind
+	Required  bool
+	Pointer   bool
+
+	// DraftType is the type of the field in the draft as it is written in the
+	// SDK source, ObjectType is the type of the field with the same name on
+	// the resource itself (empty when the resource has no such field)
+	DraftType  string
+	ObjectType string
+
+	// Elem is the name of the SDK type of enums, the element type of lists
+	// and the resource identifier type of references
+	Elem string
+
+	// Action is the name of the update action which sets this field, when
+	// one could be found with a single field of the same type.
+	Action      string
+	ActionField string
+}
+
+// model is the description of a resource as found in the SDK
+type model struct {
+	Name        string
+	Resource    string
+	Fields      []field
+	HasCustom   bool
+	CustomDraft string
+}
+
+// sdkPackage contains the parsed type declarations of the SDK
+type sdkPackage struct {
+	structs     map[string]*ast.StructType
+	stringTypes map[string]bool
+	funcs       map[string]bool
+}
+
+func loadPackage(dir string) (*sdkPackage, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(info os.FileInfo) bool {
+		return !strings.HasSuffix(info.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	pkg, ok := pkgs[filepath.Base(dir)]
+	if !ok {
+		return nil, fmt.Errorf("no package %s found in %s", filepath.Base(dir), dir)
+	}
+
+	result := &sdkPackage{
+		structs:     make(map[string]*ast.StructType),
+		stringTypes: make(map[string]bool),
+		funcs:       make(map[string]bool),
+	}
+	for _, file := range pkg.Files {
+		for _, decl := range file.Decls {
+			switch decl := decl.(type) {
+			case *ast.FuncDecl:
+				result.funcs[decl.Name.Name] = true
+			case *ast.GenDecl:
+				for _, spec := range decl.Specs {
+					typeSpec, ok := spec.(*ast.TypeSpec)
+					if !ok {
+						continue
+					}
+					switch t := typeSpec.Type.(type) {
+					case *ast.StructType:
+						result.structs[typeSpec.Name.Name] = t
+					case *ast.Ident:
+						if t.Name == "string" {
+							result.stringTypes[typeSpec.Name.Name] = true
+						}
+					}
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+// resourceModel returns the model of the resource with the given name, for
+// example `Channel`. The resource needs to have a draft and the default
+// create, get, update and delete methods on the client.
+func (p *sdkPackage) resourceModel(name string) (*model, error) {
+	draft, ok := p.structs[name+"Draft"]
+	if !ok {
+		return nil, fmt.Errorf("no draft %sDraft found in the SDK", name)
+	}
+	object, ok := p.structs[name]
+	if !ok {
+		return nil, fmt.Errorf("no type %s found in the SDK", name)
+	}
+	for _, method := range []string{"Create", "GetWithID", "UpdateWithID", "DeleteWithID"} {
+		if !p.funcs[name+method] {
+			return nil, fmt.Errorf("the SDK client has no method %s%s", name, method)
+		}
+	}
+
+	objectFields := structFieldTypes(object)
+
+	m := &model{
+		Name:     name,
+		Resource: toSnakeCase(name),
+	}
+	for _, f := range draft.Fields.List {
+		for _, ident := range f.Names {
+			fld := p.newField(name, ident.Name, f)
+			fld.ObjectType = objectFields[ident.Name]
+			if fld.Kind == kindCustom {
+				m.HasCustom = true
+				m.CustomDraft = fld.DraftType
+				continue
+			}
+			m.Fields = append(m.Fields, fld)
+		}
+	}
+
+	sort.Slice(m.Fields, func(i, j int) bool {
+		return m.Fields[i].Attribute < m.Fields[j].Attribute
+	})
+	return m, nil
+}
+
+func (p *sdkPackage) newField(resource string, name string, f *ast.Field) field {
+	fld := field{
+		Name:      name,
+		Attribute: toSnakeCase(name),
+		DraftType: exprString(f.Type),
+	}
+
+	if f.Tag != nil {
+		tag := reflect.StructTag(strings.Trim(f.Tag.Value, "`"))
+		fld.Required = !strings.Contains(tag.Get("json"), "omitempty")
+	}
+
+	typ := f.Type
+	if star, ok := typ.(*ast.StarExpr); ok {
+		fld.Pointer = true
+		typ = star.X
+	}
+
+	switch t := typ.(type) {
+	case *ast.Ident:
+		fld.Kind = p.identKind(t.Name)
+		fld.Elem = t.Name
+		switch {
+		case fld.Kind != kindUnsupported:
+		case t.Name == "LocalizedString":
+			fld.Kind = kindLocalizedString
+		case t.Name == "CustomFieldsDraft" || t.Name == "CustomFields":
+			fld.Kind = kindCustom
+		case strings.HasSuffix(t.Name, "ResourceIdentifier"):
+			fld.Kind = kindReference
+		}
+	case *ast.SelectorExpr:
+		if exprString(t) == "time.Time" {
+			fld.Kind = kindDate
+		}
+	case *ast.ArrayType:
+		if elem, ok := t.Elt.(*ast.Ident); ok {
+			fld.Elem = elem.Name
+			switch p.identKind(elem.Name) {
+			case kindString:
+				fld.Kind = kindStringList
+			case kindEnum:
+				fld.Kind = kindEnumList
+			}
+		}
+	}
+
+	// Numbers and booleans are always sent, so these have a default
+	if fld.Kind == kindInt || fld.Kind == kindFloat || fld.Kind == kindBool {
+		fld.Required = false
+	}
+
+	// Pointers to scalars are used for optional values which need to be
+	// distinguished from the zero value, these need manual work.
+	if fld.Pointer && fld.Kind <= kindEnum {
+		fld.Kind = kindUnsupported
+	}
+
+	if fld.Kind != kindUnsupported && fld.Kind != kindCustom {
+		fld.Action, fld.ActionField = p.findAction(resource, name, fld.DraftType)
+	}
+	return fld
+}
+
+func (p *sdkPackage) identKind(name string) fieldKind {
+	switch name {
+	case "string":
+		return kindString
+	case "int":
+		return kindInt
+	case "bool":
+		return kindBool
+	case "float64":
+		return kindFloat
+	}
+	if p.stringTypes[name] {
+		return kindEnum
+	}
+	return kindUnsupported
+}
+
+// findAction looks for an update action like ChannelSetKeyAction or
+// ChannelChangeNameAction which has a single field of the given type.
+func (p *sdkPackage) findAction(resource string, name string, draftType string) (string, string) {
+	for _, verb := range []string{"Set", "Change"} {
+		action := resource + verb + name + "Action"
+		st, ok := p.structs[action]
+		if !ok || len(st.Fields.List) != 1 || len(st.Fields.List[0].Names) != 1 {
+			continue
+		}
+		if exprString(st.Fields.List[0].Type) == draftType {
+			return action, st.Fields.List[0].Names[0].Name
+		}
+	}
+	return "", ""
+}
+
+func structFieldTypes(st *ast.StructType) map[string]string {
+	result := make(map[string]string)
+	for _, f := range st.Fields.List {
+		for _, ident := range f.Names {
+			result[ident.Name] = exprString(f.Type)
+		}
+	}
+	return result
+}
+
+func exprString(expr ast.Expr) string {
+	var buf bytes.Buffer
+	printer.Fprint(&buf, token.NewFileSet(), expr)
+	return buf.String()
+}
+
+// toSnakeCase converts a Go name to the name of a terraform attribute, for
+// example `ProductDiscount` to `product_discount` and `ID` to `id`
+func toSnakeCase(name string) string {
+	runes := []rune(name)
+	var buf strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				buf.WriteRune('_')
+			}
+			buf.WriteRune(unicode.ToLower(r))
+		} else {
+			buf.WriteRune(r)
+		}
+	}
+	return buf.String()
+}
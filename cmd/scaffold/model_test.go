@@ -0,0 +1,69 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToSnakeCase(t *testing.T) {
+	assert.Equal(t, "product_discount", toSnakeCase("ProductDiscount"))
+	assert.Equal(t, "id", toSnakeCase("ID"))
+	assert.Equal(t, "customer_group_id", toSnakeCase("CustomerGroupID"))
+	assert.Equal(t, "geo_location", toSnakeCase("GeoLocation"))
+}
+
+func TestResourceModel(t *testing.T) {
+	pkg, err := loadPackage("testdata/commercetools")
+	assert.NoError(t, err)
+
+	m, err := pkg.resourceModel("Widget")
+	assert.NoError(t, err)
+	assert.Equal(t, "widget", m.Resource)
+	assert.True(t, m.HasCustom)
+
+	fields := make(map[string]field)
+	for _, f := range m.Fields {
+		fields[f.Attribute] = f
+	}
+	assert.Len(t, fields, 8)
+
+	assert.Equal(t, kindString, fields["key"].Kind)
+	assert.True(t, fields["key"].Required)
+	assert.Equal(t, "WidgetSetKeyAction", fields["key"].Action)
+
+	assert.Equal(t, kindLocalizedString, fields["name"].Kind)
+	assert.Equal(t, "WidgetChangeNameAction", fields["name"].Action)
+
+	assert.Equal(t, kindEnum, fields["kind"].Kind)
+	assert.Equal(t, "WidgetKindEnum", fields["kind"].Elem)
+	assert.Equal(t, "", fields["kind"].Action)
+
+	assert.Equal(t, kindStringList, fields["tags"].Kind)
+	assert.Equal(t, kindReference, fields["parent"].Kind)
+	assert.Equal(t, "*ParentReference", fields["parent"].ObjectType)
+	assert.Equal(t, kindDate, fields["valid_from"].Kind)
+	assert.Equal(t, kindInt, fields["position"].Kind)
+	assert.False(t, fields["position"].Required)
+	assert.Equal(t, kindUnsupported, fields["dimensions"].Kind)
+
+	_, err = pkg.resourceModel("Gadget")
+	assert.Error(t, err)
+}
+
+func TestGenerateResource(t *testing.T) {
+	pkg, err := loadPackage("testdata/commercetools")
+	assert.NoError(t, err)
+	m, err := pkg.resourceModel("Widget")
+	assert.NoError(t, err)
+
+	source, err := generateResource(m)
+	assert.NoError(t, err)
+	assert.Contains(t, string(source), "func resourceWidget() *schema.Resource {")
+	assert.Contains(t, string(source), "&commercetools.WidgetSetKeyAction{Key: newKey}")
+	assert.Contains(t, string(source), "// TODO: Dimensions ([]float64) is not supported by the scaffold")
+
+	source, err = generateTest(m)
+	assert.NoError(t, err)
+	assert.Contains(t, string(source), "func TestAccWidget_create(t *testing.T) {")
+}
@@ -0,0 +1,56 @@
+package commercetools
+
+import "time"
+
+type LocalizedString map[string]string
+
+type WidgetKindEnum string
+
+type ParentResourceIdentifier struct {
+	ID string `json:"id"`
+}
+
+type ParentReference struct {
+	ID string `json:"id"`
+}
+
+type CustomFieldsDraft struct{}
+
+type Widget struct {
+	ID         string           `json:"id"`
+	Version    int              `json:"version"`
+	Key        string           `json:"key"`
+	Name       *LocalizedString `json:"name"`
+	Kind       WidgetKindEnum   `json:"kind"`
+	Parent     *ParentReference `json:"parent,omitempty"`
+	ValidFrom  *time.Time       `json:"validFrom,omitempty"`
+	Position   int              `json:"position"`
+	Dimensions []float64        `json:"dimensions"`
+}
+
+type WidgetDraft struct {
+	Key        string                    `json:"key"`
+	Name       *LocalizedString          `json:"name"`
+	Kind       WidgetKindEnum            `json:"kind"`
+	Tags       []string                  `json:"tags,omitempty"`
+	Parent     *ParentResourceIdentifier `json:"parent,omitempty"`
+	ValidFrom  *time.Time                `json:"validFrom,omitempty"`
+	Position   int                       `json:"position"`
+	Dimensions []float64                 `json:"dimensions"`
+	Custom     *CustomFieldsDraft        `json:"custom,omitempty"`
+}
+
+type WidgetSetKeyAction struct {
+	Key string `json:"key"`
+}
+
+type WidgetChangeNameAction struct {
+	Name *LocalizedString `json:"name"`
+}
+
+type Client struct{}
+
+func (client *Client) WidgetCreate()       {}
+func (client *Client) WidgetGetWithID()    {}
+func (client *Client) WidgetUpdateWithID() {}
+func (client *Client) WidgetDeleteWithID() {}
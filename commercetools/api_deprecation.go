@@ -0,0 +1,68 @@
+package commercetools
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// apiDeprecationHeaders are the response headers used to announce that an
+// endpoint is deprecated (the Deprecation header draft and RFC 8594) or
+// will change, together with the link to the announcement
+var apiDeprecationHeaders = []string{"Deprecation", "Sunset", "Warning", "Link"}
+
+// apiDeprecationTransport logs a warning when commercetools marks an endpoint
+// as deprecated, so breaking changes of the platform show up in the output of
+// the plan. Every notice is logged once per endpoint.
+type apiDeprecationTransport struct {
+	base http.RoundTripper
+
+	mu     sync.Mutex
+	logged map[string]bool
+}
+
+func newAPIDeprecationTransport(base http.RoundTripper) *apiDeprecationTransport {
+	return &apiDeprecationTransport{base: base, logged: map[string]bool{}}
+}
+
+func (t *apiDeprecationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	notice := apiDeprecationNotice(resp.Header)
+	if notice == "" {
+		return resp, err
+	}
+
+	endpoint := auditLogResource(req.URL.Path)
+	key := endpoint + "\n" + notice
+	t.mu.Lock()
+	logged := t.logged[key]
+	t.logged[key] = true
+	t.mu.Unlock()
+
+	if !logged {
+		log.Printf("[WARN] commercetools announced a deprecation of the %s endpoint: %s", endpoint, notice)
+	}
+	return resp, err
+}
+
+// apiDeprecationNotice returns the deprecation headers of the response, or
+// an empty string when the endpoint is not deprecated. A Warning or Link
+// header alone doesn't mark the endpoint as deprecated.
+func apiDeprecationNotice(header http.Header) string {
+	if header.Get("Deprecation") == "" && header.Get("Sunset") == "" {
+		return ""
+	}
+	parts := []string{}
+	for _, name := range apiDeprecationHeaders {
+		if value := strings.Join(header[http.CanonicalHeaderKey(name)], ", "); value != "" {
+			parts = append(parts, fmt.Sprintf("%s: %s", name, value))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
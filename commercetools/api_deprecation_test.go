@@ -0,0 +1,47 @@
+package commercetools
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIDeprecationNotice(t *testing.T) {
+	header := http.Header{}
+	assert.Equal(t, "", apiDeprecationNotice(header))
+
+	header.Set("Link", `<https://docs.commercetools.com/releases>; rel="deprecation"`)
+	assert.Equal(t, "", apiDeprecationNotice(header))
+
+	header.Set("Sunset", "Wed, 11 Nov 2026 23:59:59 GMT")
+	assert.Equal(t,
+		`Sunset: Wed, 11 Nov 2026 23:59:59 GMT, Link: <https://docs.commercetools.com/releases>; rel="deprecation"`,
+		apiDeprecationNotice(header))
+}
+
+func TestAPIDeprecationTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/my-project/types") {
+			w.Header().Set("Deprecation", "true")
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	transport := newAPIDeprecationTransport(http.DefaultTransport)
+	client := &http.Client{Transport: transport}
+
+	for i := 0; i < 2; i++ {
+		resp, err := client.Get(server.URL + "/my-project/types/1234")
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+	}
+	_, err := client.Get(server.URL + "/my-project/zones")
+	assert.NoError(t, err)
+
+	// The notice is only logged once for the endpoint
+	assert.Equal(t, map[string]bool{"types\nDeprecation: true": true}, transport.logged)
+}
@@ -0,0 +1,168 @@
+package commercetools
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// auditLogEntry is a single line in the audit log. Only the names of the
+// update actions are logged, never the values, so secrets like access keys
+// of subscriptions don't end up in the file. Besides the requests the create,
+// update and delete of every resource is logged with the Terraform resource
+// type, since the transport doesn't know which resource sent a request.
+type auditLogEntry struct {
+	Time          string   `json:"time"`
+	Method        string   `json:"method,omitempty"`
+	Path          string   `json:"path,omitempty"`
+	Resource      string   `json:"resource,omitempty"`
+	Operation     string   `json:"operation,omitempty"`
+	ResourceType  string   `json:"resource_type,omitempty"`
+	ID            string   `json:"id,omitempty"`
+	Key           string   `json:"key,omitempty"`
+	Actions       []string `json:"actions,omitempty"`
+	StatusCode    int      `json:"status_code,omitempty"`
+	CorrelationID string   `json:"correlation_id,omitempty"`
+	Error         string   `json:"error,omitempty"`
+}
+
+// auditLogTransport appends every mutating request to a JSON lines file
+type auditLogTransport struct {
+	base http.RoundTripper
+
+	mu   sync.Mutex
+	file *os.File
+}
+
+func newAuditLogTransport(base http.RoundTripper, filename string) (*auditLogTransport, error) {
+	file, err := os.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, err
+	}
+	return &auditLogTransport{base: base, file: file}, nil
+}
+
+func (t *auditLogTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method == "GET" || req.Method == "HEAD" {
+		return t.base.RoundTrip(req)
+	}
+
+	entry := auditLogEntry{
+		Time:     time.Now().UTC().Format(time.RFC3339),
+		Method:   req.Method,
+		Path:     req.URL.Path,
+		Resource: auditLogResource(req.URL.Path),
+	}
+
+	if req.Body != nil {
+		body, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		entry.Key, entry.Actions = auditLogBody(body)
+	}
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		entry.Error = err.Error()
+	} else {
+		entry.StatusCode = resp.StatusCode
+		entry.CorrelationID = resp.Header.Get("X-Correlation-ID")
+	}
+	t.write(entry)
+	return resp, err
+}
+
+func (t *auditLogTransport) write(entry auditLogEntry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.file.Write(append(data, '\n'))
+}
+
+// applyAuditLog wraps the create, update and delete functions of the
+// resources to log the changes of the resources with their resource type, id
+// and key
+func applyAuditLog(resources map[string]*schema.Resource) {
+	for name, resource := range resources {
+		resource.Create = auditLogFunc(name, "create", resource.Create)
+		resource.Update = auditLogFunc(name, "update", resource.Update)
+		resource.Delete = auditLogFunc(name, "delete", resource.Delete)
+	}
+}
+
+func auditLogFunc(name string, operation string, f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+	if f == nil {
+		return nil
+	}
+	return func(d *schema.ResourceData, m interface{}) error {
+		meta, ok := m.(*providerMeta)
+		if !ok || meta.auditLog == nil {
+			return f(d, m)
+		}
+
+		// The id is cleared by the delete
+		entry := auditLogEntry{
+			Operation:    operation,
+			ResourceType: name,
+			ID:           d.Id(),
+		}
+		if key, ok := d.GetOk("key"); ok {
+			entry.Key, _ = key.(string)
+		}
+
+		err := f(d, m)
+		entry.Time = time.Now().UTC().Format(time.RFC3339)
+		if operation != "delete" {
+			entry.ID = d.Id()
+		}
+		if err != nil {
+			entry.Error = err.Error()
+		}
+		meta.auditLog.write(entry)
+		return err
+	}
+}
+
+// auditLogResource returns the endpoint of the request, for example
+// `categories` for `/my-project/categories/<id>`
+func auditLogResource(path string) string {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	if len(parts) < 2 {
+		return "project"
+	}
+	return parts[1]
+}
+
+// auditLogBody returns the key of a draft and the names of the update
+// actions in the request body, all other values are left out
+func auditLogBody(body []byte) (string, []string) {
+	var data struct {
+		Key     string `json:"key"`
+		Actions []struct {
+			Action string `json:"action"`
+		} `json:"actions"`
+	}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return "", nil
+	}
+
+	var actions []string
+	for _, action := range data.Actions {
+		actions = append(actions, action.Action)
+	}
+	return data.Key, actions
+}
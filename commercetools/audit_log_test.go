@@ -0,0 +1,109 @@
+package commercetools
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditLogTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Correlation-ID", "projects-my-project-1234")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "audit")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "audit.jsonl")
+	transport, err := newAuditLogTransport(http.DefaultTransport, filename)
+	assert.NoError(t, err)
+	client := &http.Client{Transport: transport}
+
+	_, err = client.Get(server.URL + "/my-project/subscriptions/1234")
+	assert.NoError(t, err)
+
+	body := `{"version": 1, "actions": [{"action": "changeDestination", "destination": {"accessSecret": "secret"}}]}`
+	_, err = client.Post(server.URL+"/my-project/subscriptions/1234", "application/json", strings.NewReader(body))
+	assert.NoError(t, err)
+
+	data, err := ioutil.ReadFile(filename)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "secret")
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	assert.Len(t, lines, 1)
+
+	entry := auditLogEntry{}
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+	assert.Equal(t, "POST", entry.Method)
+	assert.Equal(t, "/my-project/subscriptions/1234", entry.Path)
+	assert.Equal(t, "subscriptions", entry.Resource)
+	assert.Equal(t, []string{"changeDestination"}, entry.Actions)
+	assert.Equal(t, 200, entry.StatusCode)
+	assert.Equal(t, "projects-my-project-1234", entry.CorrelationID)
+}
+
+func TestAuditLogResource(t *testing.T) {
+	assert.Equal(t, "project", auditLogResource("/my-project"))
+	assert.Equal(t, "categories", auditLogResource("/my-project/categories/1234"))
+}
+
+func TestAuditLogFunc(t *testing.T) {
+	dir, err := ioutil.TempDir("", "audit")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "audit.jsonl")
+	transport, err := newAuditLogTransport(http.DefaultTransport, filename)
+	assert.NoError(t, err)
+	meta := &providerMeta{auditLog: transport}
+
+	resource := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"key": {Type: schema.TypeString, Optional: true},
+		},
+	}
+	create := auditLogFunc("commercetools_channel", "create", func(d *schema.ResourceData, m interface{}) error {
+		d.SetId("1234")
+		return nil
+	})
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{"key": "warehouse"})
+	assert.NoError(t, create(d, meta))
+
+	del := auditLogFunc("commercetools_channel", "delete", func(d *schema.ResourceData, m interface{}) error {
+		d.SetId("")
+		return errors.New("failed")
+	})
+	assert.Error(t, del(d, meta))
+
+	data, err := ioutil.ReadFile(filename)
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	assert.Len(t, lines, 2)
+
+	entry := auditLogEntry{}
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+	assert.Equal(t, "create", entry.Operation)
+	assert.Equal(t, "commercetools_channel", entry.ResourceType)
+	assert.Equal(t, "1234", entry.ID)
+	assert.Equal(t, "warehouse", entry.Key)
+	assert.Empty(t, entry.Error)
+
+	entry = auditLogEntry{}
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &entry))
+	assert.Equal(t, "delete", entry.Operation)
+	assert.Equal(t, "1234", entry.ID)
+	assert.Equal(t, "failed", entry.Error)
+}
@@ -0,0 +1,131 @@
+package commercetools
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// circuitBreakerCooldown is the time after which an open circuit breaker lets
+// a single request through to check if the endpoint recovered
+const circuitBreakerCooldown = 30 * time.Second
+
+// circuitBreakerTransport stops sending requests to an endpoint of the API
+// after a number of consecutive failures. Without it every resource retries
+// its requests on its own, which turns an outage of the API into a very long
+// running apply. A failure is an error of the transport or a 5xx response,
+// any other response resets the count of the endpoint. After the cooldown a
+// single request is sent again, when it succeeds the endpoint is closed again.
+type circuitBreakerTransport struct {
+	base      http.RoundTripper
+	threshold int
+	cooldown  time.Duration
+
+	mu        sync.Mutex
+	endpoints map[string]*circuitBreakerEndpoint
+}
+
+// circuitBreakerEndpoint is the state of a single endpoint
+type circuitBreakerEndpoint struct {
+	Endpoint  string
+	Failures  int
+	LastError string
+	openedAt  time.Time
+	trial     bool
+}
+
+// circuitOpenError is returned for requests to an endpoint after the circuit
+// breaker opened, these errors are not retried. The error lists all open
+// endpoints, so a single error shows the extent of the outage.
+type circuitOpenError struct {
+	Endpoints []circuitBreakerEndpoint
+}
+
+func (e *circuitOpenError) Error() string {
+	lines := make([]string, len(e.Endpoints))
+	for i, endpoint := range e.Endpoints {
+		lines[i] = fmt.Sprintf(
+			"%s: %d consecutive failures, last failure: %s",
+			endpoint.Endpoint, endpoint.Failures, endpoint.LastError)
+	}
+	return fmt.Sprintf(
+		"not sending requests to %d endpoint(s) of the commercetools API after consecutive failures:\n  - %s",
+		len(e.Endpoints), strings.Join(lines, "\n  - "))
+}
+
+func newCircuitBreakerTransport(base http.RoundTripper, threshold int) *circuitBreakerTransport {
+	return &circuitBreakerTransport{
+		base:      base,
+		threshold: threshold,
+		cooldown:  circuitBreakerCooldown,
+		endpoints: map[string]*circuitBreakerEndpoint{},
+	}
+}
+
+func (t *circuitBreakerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	endpoint := auditLogResource(req.URL.Path)
+
+	t.mu.Lock()
+	state, ok := t.endpoints[endpoint]
+	if !ok {
+		state = &circuitBreakerEndpoint{Endpoint: endpoint}
+		t.endpoints[endpoint] = state
+	}
+	if state.Failures >= t.threshold {
+		if state.trial || time.Since(state.openedAt) < t.cooldown {
+			err := t.openError()
+			t.mu.Unlock()
+			return nil, err
+		}
+		// Let a single request through to check if the endpoint recovered
+		log.Printf("[DEBUG] Sending a trial request to the %s endpoint of the commercetools API", endpoint)
+		state.trial = true
+	}
+	t.mu.Unlock()
+
+	resp, err := t.base.RoundTrip(req)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	state.trial = false
+	switch {
+	case err != nil:
+		t.recordFailure(state, err.Error())
+	case resp.StatusCode >= 500:
+		t.recordFailure(state, resp.Status)
+	default:
+		state.Failures = 0
+	}
+	return resp, err
+}
+
+func (t *circuitBreakerTransport) recordFailure(state *circuitBreakerEndpoint, message string) {
+	state.Failures++
+	state.LastError = message
+	if state.Failures >= t.threshold {
+		state.openedAt = time.Now()
+	}
+	if state.Failures == t.threshold {
+		log.Printf(
+			"[WARN] The %s endpoint of the commercetools API failed %d consecutive times, not sending further requests for %s",
+			state.Endpoint, t.threshold, t.cooldown)
+	}
+}
+
+// openError returns the error for all open endpoints, the lock must be held
+func (t *circuitBreakerTransport) openError() *circuitOpenError {
+	result := &circuitOpenError{}
+	for _, state := range t.endpoints {
+		if state.Failures >= t.threshold {
+			result.Endpoints = append(result.Endpoints, *state)
+		}
+	}
+	sort.Slice(result.Endpoints, func(i, j int) bool {
+		return result.Endpoints[i].Endpoint < result.Endpoints[j].Endpoint
+	})
+	return result
+}
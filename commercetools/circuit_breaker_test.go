@@ -0,0 +1,96 @@
+package commercetools
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCircuitBreakerTransport(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path == "/my-project/types" {
+			w.WriteHeader(503)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newCircuitBreakerTransport(http.DefaultTransport, 3)}
+
+	for i := 0; i < 3; i++ {
+		resp, err := client.Get(server.URL + "/my-project/types")
+		assert.NoError(t, err)
+		assert.Equal(t, 503, resp.StatusCode)
+	}
+
+	_, err := client.Get(server.URL + "/my-project/types")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "not sending requests to 1 endpoint(s) of the commercetools API")
+	assert.Contains(t, err.Error(), "types: 3 consecutive failures, last failure: 503 Service Unavailable")
+	assert.Equal(t, 3, requests)
+
+	// Other endpoints are not affected
+	resp, err := client.Get(server.URL + "/my-project/zones")
+	assert.NoError(t, err)
+	assert.Equal(t, 200, resp.StatusCode)
+
+	// The error isn't retried, also when wrapped by the http client
+	retryErr := handleCommercetoolsError(&url.Error{Op: "Get", URL: "types", Err: &circuitOpenError{}})
+	assert.False(t, retryErr.Retryable)
+}
+
+func TestCircuitBreakerTransportHalfOpen(t *testing.T) {
+	failing := true
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if failing {
+			w.WriteHeader(503)
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	transport := newCircuitBreakerTransport(http.DefaultTransport, 2)
+	transport.cooldown = 10 * time.Millisecond
+	client := &http.Client{Transport: transport}
+
+	client.Get(server.URL + "/my-project/types")
+	client.Get(server.URL + "/my-project/zones")
+	client.Get(server.URL + "/my-project/types")
+	client.Get(server.URL + "/my-project/zones")
+
+	// A single error lists all open endpoints
+	_, err := client.Get(server.URL + "/my-project/types")
+	assert.Contains(t, err.Error(), "not sending requests to 2 endpoint(s)")
+	assert.Contains(t, err.Error(), "  - types: 2 consecutive failures")
+	assert.Contains(t, err.Error(), "  - zones: 2 consecutive failures")
+	assert.Equal(t, 4, requests)
+
+	// After the cooldown a failing trial request opens the endpoint again
+	time.Sleep(20 * time.Millisecond)
+	resp, err := client.Get(server.URL + "/my-project/types")
+	assert.NoError(t, err)
+	assert.Equal(t, 503, resp.StatusCode)
+	_, err = client.Get(server.URL + "/my-project/types")
+	assert.Error(t, err)
+	assert.Equal(t, 5, requests)
+
+	// A successful trial request closes it
+	failing = false
+	time.Sleep(20 * time.Millisecond)
+	for i := 0; i < 2; i++ {
+		resp, err = client.Get(server.URL + "/my-project/types")
+		assert.NoError(t, err)
+		assert.Equal(t, 200, resp.StatusCode)
+	}
+	assert.Equal(t, 7, requests)
+}
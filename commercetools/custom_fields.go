@@ -0,0 +1,281 @@
+package commercetools
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/labd/commercetools-go-sdk/commercetools"
+)
+
+// customFieldSchema returns the schema for the `custom` block which can be
+// used on every resource which supports custom fields. The values of the
+// fields map are passed as plain strings for the fields of the type with a
+// string value (String, Enum, LocalizedEnum, Date, Time and DateTime), the
+// values of other fields are decoded as JSON.
+//
+// The type can be referenced by id or by key. When it is referenced by key
+// the key of the type is read back from commercetools, so when the type is
+// replaced by a new type with the same key the resource is updated to use
+// the new type.
+func customFieldSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"type_id": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Computed:     true,
+					ExactlyOneOf: []string{"custom.0.type_id", "custom.0.type_key"},
+				},
+				"type_key": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Computed:     true,
+					ExactlyOneOf: []string{"custom.0.type_id", "custom.0.type_key"},
+				},
+				"fields": {
+					Type:             schema.TypeMap,
+					Optional:         true,
+					Elem:             &schema.Schema{Type: schema.TypeString},
+					DiffSuppressFunc: diffSuppressEquivalentJSON,
+				},
+			},
+		},
+	}
+}
+
+// customFieldsReadOption expands the type of the custom fields so the key of
+// the type can be read
+var customFieldsReadOption = commercetools.WithReferenceExpansion("custom.type")
+
+// expandCustomFieldsType returns the identifier of the type, referenced by
+// key when it is set
+func expandCustomFieldsType(d *schema.ResourceData) *commercetools.TypeResourceIdentifier {
+	typeID, typeKey, _ := _customFieldsFromState(d.Get("custom").([]interface{}))
+	return _customFieldsTypeIdentifier(typeID, typeKey)
+}
+
+func expandCustomFieldsContainer(d *schema.ResourceData, stringFields map[string]bool) *commercetools.FieldContainer {
+	input := d.Get("custom").([]interface{})
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+	raw := input[0].(map[string]interface{})["fields"].(map[string]interface{})
+	container := make(commercetools.FieldContainer, len(raw))
+	for key, value := range raw {
+		container[key] = _decodeCustomFieldValue(value.(string), stringFields[key])
+	}
+	return &container
+}
+
+// expandCustomFields returns the custom fields for drafts which only accept
+// a reference to the type by id (for example the customer group draft). When
+// the type is referenced by key it is resolved to the id first.
+func expandCustomFields(d *schema.ResourceData, client *commercetools.Client) (*commercetools.CustomFields, error) {
+	identifier := expandCustomFieldsType(d)
+	if identifier == nil {
+		return nil, nil
+	}
+
+	customType, err := getCustomFieldsType(client, identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	return &commercetools.CustomFields{
+		Type:   &commercetools.TypeReference{ID: customType.ID},
+		Fields: expandCustomFieldsContainer(d, _customFieldsStringFields(customType)),
+	}, nil
+}
+
+func expandCustomFieldsDraft(d *schema.ResourceData, client *commercetools.Client) (*commercetools.CustomFieldsDraft, error) {
+	identifier := expandCustomFieldsType(d)
+	if identifier == nil {
+		return nil, nil
+	}
+
+	customType, err := getCustomFieldsType(client, identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	return &commercetools.CustomFieldsDraft{
+		Type:   identifier,
+		Fields: expandCustomFieldsContainer(d, _customFieldsStringFields(customType)),
+	}, nil
+}
+
+// getCustomFieldsType returns the type of the custom fields, the field
+// definitions determine how the values are encoded
+func getCustomFieldsType(client *commercetools.Client, identifier *commercetools.TypeResourceIdentifier) (*commercetools.Type, error) {
+	if identifier.Key != "" {
+		return client.TypeGetWithKey(context.Background(), identifier.Key)
+	}
+	return client.TypeGetWithID(context.Background(), identifier.ID)
+}
+
+func flattenCustomFields(custom *commercetools.CustomFields) []map[string]interface{} {
+	if custom == nil || custom.Type == nil {
+		return []map[string]interface{}{}
+	}
+
+	fields := make(map[string]interface{})
+	if custom.Fields != nil {
+		for key, value := range *custom.Fields {
+			if key == labelsFieldName {
+				// The labels are stored in the `labels` attribute
+				continue
+			}
+			fields[key] = _encodeCustomFieldValue(value)
+		}
+	}
+
+	typeKey := ""
+	if custom.Type.Obj != nil {
+		typeKey = custom.Type.Obj.Key
+	}
+
+	return []map[string]interface{}{
+		{
+			"type_id":  custom.Type.ID,
+			"type_key": typeKey,
+			"fields":   fields,
+		},
+	}
+}
+
+// customFieldChanges describes the changes between the old and new custom
+// block. When the type is changed (or removed) all fields are set at once
+// via the setCustomType action, otherwise only the fields which are changed
+// need to be updated via setCustomField.
+type customFieldChanges struct {
+	TypeChanged bool
+	Type        *commercetools.TypeResourceIdentifier
+	Fields      *commercetools.FieldContainer
+	Changed     map[string]interface{}
+}
+
+func resourceCustomFieldChanges(d *schema.ResourceData, client *commercetools.Client) (*customFieldChanges, error) {
+	if !d.HasChange("custom") {
+		return nil, nil
+	}
+
+	old, new := d.GetChange("custom")
+	oldTypeID, oldTypeKey, oldFields := _customFieldsFromState(old.([]interface{}))
+	newTypeID, newTypeKey, newFields := _customFieldsFromState(new.([]interface{}))
+
+	stringFields := map[string]bool{}
+	if identifier := _customFieldsTypeIdentifier(newTypeID, newTypeKey); identifier != nil {
+		customType, err := getCustomFieldsType(client, identifier)
+		if err != nil {
+			return nil, err
+		}
+		stringFields = _customFieldsStringFields(customType)
+	}
+
+	changes := &customFieldChanges{}
+	if oldTypeKey != newTypeKey && newTypeKey != "" {
+		// The type referenced by key is changed or replaced by a new type
+		// with the same key, reference the type by key only since the id in
+		// the state belongs to the previous type.
+		changes.TypeChanged = true
+		changes.Type = &commercetools.TypeResourceIdentifier{Key: newTypeKey}
+		changes.Fields = expandCustomFieldsContainer(d, stringFields)
+		return changes, nil
+	}
+	if oldTypeID != newTypeID {
+		changes.TypeChanged = true
+		changes.Type = _customFieldsTypeIdentifier(newTypeID, "")
+		if changes.Type != nil {
+			changes.Fields = expandCustomFieldsContainer(d, stringFields)
+		}
+		return changes, nil
+	}
+
+	changes.Changed = make(map[string]interface{})
+	for key, value := range newFields {
+		oldValue, ok := oldFields[key]
+		if !ok || !diffSuppressEquivalentJSON(key, oldValue.(string), value.(string), nil) {
+			changes.Changed[key] = _decodeCustomFieldValue(value.(string), stringFields[key])
+		}
+	}
+	for key := range oldFields {
+		if _, ok := newFields[key]; !ok {
+			// A nil value removes the field
+			changes.Changed[key] = nil
+		}
+	}
+	return changes, nil
+}
+
+func _customFieldsFromState(input []interface{}) (string, string, map[string]interface{}) {
+	if len(input) == 0 || input[0] == nil {
+		return "", "", map[string]interface{}{}
+	}
+	raw := input[0].(map[string]interface{})
+	typeKey, _ := raw["type_key"].(string)
+	fields, _ := raw["fields"].(map[string]interface{})
+	if fields == nil {
+		fields = map[string]interface{}{}
+	}
+	return raw["type_id"].(string), typeKey, fields
+}
+
+func _customFieldsTypeIdentifier(typeID string, typeKey string) *commercetools.TypeResourceIdentifier {
+	switch {
+	case typeKey != "":
+		return &commercetools.TypeResourceIdentifier{Key: typeKey}
+	case typeID != "":
+		return &commercetools.TypeResourceIdentifier{ID: typeID}
+	}
+	return nil
+}
+
+// _customFieldsStringFields returns the fields of the type whose values are
+// strings, these values are passed as is instead of decoding them as JSON
+func _customFieldsStringFields(customType *commercetools.Type) map[string]bool {
+	result := map[string]bool{}
+	if customType == nil {
+		return result
+	}
+	for _, field := range customType.FieldDefinitions {
+		switch field.Type.(type) {
+		case commercetools.CustomFieldStringType,
+			commercetools.CustomFieldEnumType,
+			commercetools.CustomFieldLocalizedEnumType,
+			commercetools.CustomFieldDateType,
+			commercetools.CustomFieldTimeType,
+			commercetools.CustomFieldDateTimeType:
+			result[field.Name] = true
+		}
+	}
+	return result
+}
+
+// _decodeCustomFieldValue returns the value of a custom field, the values of
+// fields without a string value are decoded as JSON when possible
+func _decodeCustomFieldValue(value string, isString bool) interface{} {
+	if isString {
+		return value
+	}
+	var data interface{}
+	if err := json.Unmarshal([]byte(value), &data); err != nil {
+		return value
+	}
+	return data
+}
+
+func _encodeCustomFieldValue(value interface{}) string {
+	if s, ok := value.(string); ok {
+		return s
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
@@ -0,0 +1,117 @@
+package commercetools
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/labd/commercetools-go-sdk/commercetools"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeCustomFieldValue(t *testing.T) {
+	assert.Equal(t, "plain value", _decodeCustomFieldValue("plain value", false))
+	assert.Equal(t, float64(10), _decodeCustomFieldValue("10", false))
+	assert.Equal(t, true, _decodeCustomFieldValue("true", false))
+	assert.Equal(t, map[string]interface{}{"en": "Hello"}, _decodeCustomFieldValue(`{"en": "Hello"}`, false))
+
+	// The values of string fields are never decoded
+	assert.Equal(t, "10", _decodeCustomFieldValue("10", true))
+	assert.Equal(t, "true", _decodeCustomFieldValue("true", true))
+}
+
+func TestCustomFieldsStringFields(t *testing.T) {
+	customType := &commercetools.Type{
+		FieldDefinitions: []commercetools.FieldDefinition{
+			{Name: "code", Type: commercetools.CustomFieldStringType{}},
+			{Name: "size", Type: commercetools.CustomFieldEnumType{}},
+			{Name: "since", Type: commercetools.CustomFieldDateType{}},
+			{Name: "amount", Type: commercetools.CustomFieldNumberType{}},
+			{Name: "enabled", Type: commercetools.CustomFieldBooleanType{}},
+			{Name: "title", Type: commercetools.CustomFieldLocalizedStringType{}},
+		},
+	}
+	assert.Equal(t,
+		map[string]bool{"code": true, "size": true, "since": true},
+		_customFieldsStringFields(customType))
+	assert.Empty(t, _customFieldsStringFields(nil))
+}
+
+func TestCustomFieldsLocalizedValueDiff(t *testing.T) {
+	resource := resourceStore()
+	state := &terraform.InstanceState{
+		ID: "1234",
+		Attributes: map[string]string{
+			"id":                    "1234",
+			"key":                   "my-store",
+			"version":               "1",
+			"custom.#":              "1",
+			"custom.0.type_id":      "type-id",
+			"custom.0.type_key":     "store-fields",
+			"custom.0.fields.%":     "1",
+			"custom.0.fields.title": `{"de":"Hallo","en":"Hello"}`,
+		},
+	}
+
+	// The value read back from commercetools is compact JSON with sorted keys
+	diff, err := resource.Diff(state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"key": "my-store",
+		"custom": []interface{}{
+			map[string]interface{}{
+				"type_key": "store-fields",
+				"fields":   map[string]interface{}{"title": "{\n  \"en\": \"Hello\",\n  \"de\": \"Hallo\"\n}"},
+			},
+		},
+	}), nil)
+	assert.NoError(t, err)
+	assert.Nil(t, diff)
+
+	diff, err = resource.Diff(state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"key": "my-store",
+		"custom": []interface{}{
+			map[string]interface{}{
+				"type_key": "store-fields",
+				"fields":   map[string]interface{}{"title": `{"en": "Hi", "de": "Hallo"}`},
+			},
+		},
+	}), nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, diff)
+}
+
+func TestFlattenCustomFields(t *testing.T) {
+	assert.Empty(t, flattenCustomFields(nil))
+
+	custom := &commercetools.CustomFields{
+		Type: &commercetools.TypeReference{
+			ID:  "type-id",
+			Obj: &commercetools.Type{ID: "type-id", Key: "type-key"},
+		},
+		Fields: &commercetools.FieldContainer{
+			"name":    "value",
+			"amount":  float64(10),
+			"enabled": true,
+		},
+	}
+	result := flattenCustomFields(custom)
+	assert.Equal(t, []map[string]interface{}{
+		{
+			"type_id":  "type-id",
+			"type_key": "type-key",
+			"fields": map[string]interface{}{
+				"name":    "value",
+				"amount":  "10",
+				"enabled": "true",
+			},
+		},
+	}, result)
+}
+
+func TestCustomFieldsTypeIdentifier(t *testing.T) {
+	assert.Nil(t, _customFieldsTypeIdentifier("", ""))
+	assert.Equal(t,
+		&commercetools.TypeResourceIdentifier{ID: "type-id"},
+		_customFieldsTypeIdentifier("type-id", ""))
+	assert.Equal(t,
+		&commercetools.TypeResourceIdentifier{Key: "type-key"},
+		_customFieldsTypeIdentifier("type-id", "type-key"))
+}
@@ -0,0 +1,94 @@
+package commercetools
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"io"
+)
+
+// customObjectEncryptionAlgorithm is stored next to the encrypted value, so
+// the format can be changed later on
+const customObjectEncryptionAlgorithm = "AES-256-GCM"
+
+// encryptedCustomObjectValue is the value stored in commercetools for an
+// encrypted custom object. The container and key of the object are used as
+// additional data, so the value can't be copied to another object.
+type encryptedCustomObjectValue struct {
+	Algorithm string `json:"algorithm"`
+	Encrypted string `json:"encrypted"`
+}
+
+// expandCustomObjectEncryptionKey decodes the base64 encoded key of the
+// provider configuration, which needs to be 32 bytes for AES-256
+func expandCustomObjectEncryptionKey(input string) ([]byte, error) {
+	if input == "" {
+		return nil, nil
+	}
+	key, err := base64.StdEncoding.DecodeString(input)
+	if err != nil {
+		return nil, fmt.Errorf("custom_object_encryption_key is not base64 encoded: %s", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("custom_object_encryption_key needs to be 32 bytes, got %d bytes", len(key))
+	}
+	return key, nil
+}
+
+func encryptCustomObjectValue(key []byte, container string, objectKey string, value string) (*encryptedCustomObjectValue, error) {
+	gcm, err := customObjectCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+
+	sealed := gcm.Seal(nonce, nonce, []byte(value), customObjectAdditionalData(container, objectKey))
+	return &encryptedCustomObjectValue{
+		Algorithm: customObjectEncryptionAlgorithm,
+		Encrypted: base64.StdEncoding.EncodeToString(sealed),
+	}, nil
+}
+
+func decryptCustomObjectValue(key []byte, container string, objectKey string, value *encryptedCustomObjectValue) (string, error) {
+	if value.Algorithm != customObjectEncryptionAlgorithm {
+		return "", fmt.Errorf("unsupported encryption algorithm %s", value.Algorithm)
+	}
+	gcm, err := customObjectCipher(key)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := base64.StdEncoding.DecodeString(value.Encrypted)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return "", fmt.Errorf("the encrypted value is too short")
+	}
+
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, customObjectAdditionalData(container, objectKey))
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt the value of custom object %s/%s, was it encrypted with another key?", container, objectKey)
+	}
+	return string(plaintext), nil
+}
+
+func customObjectCipher(key []byte) (cipher.AEAD, error) {
+	if len(key) == 0 {
+		return nil, fmt.Errorf("encrypting custom objects requires the custom_object_encryption_key of the provider")
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func customObjectAdditionalData(container string, objectKey string) []byte {
+	return []byte(container + "/" + objectKey)
+}
@@ -0,0 +1,89 @@
+package commercetools
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/labd/commercetools-go-sdk/commercetools"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandCustomObjectEncryptionKey(t *testing.T) {
+	key, err := expandCustomObjectEncryptionKey("")
+	assert.NoError(t, err)
+	assert.Nil(t, key)
+
+	key, err = expandCustomObjectEncryptionKey(base64.StdEncoding.EncodeToString(make([]byte, 32)))
+	assert.NoError(t, err)
+	assert.Len(t, key, 32)
+
+	_, err = expandCustomObjectEncryptionKey(base64.StdEncoding.EncodeToString(make([]byte, 16)))
+	assert.EqualError(t, err, "custom_object_encryption_key needs to be 32 bytes, got 16 bytes")
+
+	_, err = expandCustomObjectEncryptionKey("not base64!")
+	assert.Error(t, err)
+}
+
+func TestEncryptCustomObjectValue(t *testing.T) {
+	key := []byte(strings.Repeat("k", 32))
+
+	encrypted, err := encryptCustomObjectValue(key, "settings", "payment", `{"secret": "s3cr3t"}`)
+	assert.NoError(t, err)
+	assert.Equal(t, "AES-256-GCM", encrypted.Algorithm)
+	assert.NotContains(t, encrypted.Encrypted, "s3cr3t")
+
+	value, err := decryptCustomObjectValue(key, "settings", "payment", encrypted)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"secret": "s3cr3t"}`, value)
+
+	// The value is bound to the object and the key
+	_, err = decryptCustomObjectValue(key, "settings", "other", encrypted)
+	assert.Error(t, err)
+	_, err = decryptCustomObjectValue([]byte(strings.Repeat("x", 32)), "settings", "payment", encrypted)
+	assert.Error(t, err)
+
+	_, err = encryptCustomObjectValue(nil, "settings", "payment", `{}`)
+	assert.EqualError(t, err, "encrypting custom objects requires the custom_object_encryption_key of the provider")
+}
+
+func TestResourceCustomObjectReadEncrypted(t *testing.T) {
+	key := []byte(strings.Repeat("k", 32))
+	encrypted, err := encryptCustomObjectValue(key, "settings", "payment", `{"secret":"s3cr3t"}`)
+	assert.NoError(t, err)
+	stored, err := json.Marshal(encrypted)
+	assert.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/my-project/custom-objects/settings/payment", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id": "1234", "version": 3, "container": "settings", "key": "payment", "value": %s}`, stored)
+	}))
+	defer server.Close()
+
+	meta := &providerMeta{
+		client: commercetools.New(&commercetools.Config{
+			ProjectKey: "my-project",
+			URL:        server.URL,
+			HTTPClient: server.Client(),
+		}),
+		customObjectEncryptionKey: key,
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceCustomObject().Schema, map[string]interface{}{
+		"container": "settings",
+		"key":       "payment",
+		"value":     `{"secret":"old"}`,
+		"encrypted": true,
+	})
+	d.SetId("1234")
+
+	assert.NoError(t, resourceCustomObjectRead(d, meta))
+	assert.Equal(t, `{"secret":"s3cr3t"}`, d.Get("value"))
+	assert.Equal(t, 3, d.Get("version"))
+}
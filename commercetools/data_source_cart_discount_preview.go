@@ -0,0 +1,227 @@
+package commercetools
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+// The preview only needs a small part of the cart, so the cart is decoded
+// into the types below instead of the types of the commercetools-go-sdk.
+
+type previewMoney struct {
+	CentAmount   int    `json:"centAmount"`
+	CurrencyCode string `json:"currencyCode"`
+}
+
+type previewReference struct {
+	ID string `json:"id"`
+}
+
+type previewCart struct {
+	ID         string       `json:"id"`
+	Version    int          `json:"version"`
+	TotalPrice previewMoney `json:"totalPrice"`
+	LineItems  []struct {
+		Variant struct {
+			SKU string `json:"sku"`
+		} `json:"variant"`
+		Quantity                   int          `json:"quantity"`
+		TotalPrice                 previewMoney `json:"totalPrice"`
+		DiscountedPricePerQuantity []struct {
+			DiscountedPrice struct {
+				IncludedDiscounts []struct {
+					Discount previewReference `json:"discount"`
+				} `json:"includedDiscounts"`
+			} `json:"discountedPrice"`
+		} `json:"discountedPricePerQuantity"`
+	} `json:"lineItems"`
+	DiscountCodes []struct {
+		DiscountCode previewReference `json:"discountCode"`
+		State        string           `json:"state"`
+	} `json:"discountCodes"`
+}
+
+// dataSourceCartDiscountPreview creates a cart with the given line items and
+// discount codes, reports the discounts which are applied and deletes the
+// cart again. Use this as a check after changing cart discounts.
+func dataSourceCartDiscountPreview() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceCartDiscountPreviewRead,
+		Schema: map[string]*schema.Schema{
+			"currency": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"country": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The country of the cart, used for the prices and the shipping address",
+			},
+			"discount_codes": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"line_item": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"sku": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"quantity": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1,
+							ValidateFunc: validation.IntAtLeast(1),
+						},
+						"total_price_cent_amount": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"total_price_cent_amount": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"applied_cart_discount_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The ids of the cart discounts applied to the line items",
+			},
+			"discount_code_states": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The state of each discount code, for example MatchesCart or DoesNotMatchCart",
+			},
+		},
+	}
+}
+
+func dataSourceCartDiscountPreviewRead(d *schema.ResourceData, m interface{}) error {
+	client := getRestClient(m)
+	ctx := context.Background()
+
+	lineItems := []map[string]interface{}{}
+	for _, raw := range d.Get("line_item").([]interface{}) {
+		item := raw.(map[string]interface{})
+		lineItems = append(lineItems, map[string]interface{}{
+			"sku":      item["sku"],
+			"quantity": item["quantity"],
+		})
+	}
+	draft := map[string]interface{}{
+		"currency":  d.Get("currency").(string),
+		"lineItems": lineItems,
+	}
+	if country := d.Get("country").(string); country != "" {
+		draft["country"] = country
+		draft["shippingAddress"] = map[string]string{"country": country}
+	}
+
+	cart := &previewCart{}
+	if err := client.create(ctx, "carts", nil, draft, cart); err != nil {
+		return fmt.Errorf("failed to create the preview cart: %w", err)
+	}
+	// The cart is only needed for the preview, so it is always deleted
+	defer func() {
+		query := url.Values{}
+		query.Set("version", strconv.Itoa(cart.Version))
+		if err := client.delete(ctx, fmt.Sprintf("carts/%s", cart.ID), query, nil); err != nil {
+			log.Printf("[WARN] Failed to delete preview cart %s: %s", cart.ID, err)
+		}
+	}()
+
+	codes := expandStringArray(d.Get("discount_codes").([]interface{}))
+	if len(codes) > 0 {
+		actions := make([]map[string]interface{}, len(codes))
+		for i, code := range codes {
+			actions[i] = map[string]interface{}{"action": "addDiscountCode", "code": code}
+		}
+		updated := &previewCart{}
+		err := client.update(ctx, fmt.Sprintf("carts/%s", cart.ID), nil, cart.Version, actions, updated)
+		if err != nil {
+			return fmt.Errorf("failed to add the discount codes to the preview cart: %w", err)
+		}
+		cart = updated
+	}
+
+	// The codes are referenced by id in the cart
+	codeStates := map[string]string{}
+	for _, code := range cart.DiscountCodes {
+		codeStates[code.DiscountCode.ID] = code.State
+	}
+	states := map[string]string{}
+	for _, code := range codes {
+		id, err := previewDiscountCodeID(client, code)
+		if err != nil {
+			return err
+		}
+		states[code] = codeStates[id]
+	}
+
+	itemTotals := make([]interface{}, len(cart.LineItems))
+	for i, item := range cart.LineItems {
+		itemTotals[i] = map[string]interface{}{
+			"sku":                     item.Variant.SKU,
+			"quantity":                item.Quantity,
+			"total_price_cent_amount": item.TotalPrice.CentAmount,
+		}
+	}
+
+	d.SetId(cart.ID)
+	d.Set("total_price_cent_amount", cart.TotalPrice.CentAmount)
+	d.Set("applied_cart_discount_ids", previewAppliedDiscounts(cart))
+	d.Set("discount_code_states", states)
+	return d.Set("line_item", itemTotals)
+}
+
+// previewAppliedDiscounts returns the sorted ids of the cart discounts
+// included in the discounted prices of the line items
+func previewAppliedDiscounts(cart *previewCart) []string {
+	lookup := map[string]bool{}
+	for _, item := range cart.LineItems {
+		for _, price := range item.DiscountedPricePerQuantity {
+			for _, included := range price.DiscountedPrice.IncludedDiscounts {
+				lookup[included.Discount.ID] = true
+			}
+		}
+	}
+	ids := []string{}
+	for id := range lookup {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func previewDiscountCodeID(client *restClient, code string) (string, error) {
+	query := url.Values{}
+	query.Set("where", fmt.Sprintf("code = %q", code))
+	query.Set("limit", "1")
+
+	result := struct {
+		Results []previewReference `json:"results"`
+	}{}
+	if err := client.get(context.Background(), "discount-codes", query, &result); err != nil {
+		return "", err
+	}
+	if len(result.Results) == 0 {
+		return "", fmt.Errorf("discount code %s does not exist", code)
+	}
+	return result.Results[0].ID, nil
+}
@@ -0,0 +1,73 @@
+package commercetools
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDataSourceCartDiscountPreviewRead(t *testing.T) {
+	cart := `{
+		"id": "cart-id",
+		"version": %d,
+		"totalPrice": {"centAmount": 1800, "currencyCode": "EUR"},
+		"lineItems": [{
+			"variant": {"sku": "shirt"},
+			"quantity": 2,
+			"totalPrice": {"centAmount": 1800, "currencyCode": "EUR"},
+			"discountedPricePerQuantity": [{
+				"discountedPrice": {"includedDiscounts": [
+					{"discount": {"typeId": "cart-discount", "id": "summer"}},
+					{"discount": {"typeId": "cart-discount", "id": "members"}}
+				]}
+			}]
+		}],
+		"discountCodes": [{"discountCode": {"typeId": "discount-code", "id": "code-id"}, "state": "MatchesCart"}]
+	}`
+	deleted := false
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/my-project/carts":
+			body, _ := ioutil.ReadAll(r.Body)
+			draft := map[string]interface{}{}
+			assert.NoError(t, json.Unmarshal(body, &draft))
+			assert.Equal(t, "EUR", draft["currency"])
+			w.Write([]byte(`{"id": "cart-id", "version": 1}`))
+		case r.Method == "POST" && r.URL.Path == "/my-project/carts/cart-id":
+			w.Write([]byte(strings.Replace(cart, "%d", "2", 1)))
+		case r.Method == "GET" && r.URL.Path == "/my-project/discount-codes":
+			assert.Equal(t, `code = "SUMMER"`, r.URL.Query().Get("where"))
+			w.Write([]byte(`{"results": [{"id": "code-id"}]}`))
+		case r.Method == "DELETE" && r.URL.Path == "/my-project/carts/cart-id":
+			assert.Equal(t, "2", r.URL.Query().Get("version"))
+			deleted = true
+			w.Write([]byte(`{}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, dataSourceCartDiscountPreview().Schema, map[string]interface{}{
+		"currency":       "EUR",
+		"discount_codes": []interface{}{"SUMMER"},
+		"line_item": []interface{}{
+			map[string]interface{}{"sku": "shirt", "quantity": 2},
+		},
+	})
+	meta := &providerMeta{rest: newRestClient(server.Client(), server.URL, "my-project")}
+
+	assert.NoError(t, dataSourceCartDiscountPreviewRead(d, meta))
+	assert.True(t, deleted)
+	assert.Equal(t, 1800, d.Get("total_price_cent_amount"))
+	assert.Equal(t, []interface{}{"members", "summer"}, d.Get("applied_cart_discount_ids"))
+	assert.Equal(t, map[string]interface{}{"SUMMER": "MatchesCart"}, d.Get("discount_code_states"))
+	assert.Equal(t, 1800, d.Get("line_item.0.total_price_cent_amount"))
+}
@@ -0,0 +1,139 @@
+package commercetools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/labd/commercetools-go-sdk/commercetools"
+)
+
+const (
+	keyReferenceTypeCategory      = "category"
+	keyReferenceTypeCustomerGroup = "customer-group"
+)
+
+// dataSourceKeyReferences resolves the keys of categories or customer groups
+// to their ids. Predicates in commercetools only support references by id,
+// this allows writing predicates without environment specific ids.
+func dataSourceKeyReferences() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceKeyReferencesRead,
+		Schema: map[string]*schema.Schema{
+			"type_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					keyReferenceTypeCategory,
+					keyReferenceTypeCustomerGroup,
+				}, false),
+			},
+			"keys": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"quoted_ids": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"predicate": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceKeyReferencesRead(d *schema.ResourceData, m interface{}) error {
+	client := getClient(m)
+	typeID := d.Get("type_id").(string)
+	keys := expandStringArray(d.Get("keys").([]interface{}))
+
+	lookup, err := lookupKeyReferences(client, typeID, keys)
+	if err != nil {
+		return err
+	}
+
+	ids, err := resolveKeyReferences(typeID, keys, lookup)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s", typeID, strings.Join(keys, ",")))
+	d.Set("ids", ids)
+	d.Set("quoted_ids", quotePredicateValues(ids))
+	d.Set("predicate", keyReferencesPredicate(typeID, ids))
+	return nil
+}
+
+// lookupKeyReferences returns the ids of the resources with the given keys.
+// The keys are queried in batches of keyedPageSize, which is the maximum limit
+// of a query.
+func lookupKeyReferences(client *commercetools.Client, typeID string, keys []string) (map[string]string, error) {
+	lookup := make(map[string]string, len(keys))
+	for start := 0; start < len(keys); start += keyedPageSize {
+		end := start + keyedPageSize
+		if end > len(keys) {
+			end = len(keys)
+		}
+		input := &commercetools.QueryInput{
+			Where: fmt.Sprintf("key in (%s)", quotePredicateValues(keys[start:end])),
+			Limit: end - start,
+		}
+
+		switch typeID {
+		case keyReferenceTypeCategory:
+			result, err := client.CategoryQuery(context.Background(), input)
+			if err != nil {
+				return nil, err
+			}
+			for _, category := range result.Results {
+				lookup[category.Key] = category.ID
+			}
+		case keyReferenceTypeCustomerGroup:
+			result, err := client.CustomerGroupQuery(context.Background(), input)
+			if err != nil {
+				return nil, err
+			}
+			for _, customerGroup := range result.Results {
+				lookup[customerGroup.Key] = customerGroup.ID
+			}
+		}
+	}
+	return lookup, nil
+}
+
+// resolveKeyReferences returns the ids for the given keys in the same order.
+// An error is returned when one of the keys could not be found.
+func resolveKeyReferences(typeID string, keys []string, lookup map[string]string) ([]string, error) {
+	ids := make([]string, len(keys))
+	for i, key := range keys {
+		id, ok := lookup[key]
+		if !ok {
+			return nil, fmt.Errorf("no %s found with key %s", typeID, key)
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
+
+// keyReferencesPredicate returns the predicate fragment which matches any of
+// the given ids, for example to be used in a cart discount predicate.
+func keyReferencesPredicate(typeID string, ids []string) string {
+	switch typeID {
+	case keyReferenceTypeCategory:
+		return fmt.Sprintf("categories.id contains any (%s)", quotePredicateValues(ids))
+	case keyReferenceTypeCustomerGroup:
+		return fmt.Sprintf("customerGroup.id in (%s)", quotePredicateValues(ids))
+	}
+	return ""
+}
@@ -0,0 +1,68 @@
+package commercetools
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+
+	"github.com/labd/commercetools-go-sdk/commercetools"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveKeyReferences(t *testing.T) {
+	lookup := map[string]string{
+		"shoes":  "id-shoes",
+		"shirts": "id-shirts",
+	}
+
+	ids, err := resolveKeyReferences("category", []string{"shirts", "shoes"}, lookup)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"id-shirts", "id-shoes"}, ids)
+
+	_, err = resolveKeyReferences("category", []string{"shoes", "hats"}, lookup)
+	assert.EqualError(t, err, "no category found with key hats")
+}
+
+func TestKeyReferencesPredicate(t *testing.T) {
+	assert.Equal(t,
+		`categories.id contains any ("id-1", "id-2")`,
+		keyReferencesPredicate("category", []string{"id-1", "id-2"}))
+	assert.Equal(t,
+		`customerGroup.id in ("id-1")`,
+		keyReferencesPredicate("customer-group", []string{"id-1"}))
+}
+
+func TestLookupKeyReferencesBatches(t *testing.T) {
+	quoted := regexp.MustCompile(`"([^"]*)"`)
+	limits := []string{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		limits = append(limits, r.URL.Query().Get("limit"))
+		results := []map[string]string{}
+		for _, match := range quoted.FindAllStringSubmatch(r.URL.Query().Get("where"), -1) {
+			results = append(results, map[string]string{"id": "id-" + match[1], "key": match[1]})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"count": len(results), "results": results})
+	}))
+	defer server.Close()
+
+	client := commercetools.New(&commercetools.Config{
+		ProjectKey: "my-project",
+		URL:        server.URL,
+		HTTPClient: server.Client(),
+	})
+
+	keys := make([]string, 501)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("category-%d", i)
+	}
+	lookup, err := lookupKeyReferences(client, "category", keys)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"500", "1"}, limits)
+	assert.Len(t, lookup, 501)
+	assert.Equal(t, "id-category-500", lookup["category-500"])
+}
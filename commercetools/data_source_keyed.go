@@ -0,0 +1,247 @@
+package commercetools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/labd/commercetools-go-sdk/commercetools"
+)
+
+// keyedPageSize is the number of resources fetched per request, which is the
+// maximum page size of the commercetools API
+const keyedPageSize = 500
+
+// keyedResource is the id and key of a resource returned by one of the query
+// endpoints
+type keyedResource struct {
+	ID  string
+	Key string
+}
+
+// keyedQueryFunc fetches a single page of resources, it returns the resources
+// in the page and the total number of resources
+type keyedQueryFunc func(client *commercetools.Client, input *commercetools.QueryInput) ([]keyedResource, int, error)
+
+// dataSourceTypes returns the ids of all types keyed by their key
+func dataSourceTypes() *schema.Resource {
+	return dataSourceKeyedResources("types", nil, func(client *commercetools.Client, input *commercetools.QueryInput) ([]keyedResource, int, error) {
+		result, err := client.TypeQuery(context.Background(), input)
+		if err != nil {
+			return nil, 0, err
+		}
+		resources := make([]keyedResource, len(result.Results))
+		for i, item := range result.Results {
+			resources[i] = keyedResource{ID: item.ID, Key: item.Key}
+		}
+		return resources, result.Total, nil
+	})
+}
+
+// dataSourceStates returns the ids of all states keyed by their key,
+// optionally filtered by the type of the state
+func dataSourceStates() *schema.Resource {
+	extra := map[string]*schema.Schema{
+		"type": {
+			Type:     schema.TypeString,
+			Optional: true,
+			ValidateFunc: validation.StringInSlice([]string{
+				string(commercetools.StateTypeEnumOrderState),
+				string(commercetools.StateTypeEnumLineItemState),
+				string(commercetools.StateTypeEnumProductState),
+				string(commercetools.StateTypeEnumReviewState),
+				string(commercetools.StateTypeEnumPaymentState),
+			}, false),
+		},
+	}
+	return dataSourceKeyedResources("states", extra, func(client *commercetools.Client, input *commercetools.QueryInput) ([]keyedResource, int, error) {
+		result, err := client.StateQuery(context.Background(), input)
+		if err != nil {
+			return nil, 0, err
+		}
+		resources := make([]keyedResource, len(result.Results))
+		for i, item := range result.Results {
+			resources[i] = keyedResource{ID: item.ID, Key: item.Key}
+		}
+		return resources, result.Total, nil
+	})
+}
+
+// dataSourceZones returns the ids of all zones keyed by their key
+func dataSourceZones() *schema.Resource {
+	return dataSourceKeyedResources("zones", nil, func(client *commercetools.Client, input *commercetools.QueryInput) ([]keyedResource, int, error) {
+		result, err := client.ZoneQuery(context.Background(), input)
+		if err != nil {
+			return nil, 0, err
+		}
+		resources := make([]keyedResource, len(result.Results))
+		for i, item := range result.Results {
+			resources[i] = keyedResource{ID: item.ID, Key: item.Key}
+		}
+		return resources, result.Total, nil
+	})
+}
+
+// dataSourceCustomerGroups returns the ids of all customer groups keyed by
+// their key, optionally filtered by their labels
+func dataSourceCustomerGroups() *schema.Resource {
+	extra := map[string]*schema.Schema{
+		"labels": {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Only return the customer groups which have all these labels",
+		},
+	}
+	return dataSourceKeyedResources("customer groups", extra, func(client *commercetools.Client, input *commercetools.QueryInput) ([]keyedResource, int, error) {
+		result, err := client.CustomerGroupQuery(context.Background(), input)
+		if err != nil {
+			return nil, 0, err
+		}
+		resources := make([]keyedResource, len(result.Results))
+		for i, item := range result.Results {
+			resources[i] = keyedResource{ID: item.ID, Key: item.Key}
+		}
+		return resources, result.Total, nil
+	})
+}
+
+// dataSourceKeyedResources returns a data source which reads all resources
+// of an endpoint into an `ids` map of key to id. The map can be used directly
+// in for_each to combine resources managed in Terraform with resources
+// managed by other tools. Resources without a key are skipped.
+func dataSourceKeyedResources(name string, extra map[string]*schema.Schema, query keyedQueryFunc) *schema.Resource {
+	resourceSchema := map[string]*schema.Schema{
+		"keys": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Only return the resources with these keys",
+		},
+		"sort": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Sort expressions, for example `createdAt desc`. The results are always sorted by key and id afterwards",
+		},
+		"limit": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntAtLeast(1),
+			Description:  "The maximum number of resources to return",
+		},
+		"ids": {
+			Type:     schema.TypeMap,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+		"ordered_keys": {
+			Type:     schema.TypeList,
+			Computed: true,
+			Elem:     &schema.Schema{Type: schema.TypeString},
+		},
+	}
+	for key, value := range extra {
+		resourceSchema[key] = value
+	}
+
+	return &schema.Resource{
+		Read: func(d *schema.ResourceData, m interface{}) error {
+			client := getClient(m)
+			keys := expandStringArray(d.Get("keys").([]interface{}))
+			sort := keyedResourcesSort(expandStringArray(d.Get("sort").([]interface{})))
+			limit := d.Get("limit").(int)
+			stateType := ""
+			if _, ok := resourceSchema["type"]; ok {
+				stateType = d.Get("type").(string)
+			}
+			labels := map[string]interface{}{}
+			if _, ok := resourceSchema["labels"]; ok {
+				labels = d.Get("labels").(map[string]interface{})
+			}
+			where := keyedResourcesPredicate(keys, stateType, labels)
+
+			ids := map[string]string{}
+			orderedKeys := []string{}
+			for offset := 0; ; offset += keyedPageSize {
+				pageSize := keyedPageSize
+				if limit > 0 && limit-offset < pageSize {
+					pageSize = limit - offset
+				}
+
+				input := &commercetools.QueryInput{
+					Where:  where,
+					Sort:   sort,
+					Limit:  pageSize,
+					Offset: offset,
+				}
+				resources, total, err := query(client, input)
+				if err != nil {
+					return err
+				}
+				for _, item := range resources {
+					if item.Key != "" {
+						ids[item.Key] = item.ID
+						orderedKeys = append(orderedKeys, item.Key)
+					}
+				}
+				if len(resources) < pageSize || (total > 0 && offset+len(resources) >= total) {
+					break
+				}
+				if limit > 0 && offset+len(resources) >= limit {
+					break
+				}
+			}
+
+			for _, key := range keys {
+				if _, ok := ids[key]; !ok {
+					return fmt.Errorf("no %s found with key %s", name, key)
+				}
+			}
+
+			d.SetId(fmt.Sprintf(
+				"%s:%s:%s:%s:%d:%s", name, stateType, strings.Join(keys, ","), strings.Join(sort, ","), limit,
+				strings.Join(expandLabels(labels), ",")))
+			d.Set("ids", ids)
+			d.Set("ordered_keys", orderedKeys)
+			return nil
+		},
+		Schema: resourceSchema,
+	}
+}
+
+// keyedResourcesSort returns the sort expressions followed by sorting on key
+// and id, so the order of the results is stable between plans
+func keyedResourcesSort(sort []string) []string {
+	result := append([]string{}, sort...)
+	for _, expression := range []string{"key asc", "id asc"} {
+		found := false
+		for _, item := range sort {
+			if strings.HasPrefix(item, strings.Fields(expression)[0]+" ") {
+				found = true
+			}
+		}
+		if !found {
+			result = append(result, expression)
+		}
+	}
+	return result
+}
+
+// keyedResourcesPredicate returns the where predicate for the given keys,
+// (state) type and labels
+func keyedResourcesPredicate(keys []string, stateType string, labels map[string]interface{}) string {
+	predicates := []string{}
+	if len(keys) > 0 {
+		predicates = append(predicates, fmt.Sprintf("key in (%s)", quotePredicateValues(keys)))
+	}
+	if stateType != "" {
+		predicates = append(predicates, fmt.Sprintf("type = %q", stateType))
+	}
+	if len(labels) > 0 {
+		predicates = append(predicates, labelsPredicate(labels))
+	}
+	return strings.Join(predicates, " and ")
+}
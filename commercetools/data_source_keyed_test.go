@@ -0,0 +1,30 @@
+package commercetools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKeyedResourcesPredicate(t *testing.T) {
+	assert.Equal(t, "", keyedResourcesPredicate([]string{}, "", nil))
+	assert.Equal(t,
+		`key in ("a", "b")`,
+		keyedResourcesPredicate([]string{"a", "b"}, "", nil))
+	assert.Equal(t,
+		`key in ("a") and type = "OrderState"`,
+		keyedResourcesPredicate([]string{"a"}, "OrderState", nil))
+	assert.Equal(t,
+		`custom(fields(terraform_labels contains all ("env=prod", "team=checkout")))`,
+		keyedResourcesPredicate([]string{}, "", map[string]interface{}{"team": "checkout", "env": "prod"}))
+}
+
+func TestKeyedResourcesSort(t *testing.T) {
+	assert.Equal(t, []string{"key asc", "id asc"}, keyedResourcesSort([]string{}))
+	assert.Equal(t,
+		[]string{"createdAt desc", "key asc", "id asc"},
+		keyedResourcesSort([]string{"createdAt desc"}))
+	assert.Equal(t,
+		[]string{"key desc", "id asc"},
+		keyedResourcesSort([]string{"key desc"}))
+}
@@ -0,0 +1,125 @@
+package commercetools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/labd/commercetools-go-sdk/commercetools"
+)
+
+// dataSourceMatchingShippingMethods returns the shipping methods commercetools
+// offers for an existing cart or for a shipping location, for example to check
+// the shipping configuration after a change
+func dataSourceMatchingShippingMethods() *schema.Resource {
+	lookup := []string{"cart_id", "country"}
+	return &schema.Resource{
+		Read: dataSourceMatchingShippingMethodsRead,
+		Schema: map[string]*schema.Schema{
+			"cart_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: lookup,
+			},
+			"country": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: lookup,
+				Description:  "The country of the shipping address, when no cart is given",
+			},
+			"state": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"cart_id"},
+				Description:   "The state of the shipping address, when no cart is given",
+			},
+			"currency": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"cart_id"},
+				Description:   "Only return shipping methods with a rate in this currency, when no cart is given",
+			},
+			"shipping_methods": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"is_default": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"keys": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The keys of the matching shipping methods which have a key",
+			},
+		},
+	}
+}
+
+func dataSourceMatchingShippingMethodsRead(d *schema.ResourceData, m interface{}) error {
+	client := getRestClient(m)
+
+	endpoint, query := matchingShippingMethodsQuery(
+		d.Get("cart_id").(string), d.Get("country").(string),
+		d.Get("state").(string), d.Get("currency").(string))
+
+	result := &commercetools.ShippingMethodPagedQueryResponse{}
+	if err := client.get(context.Background(), endpoint, query, result); err != nil {
+		return err
+	}
+
+	methods := make([]map[string]interface{}, len(result.Results))
+	keys := []string{}
+	for i, method := range result.Results {
+		methods[i] = map[string]interface{}{
+			"id":         method.ID,
+			"key":        method.Key,
+			"name":       method.Name,
+			"is_default": method.IsDefault,
+		}
+		if method.Key != "" {
+			keys = append(keys, method.Key)
+		}
+	}
+
+	d.SetId(fmt.Sprintf("%s?%s", endpoint, query.Encode()))
+	d.Set("keys", keys)
+	return d.Set("shipping_methods", methods)
+}
+
+// matchingShippingMethodsQuery returns the endpoint and query to get the
+// shipping methods matching the cart, or the location when no cart is given
+func matchingShippingMethodsQuery(cartID string, country string, state string, currency string) (string, url.Values) {
+	query := url.Values{}
+	if cartID != "" {
+		query.Set("cartId", cartID)
+		return "shipping-methods/matching-cart", query
+	}
+
+	query.Set("country", country)
+	if state != "" {
+		query.Set("state", state)
+	}
+	if currency != "" {
+		query.Set("currency", currency)
+	}
+	return "shipping-methods/matching-location", query
+}
@@ -0,0 +1,20 @@
+package commercetools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchingShippingMethodsQuery(t *testing.T) {
+	endpoint, query := matchingShippingMethodsQuery("cart-id", "", "", "")
+	assert.Equal(t, "shipping-methods/matching-cart", endpoint)
+	assert.Equal(t, "cartId=cart-id", query.Encode())
+
+	endpoint, query = matchingShippingMethodsQuery("", "DE", "", "EUR")
+	assert.Equal(t, "shipping-methods/matching-location", endpoint)
+	assert.Equal(t, "country=DE&currency=EUR", query.Encode())
+
+	_, query = matchingShippingMethodsQuery("", "US", "CA", "")
+	assert.Equal(t, "country=US&state=CA", query.Encode())
+}
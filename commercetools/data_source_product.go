@@ -0,0 +1,157 @@
+package commercetools
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/labd/commercetools-go-sdk/commercetools"
+)
+
+const (
+	productProjectionCurrent = "current"
+	productProjectionStaged  = "staged"
+)
+
+// dataSourceProduct looks up a single product by key, sku or slug. Products
+// are not managed by the provider, but the id of a product is needed to
+// reference it from inventory entries, prices and product selections.
+func dataSourceProduct() *schema.Resource {
+	lookup := []string{"key", "sku", "slug"}
+	return &schema.Resource{
+		Read: dataSourceProductRead,
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: lookup,
+			},
+			"sku": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: lookup,
+				Description:  "The sku of the master variant or one of the other variants",
+			},
+			"slug": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: lookup,
+				RequiredWith: []string{"slug", "locale"},
+			},
+			"locale": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"slug", "locale"},
+				Description:  "The locale of the slug",
+			},
+			"projection": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      productProjectionCurrent,
+				ValidateFunc: validation.StringInSlice([]string{productProjectionCurrent, productProjectionStaged}, false),
+				Description:  "Read the current (published) or the staged product data",
+			},
+			"published": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"has_staged_changes": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"product_type_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"master_variant_sku": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"variant_skus": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"category_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func dataSourceProductRead(d *schema.ResourceData, m interface{}) error {
+	client := getRestClient(m)
+
+	where := productLookupPredicate(
+		d.Get("key").(string), d.Get("sku").(string),
+		d.Get("slug").(string), d.Get("locale").(string))
+
+	// The product projections are queried since the product query endpoint
+	// doesn't support querying the staged and current data separately
+	query := url.Values{}
+	query.Set("where", where)
+	query.Set("staged", strconv.FormatBool(d.Get("projection").(string) == productProjectionStaged))
+	query.Set("limit", "2")
+
+	result := &commercetools.ProductProjectionPagedQueryResponse{}
+	if err := client.get(context.Background(), "product-projections", query, result); err != nil {
+		return err
+	}
+
+	switch len(result.Results) {
+	case 0:
+		return fmt.Errorf("no product found matching %s", where)
+	case 1:
+	default:
+		return fmt.Errorf("multiple products found matching %s", where)
+	}
+
+	product := result.Results[0]
+	d.SetId(product.ID)
+	d.Set("version", product.Version)
+	d.Set("key", product.Key)
+	d.Set("published", product.Published)
+	d.Set("has_staged_changes", product.HasStagedChanges)
+	if product.ProductType != nil {
+		d.Set("product_type_id", product.ProductType.ID)
+	}
+	if product.MasterVariant != nil {
+		d.Set("master_variant_sku", product.MasterVariant.SKU)
+	}
+
+	skus := []string{}
+	for _, variant := range product.Variants {
+		if variant.SKU != "" {
+			skus = append(skus, variant.SKU)
+		}
+	}
+	d.Set("variant_skus", skus)
+
+	categoryIDs := make([]string, len(product.Categories))
+	for i, category := range product.Categories {
+		categoryIDs[i] = category.ID
+	}
+	d.Set("category_ids", categoryIDs)
+	return nil
+}
+
+// productLookupPredicate returns the predicate to find a product projection
+// by key, sku (of any variant) or the slug in the given locale
+func productLookupPredicate(key string, sku string, slug string, locale string) string {
+	switch {
+	case key != "":
+		return fmt.Sprintf("key = %q", key)
+	case sku != "":
+		return fmt.Sprintf("masterVariant(sku = %q) or variants(sku = %q)", sku, sku)
+	}
+	return fmt.Sprintf("slug(%s = %q)", locale, slug)
+}
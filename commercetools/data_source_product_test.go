@@ -0,0 +1,15 @@
+package commercetools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProductLookupPredicate(t *testing.T) {
+	assert.Equal(t, `key = "shirt"`, productLookupPredicate("shirt", "", "", ""))
+	assert.Equal(t,
+		`masterVariant(sku = "shirt-red") or variants(sku = "shirt-red")`,
+		productLookupPredicate("", "shirt-red", "", ""))
+	assert.Equal(t, `slug(en = "red-shirt")`, productLookupPredicate("", "", "red-shirt", "en"))
+}
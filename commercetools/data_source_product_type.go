@@ -0,0 +1,146 @@
+package commercetools
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/labd/commercetools-go-sdk/commercetools"
+)
+
+// dataSourceProductType looks up a product type by key, so configurations
+// can reference product types which are managed elsewhere, for example in
+// nested attributes or subscriptions
+func dataSourceProductType() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceProductTypeRead,
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"name": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"description": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"attribute": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"label": {
+							Type:     TypeLocalizedString,
+							Computed: true,
+						},
+						"required": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"constraint": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"input_hint": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"searchable": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the attribute type, for example text or set",
+						},
+						"element_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the type of the elements of a set",
+						},
+						"reference_type_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The referenced resource type of a reference (or set of references)",
+						},
+						"type_reference": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The id of the product type of a nested type (or set of nested types)",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceProductTypeRead(d *schema.ResourceData, m interface{}) error {
+	client := getClient(m)
+
+	productType, err := client.ProductTypeGetWithKey(context.Background(), d.Get("key").(string))
+	if err != nil {
+		return err
+	}
+
+	attributes, err := flattenProductTypeDataSourceAttributes(productType.Attributes)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(productType.ID)
+	d.Set("version", productType.Version)
+	d.Set("name", productType.Name)
+	d.Set("description", productType.Description)
+	return d.Set("attribute", attributes)
+}
+
+// flattenProductTypeDataSourceAttributes returns the attribute definitions
+// with the type flattened to its name and the references of the type, which
+// is what other configurations need
+func flattenProductTypeDataSourceAttributes(definitions []commercetools.AttributeDefinition) ([]map[string]interface{}, error) {
+	attributes := make([]map[string]interface{}, len(definitions))
+	for i, definition := range definitions {
+		attrType, err := resourceProductTypeReadAttributeType(definition.Type, true)
+		if err != nil {
+			return nil, err
+		}
+
+		attribute := map[string]interface{}{
+			"name":       definition.Name,
+			"required":   definition.IsRequired,
+			"constraint": string(definition.AttributeConstraint),
+			"input_hint": string(definition.InputHint),
+			"searchable": definition.IsSearchable,
+		}
+		if definition.Label != nil {
+			attribute["label"] = *definition.Label
+		}
+
+		typeData := attrType[0].(map[string]interface{})
+		attribute["type"] = typeData["name"]
+		if elementTypes, ok := typeData["element_type"].([]interface{}); ok && len(elementTypes) > 0 {
+			typeData = elementTypes[0].(map[string]interface{})
+			attribute["element_type"] = typeData["name"]
+		}
+		if referenceTypeID, ok := typeData["reference_type_id"]; ok {
+			attribute["reference_type_id"] = referenceTypeID
+		}
+		if typeReference, ok := typeData["type_reference"]; ok {
+			attribute["type_reference"] = typeReference
+		}
+		attributes[i] = attribute
+	}
+	return attributes, nil
+}
@@ -0,0 +1,46 @@
+package commercetools
+
+import (
+	"testing"
+
+	"github.com/labd/commercetools-go-sdk/commercetools"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlattenProductTypeDataSourceAttributes(t *testing.T) {
+	label := commercetools.LocalizedString{"en": "Related"}
+	attributes, err := flattenProductTypeDataSourceAttributes([]commercetools.AttributeDefinition{
+		{
+			Name:                "related",
+			Label:               &label,
+			AttributeConstraint: commercetools.AttributeConstraintEnumNone,
+			InputHint:           commercetools.TextInputHintSingleLine,
+			Type: commercetools.AttributeSetType{
+				ElementType: commercetools.AttributeReferenceType{
+					ReferenceTypeID: commercetools.ReferenceTypeIDProduct,
+				},
+			},
+		},
+		{
+			Name: "dimensions",
+			Type: commercetools.AttributeNestedType{
+				TypeReference: &commercetools.ProductTypeReference{ID: "dimensions-id"},
+			},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"name":              "related",
+		"label":             label,
+		"required":          false,
+		"constraint":        "None",
+		"input_hint":        "SingleLine",
+		"searchable":        false,
+		"type":              "set",
+		"element_type":      "reference",
+		"reference_type_id": "product",
+	}, attributes[0])
+	assert.Equal(t, "nested", attributes[1]["type"])
+	assert.Equal(t, "dimensions-id", attributes[1]["type_reference"])
+}
@@ -0,0 +1,78 @@
+package commercetools
+
+import (
+	"runtime/debug"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+const sdkModulePath = "github.com/labd/commercetools-go-sdk"
+
+// testedAPIVersion is the date of the commercetools API specification which
+// the provider is tested against. Update this when upgrading the SDK.
+const testedAPIVersion = "2020-10-22"
+
+var (
+	providerVersion = "dev"
+	providerCommit  = "snapshot"
+)
+
+// SetBuildInfo sets the version information of the provider binary, this is
+// exposed via the commercetools_provider_info data source.
+func SetBuildInfo(version string, commit string) {
+	providerVersion = version
+	providerCommit = commit
+}
+
+// dataSourceProviderInfo exposes the build information of the provider so
+// modules can check if the provider supports the features they use.
+func dataSourceProviderInfo() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceProviderInfoRead,
+		Schema: map[string]*schema.Schema{
+			"version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"commit": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"sdk_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"api_version": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceProviderInfoRead(d *schema.ResourceData, m interface{}) error {
+	d.SetId(providerVersion)
+	d.Set("version", providerVersion)
+	d.Set("commit", providerCommit)
+	d.Set("sdk_version", sdkVersion())
+	d.Set("api_version", testedAPIVersion)
+	return nil
+}
+
+// sdkVersion returns the version of the commercetools SDK which was compiled
+// into the binary
+func sdkVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "unknown"
+	}
+	for _, dep := range info.Deps {
+		if dep.Path == sdkModulePath {
+			if dep.Replace != nil {
+				return dep.Replace.Version
+			}
+			return dep.Version
+		}
+	}
+	return "unknown"
+}
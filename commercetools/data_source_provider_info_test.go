@@ -0,0 +1,27 @@
+package commercetools
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccProviderInfo(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `data "commercetools_provider_info" "info" {}`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.commercetools_provider_info.info", "version", "dev",
+					),
+					resource.TestCheckResourceAttr(
+						"data.commercetools_provider_info.info", "api_version", testedAPIVersion,
+					),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,123 @@
+package commercetools
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+const (
+	searchIndexProducts      = "products"
+	searchIndexOrders        = "orders"
+	searchIndexCustomers     = "customers"
+	searchIndexBusinessUnits = "business_units"
+
+	searchIndexStatusActivated   = "Activated"
+	searchIndexStatusDeactivated = "Deactivated"
+	searchIndexStatusIndexing    = "Indexing"
+)
+
+// The search indexing configuration of the project is not supported by the
+// commercetools-go-sdk yet, so it is read with the restClient.
+
+type searchIndexingConfigurationValues struct {
+	Status         string `json:"status,omitempty"`
+	LastModifiedAt string `json:"lastModifiedAt,omitempty"`
+}
+
+type searchIndexingConfiguration struct {
+	Products      *searchIndexingConfigurationValues `json:"products,omitempty"`
+	Orders        *searchIndexingConfigurationValues `json:"orders,omitempty"`
+	Customers     *searchIndexingConfigurationValues `json:"customers,omitempty"`
+	BusinessUnits *searchIndexingConfigurationValues `json:"businessUnits,omitempty"`
+}
+
+// searchIndexes are the search indexes of the project
+var searchIndexes = []string{
+	searchIndexProducts,
+	searchIndexOrders,
+	searchIndexCustomers,
+	searchIndexBusinessUnits,
+}
+
+type searchIndexingProject struct {
+	Key            string                       `json:"key"`
+	Version        int                          `json:"version"`
+	SearchIndexing *searchIndexingConfiguration `json:"searchIndexing,omitempty"`
+}
+
+// dataSourceSearchIndexStatus exposes the status of the product and order
+// search indexes of the project
+func dataSourceSearchIndexStatus() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceSearchIndexStatusRead,
+		Schema: map[string]*schema.Schema{
+			"products_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"products_last_modified_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"orders_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"orders_last_modified_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceSearchIndexStatusRead(d *schema.ResourceData, m interface{}) error {
+	project, err := getSearchIndexingProject(m)
+	if err != nil {
+		return err
+	}
+
+	products := project.searchIndex(searchIndexProducts)
+	orders := project.searchIndex(searchIndexOrders)
+
+	d.SetId(project.Key)
+	d.Set("products_status", products.Status)
+	d.Set("products_last_modified_at", products.LastModifiedAt)
+	d.Set("orders_status", orders.Status)
+	d.Set("orders_last_modified_at", orders.LastModifiedAt)
+	return nil
+}
+
+func getSearchIndexingProject(m interface{}) (*searchIndexingProject, error) {
+	client := getRestClient(m)
+
+	project := &searchIndexingProject{}
+	if err := client.get(context.Background(), "", nil, project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// searchIndex returns the configuration of the given index. Indexes which
+// were never activated are not returned by commercetools, these are reported
+// as deactivated.
+func (p *searchIndexingProject) searchIndex(index string) *searchIndexingConfigurationValues {
+	var values *searchIndexingConfigurationValues
+	if p.SearchIndexing != nil {
+		switch index {
+		case searchIndexProducts:
+			values = p.SearchIndexing.Products
+		case searchIndexOrders:
+			values = p.SearchIndexing.Orders
+		case searchIndexCustomers:
+			values = p.SearchIndexing.Customers
+		case searchIndexBusinessUnits:
+			values = p.SearchIndexing.BusinessUnits
+		}
+	}
+	if values == nil || values.Status == "" {
+		return &searchIndexingConfigurationValues{Status: searchIndexStatusDeactivated}
+	}
+	return values
+}
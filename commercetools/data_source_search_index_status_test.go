@@ -0,0 +1,40 @@
+package commercetools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchIndexingProjectSearchIndex(t *testing.T) {
+	project := &searchIndexingProject{}
+	assert.Equal(t, searchIndexStatusDeactivated, project.searchIndex(searchIndexProducts).Status)
+
+	project.SearchIndexing = &searchIndexingConfiguration{
+		Products: &searchIndexingConfigurationValues{
+			Status:         searchIndexStatusIndexing,
+			LastModifiedAt: "2021-03-01T10:00:00.000Z",
+		},
+	}
+	assert.Equal(t, searchIndexStatusIndexing, project.searchIndex(searchIndexProducts).Status)
+	assert.Equal(t, searchIndexStatusDeactivated, project.searchIndex(searchIndexOrders).Status)
+}
+
+func TestSearchIndexAction(t *testing.T) {
+	assert.Equal(t, map[string]interface{}{
+		"action": "changeOrderSearchStatus",
+		"status": "Activated",
+	}, searchIndexAction(searchIndexOrders, true))
+	assert.Equal(t, map[string]interface{}{
+		"action":  "changeProductSearchIndexingEnabled",
+		"enabled": false,
+	}, searchIndexAction(searchIndexProducts, false))
+	assert.Equal(t, map[string]interface{}{
+		"action": "changeCustomerSearchStatus",
+		"status": "Activated",
+	}, searchIndexAction(searchIndexCustomers, true))
+	assert.Equal(t, map[string]interface{}{
+		"action": "changeBusinessUnitSearchStatus",
+		"status": "Deactivated",
+	}, searchIndexAction(searchIndexBusinessUnits, false))
+}
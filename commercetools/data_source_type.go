@@ -0,0 +1,144 @@
+package commercetools
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/labd/commercetools-go-sdk/commercetools"
+)
+
+// dataSourceType looks up a custom type by key, so the custom fields of other
+// resources can reference types which are managed elsewhere and check the
+// names of their fields
+func dataSourceType() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceTypeRead,
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"name": {
+				Type:     TypeLocalizedString,
+				Computed: true,
+			},
+			"description": {
+				Type:     TypeLocalizedString,
+				Computed: true,
+			},
+			"resource_type_ids": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"field_names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The names of the fields, in the order of the field definitions",
+			},
+			"field": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"label": {
+							Type:     TypeLocalizedString,
+							Computed: true,
+						},
+						"required": {
+							Type:     schema.TypeBool,
+							Computed: true,
+						},
+						"input_hint": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the field type, for example String or Set",
+						},
+						"element_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the type of the elements of a Set",
+						},
+						"reference_type_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The referenced resource type of a Reference (or Set of references)",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTypeRead(d *schema.ResourceData, m interface{}) error {
+	client := getClient(m)
+
+	ctType, err := client.TypeGetWithKey(context.Background(), d.Get("key").(string))
+	if err != nil {
+		return err
+	}
+
+	fields, err := flattenTypeDataSourceFields(ctType.FieldDefinitions)
+	if err != nil {
+		return err
+	}
+	fieldNames := make([]string, len(ctType.FieldDefinitions))
+	for i, definition := range ctType.FieldDefinitions {
+		fieldNames[i] = definition.Name
+	}
+
+	d.SetId(ctType.ID)
+	d.Set("version", ctType.Version)
+	d.Set("name", ctType.Name)
+	d.Set("description", ctType.Description)
+	d.Set("resource_type_ids", ctType.ResourceTypeIds)
+	d.Set("field_names", fieldNames)
+	return d.Set("field", fields)
+}
+
+// flattenTypeDataSourceFields returns the field definitions with the type
+// flattened to its name and the referenced resource type
+func flattenTypeDataSourceFields(definitions []commercetools.FieldDefinition) ([]map[string]interface{}, error) {
+	fields := make([]map[string]interface{}, len(definitions))
+	for i, definition := range definitions {
+		fieldType, err := resourceTypeReadFieldType(definition.Type, true)
+		if err != nil {
+			return nil, err
+		}
+
+		field := map[string]interface{}{
+			"name":       definition.Name,
+			"required":   definition.Required,
+			"input_hint": string(definition.InputHint),
+		}
+		if definition.Label != nil {
+			field["label"] = *definition.Label
+		}
+
+		typeData := fieldType[0].(map[string]interface{})
+		field["type"] = typeData["name"]
+		if elementTypes, ok := typeData["element_type"].([]interface{}); ok && len(elementTypes) > 0 {
+			typeData = elementTypes[0].(map[string]interface{})
+			field["element_type"] = typeData["name"]
+		}
+		if referenceTypeID, ok := typeData["reference_type_id"]; ok {
+			field["reference_type_id"] = referenceTypeID
+		}
+		fields[i] = field
+	}
+	return fields, nil
+}
@@ -0,0 +1,46 @@
+package commercetools
+
+import (
+	"testing"
+
+	"github.com/labd/commercetools-go-sdk/commercetools"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlattenTypeDataSourceFields(t *testing.T) {
+	label := commercetools.LocalizedString{"en": "Related"}
+	fields, err := flattenTypeDataSourceFields([]commercetools.FieldDefinition{
+		{
+			Name:      "related",
+			Label:     &label,
+			Required:  true,
+			InputHint: commercetools.TypeTextInputHintSingleLine,
+			Type: commercetools.CustomFieldSetType{
+				ElementType: commercetools.CustomFieldReferenceType{
+					ReferenceTypeID: commercetools.ReferenceTypeIDProduct,
+				},
+			},
+		},
+		{
+			Name: "note",
+			Type: commercetools.CustomFieldStringType{},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"name":              "related",
+		"label":             label,
+		"required":          true,
+		"input_hint":        "SingleLine",
+		"type":              "Set",
+		"element_type":      "Reference",
+		"reference_type_id": "product",
+	}, fields[0])
+	assert.Equal(t, map[string]interface{}{
+		"name":       "note",
+		"required":   false,
+		"input_hint": "",
+		"type":       "String",
+	}, fields[1])
+}
@@ -0,0 +1,46 @@
+package commercetools
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// deprecation describes an attribute which maps to a field that is
+// deprecated by commercetools
+type deprecation struct {
+	Resource  string
+	Attribute string
+	Message   string
+}
+
+// deprecations is the table of attributes which map to fields deprecated by
+// commercetools. Terraform shows the message as a warning when the attribute
+// is used, so configurations can be migrated before commercetools removes the
+// field. Add new deprecations here instead of setting Deprecated in the
+// schema of the resource.
+var deprecations = []deprecation{
+	{
+		Resource:  "commercetools_shipping_method",
+		Attribute: "description",
+		Message: "The non-localized description of shipping methods is deprecated by commercetools " +
+			"in favour of the localized description",
+	},
+}
+
+// applyDeprecations marks the attributes in the deprecations table as
+// deprecated in the schema of the resources
+func applyDeprecations(resources map[string]*schema.Resource) error {
+	for _, item := range deprecations {
+		resource, ok := resources[item.Resource]
+		if !ok {
+			return fmt.Errorf("deprecation for unknown resource %s", item.Resource)
+		}
+		attribute, ok := resource.Schema[item.Attribute]
+		if !ok {
+			return fmt.Errorf("deprecation for unknown attribute %s of %s", item.Attribute, item.Resource)
+		}
+		attribute.Deprecated = item.Message
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package commercetools
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyDeprecations(t *testing.T) {
+	provider := Provider().(*schema.Provider)
+	attribute := provider.ResourcesMap["commercetools_shipping_method"].Schema["description"]
+	assert.NotEmpty(t, attribute.Deprecated)
+
+	err := applyDeprecations(map[string]*schema.Resource{})
+	assert.EqualError(t, err, "deprecation for unknown resource commercetools_shipping_method")
+}
@@ -0,0 +1,75 @@
+package commercetools
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/labd/commercetools-go-sdk/commercetools"
+)
+
+// discountCodeDeleter retries the deletes of discount codes and logs the
+// progress. Terraform deletes every code separately with up to -parallelism
+// codes at the same time, so destroying a large campaign is a long series of
+// deletes of which a single failure shouldn't fail the destroy.
+type discountCodeDeleter struct {
+	mu      sync.Mutex
+	pending int
+	deleted int
+}
+
+func newDiscountCodeDeleter() *discountCodeDeleter {
+	return &discountCodeDeleter{}
+}
+
+// delete deletes the discount code, a deleted code is not an error. Version
+// conflicts (for example when the code was used in the meantime) are retried
+// with the current version of the code, errors of the transport and of the
+// API with a 5xx or 429 status code are retried as well.
+func (deleter *discountCodeDeleter) delete(client *commercetools.Client, id string, version int, timeout time.Duration) error {
+	deleter.mu.Lock()
+	deleter.pending++
+	deleter.mu.Unlock()
+
+	err := resource.Retry(timeout, func() *resource.RetryError {
+		_, err := client.DiscountCodeDeleteWithID(context.Background(), id, version, false)
+		if err == nil {
+			return nil
+		}
+		ctErr, ok := err.(commercetools.ErrorResponse)
+		if !ok {
+			return handleCommercetoolsError(err)
+		}
+
+		switch {
+		case ctErr.StatusCode == 404:
+			return nil
+		case ctErr.StatusCode == 409:
+			current, getErr := client.DiscountCodeGetWithID(context.Background(), id)
+			if getErr != nil {
+				if getCtErr, ok := getErr.(commercetools.ErrorResponse); ok && getCtErr.StatusCode == 404 {
+					return nil
+				}
+				return resource.NonRetryableError(getErr)
+			}
+			log.Printf("[DEBUG] Retrying the delete of discount code %s with version %d", id, current.Version)
+			version = current.Version
+			return resource.RetryableError(err)
+		case ctErr.StatusCode == 429 || ctErr.StatusCode >= 500:
+			log.Printf("[DEBUG] Retrying the delete of discount code %s: %s", id, err)
+			return resource.RetryableError(err)
+		}
+		return resource.NonRetryableError(err)
+	})
+
+	deleter.mu.Lock()
+	deleter.pending--
+	if err == nil {
+		deleter.deleted++
+		log.Printf("[INFO] Deleted discount code %s, %d deleted and %d pending", id, deleter.deleted, deleter.pending)
+	}
+	deleter.mu.Unlock()
+	return err
+}
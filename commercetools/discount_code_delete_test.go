@@ -0,0 +1,74 @@
+package commercetools
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/labd/commercetools-go-sdk/commercetools"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiscountCodeDeleter(t *testing.T) {
+	var mu sync.Mutex
+	conflicts := map[string]bool{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/my-project/discount-codes/")
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == http.MethodGet {
+			fmt.Fprintf(w, `{"id": %q, "version": 2}`, id)
+			return
+		}
+
+		mu.Lock()
+		conflict := id == "conflict" && r.URL.Query().Get("version") == "1" && !conflicts[id]
+		conflicts[id] = true
+		mu.Unlock()
+
+		switch {
+		case id == "missing":
+			w.WriteHeader(404)
+			w.Write([]byte(`{"statusCode": 404, "message": "not found"}`))
+		case id == "invalid":
+			w.WriteHeader(400)
+			w.Write([]byte(`{"statusCode": 400, "message": "invalid"}`))
+		case conflict:
+			w.WriteHeader(409)
+			w.Write([]byte(`{"statusCode": 409, "message": "conflict"}`))
+		default:
+			fmt.Fprintf(w, `{"id": %q}`, id)
+		}
+	}))
+	defer server.Close()
+
+	client := commercetools.New(&commercetools.Config{
+		ProjectKey: "my-project",
+		URL:        server.URL,
+		HTTPClient: server.Client(),
+	})
+	deleter := newDiscountCodeDeleter()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 6; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			assert.NoError(t, deleter.delete(client, fmt.Sprintf("code-%d", i), 1, time.Minute))
+		}(i)
+	}
+	wg.Wait()
+
+	// A version conflict is retried with the current version and a deleted
+	// code is not an error
+	assert.NoError(t, deleter.delete(client, "conflict", 1, time.Minute))
+	assert.NoError(t, deleter.delete(client, "missing", 1, time.Minute))
+	assert.Error(t, deleter.delete(client, "invalid", 1, time.Minute))
+	assert.Equal(t, 8, deleter.deleted)
+	assert.Equal(t, 0, deleter.pending)
+}
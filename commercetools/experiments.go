@@ -0,0 +1,89 @@
+package commercetools
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// experiment describes a resource or an attribute of a resource which is
+// experimental. An empty attribute marks the complete resource as
+// experimental.
+type experiment struct {
+	Name      string
+	Resource  string
+	Attribute string
+}
+
+// experiments is the table of resources and attributes which can only be used
+// when the experiment is listed in the experiments attribute of the provider.
+// This allows shipping support for new commercetools features before their
+// API is stable, without changing the plans of configurations which don't use
+// them. Remove the entries when the feature is stable.
+var experiments = []experiment{
+	{
+		Name:      "business_units",
+		Resource:  "commercetools_project_settings",
+		Attribute: "business_units",
+	},
+	{
+		Name:      "business_units",
+		Resource:  "commercetools_project_settings",
+		Attribute: "search_indexing.0.business_units",
+	},
+}
+
+// experimentNames returns the names of the known experiments
+func experimentNames() []string {
+	lookup := map[string]bool{}
+	for _, item := range experiments {
+		lookup[item.Name] = true
+	}
+	names := []string{}
+	for name := range lookup {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// applyExperiments adds a check to the plan of the resources in the
+// experiments table, which fails when an experimental resource or attribute
+// is used without enabling the experiment
+func applyExperiments(resources map[string]*schema.Resource) error {
+	for _, item := range experiments {
+		resource, ok := resources[item.Resource]
+		if !ok {
+			return fmt.Errorf("experiment %s for unknown resource %s", item.Name, item.Resource)
+		}
+
+		check := experimentCheck(item)
+		if resource.CustomizeDiff != nil {
+			check = customdiff.All(check, resource.CustomizeDiff)
+		}
+		resource.CustomizeDiff = check
+	}
+	return nil
+}
+
+func experimentCheck(item experiment) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, m interface{}) error {
+		meta, ok := m.(*providerMeta)
+		if !ok || meta.experiments[item.Name] {
+			return nil
+		}
+		if item.Attribute == "" {
+			return fmt.Errorf(
+				"%s is experimental, add %q to the experiments of the provider to use it",
+				item.Resource, item.Name)
+		}
+		if _, ok := d.GetOk(item.Attribute); ok {
+			return fmt.Errorf(
+				"%s of %s is experimental, add %q to the experiments of the provider to use it",
+				item.Attribute, item.Resource, item.Name)
+		}
+		return nil
+	}
+}
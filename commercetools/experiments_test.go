@@ -0,0 +1,36 @@
+package commercetools
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyExperiments(t *testing.T) {
+	resource := Provider().(*schema.Provider).ResourcesMap["commercetools_project_settings"]
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"business_units": []interface{}{
+			map[string]interface{}{"my_business_unit_status_on_creation": "Active"},
+		},
+	})
+
+	_, err := resource.Diff(nil, config, &providerMeta{experiments: map[string]bool{}})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(),
+		`business_units of commercetools_project_settings is experimental, add "business_units" to the experiments of the provider to use it`)
+
+	_, err = resource.Diff(nil, config, &providerMeta{experiments: map[string]bool{"business_units": true}})
+	assert.NoError(t, err)
+
+	_, err = resource.Diff(nil, terraform.NewResourceConfigRaw(map[string]interface{}{}), &providerMeta{})
+	assert.NoError(t, err)
+
+	err = applyExperiments(map[string]*schema.Resource{})
+	assert.EqualError(t, err, "experiment business_units for unknown resource commercetools_project_settings")
+}
+
+func TestExperimentNames(t *testing.T) {
+	assert.Equal(t, []string{"business_units"}, experimentNames())
+}
@@ -0,0 +1,289 @@
+package commercetools
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// The provider validates custom object values against a JSON schema without
+// an external library, so only the validation keywords below are supported.
+// Schemas using other keywords (like $ref) are rejected, instead of silently
+// accepting any value.
+
+// jsonSchemaKeywords are the supported keywords, the annotations are accepted
+// but don't validate anything
+var jsonSchemaKeywords = []string{
+	"type", "enum", "const",
+	"properties", "required", "additionalProperties", "minProperties", "maxProperties",
+	"items", "minItems", "maxItems", "uniqueItems",
+	"minLength", "maxLength", "pattern",
+	"minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum", "multipleOf",
+	"allOf", "anyOf", "oneOf", "not",
+	"$schema", "$id", "$comment", "title", "description", "default", "examples", "format",
+}
+
+// parseJSONSchema decodes the schema and checks that it only uses supported
+// keywords
+func parseJSONSchema(input string) (map[string]interface{}, error) {
+	var schema map[string]interface{}
+	if err := json.Unmarshal([]byte(input), &schema); err != nil {
+		return nil, fmt.Errorf("invalid JSON schema: %s", err)
+	}
+	if err := checkJSONSchemaKeywords(schema, "#"); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+func checkJSONSchemaKeywords(schema map[string]interface{}, path string) error {
+	for keyword, value := range schema {
+		if !stringInSlice(keyword, jsonSchemaKeywords) {
+			return fmt.Errorf("the JSON schema keyword %s at %s is not supported", keyword, path)
+		}
+		if keyword == "pattern" {
+			if _, err := regexp.Compile(fmt.Sprint(value)); err != nil {
+				return fmt.Errorf("invalid pattern at %s: %s", path, err)
+			}
+		}
+
+		// Check the nested schemas
+		switch keyword {
+		case "properties":
+			properties, _ := value.(map[string]interface{})
+			for name, property := range properties {
+				if nested, ok := property.(map[string]interface{}); ok {
+					if err := checkJSONSchemaKeywords(nested, path+"/properties/"+name); err != nil {
+						return err
+					}
+				}
+			}
+		case "items", "additionalProperties", "not":
+			if nested, ok := value.(map[string]interface{}); ok {
+				if err := checkJSONSchemaKeywords(nested, path+"/"+keyword); err != nil {
+					return err
+				}
+			}
+		case "allOf", "anyOf", "oneOf":
+			items, _ := value.([]interface{})
+			for i, item := range items {
+				if nested, ok := item.(map[string]interface{}); ok {
+					if err := checkJSONSchemaKeywords(nested, fmt.Sprintf("%s/%s/%d", path, keyword, i)); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// validateJSONSchema returns the violations of the schema by the value, the
+// value is decoded with encoding/json so numbers are float64
+func validateJSONSchema(schema map[string]interface{}, value interface{}, path string) []string {
+	errs := []string{}
+	fail := func(format string, args ...interface{}) {
+		errs = append(errs, fmt.Sprintf("%s: %s", path, fmt.Sprintf(format, args...)))
+	}
+
+	if raw, ok := schema["type"]; ok {
+		types := []string{}
+		switch t := raw.(type) {
+		case string:
+			types = append(types, t)
+		case []interface{}:
+			types = expandStringArray(t)
+		}
+		if !jsonSchemaTypeMatches(types, value) {
+			fail("expected %s, got %s", strings.Join(types, " or "), jsonSchemaTypeName(value))
+			// The other keywords don't apply to a value of the wrong type
+			return errs
+		}
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, item := range enum {
+			if reflect.DeepEqual(item, value) {
+				found = true
+			}
+		}
+		if !found {
+			fail("must be one of the values of the enum")
+		}
+	}
+	if constValue, ok := schema["const"]; ok && !reflect.DeepEqual(constValue, value) {
+		fail("must be %v", constValue)
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		errs = append(errs, validateJSONSchemaObject(schema, v, path)...)
+	case []interface{}:
+		if min, ok := schema["minItems"].(float64); ok && float64(len(v)) < min {
+			fail("must have at least %v items", min)
+		}
+		if max, ok := schema["maxItems"].(float64); ok && float64(len(v)) > max {
+			fail("must have at most %v items", max)
+		}
+		if unique, _ := schema["uniqueItems"].(bool); unique {
+			for i := range v {
+				for j := i + 1; j < len(v); j++ {
+					if reflect.DeepEqual(v[i], v[j]) {
+						fail("items %d and %d are equal", i, j)
+					}
+				}
+			}
+		}
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range v {
+				errs = append(errs, validateJSONSchema(items, item, fmt.Sprintf("%s/%d", path, i))...)
+			}
+		}
+	case string:
+		length := float64(len([]rune(v)))
+		if min, ok := schema["minLength"].(float64); ok && length < min {
+			fail("must be at least %v characters", min)
+		}
+		if max, ok := schema["maxLength"].(float64); ok && length > max {
+			fail("must be at most %v characters", max)
+		}
+		if pattern, ok := schema["pattern"].(string); ok {
+			if re, err := regexp.Compile(pattern); err == nil && !re.MatchString(v) {
+				fail("must match the pattern %s", pattern)
+			}
+		}
+	case float64:
+		if min, ok := schema["minimum"].(float64); ok && v < min {
+			fail("must be at least %v", min)
+		}
+		if max, ok := schema["maximum"].(float64); ok && v > max {
+			fail("must be at most %v", max)
+		}
+		if min, ok := schema["exclusiveMinimum"].(float64); ok && v <= min {
+			fail("must be greater than %v", min)
+		}
+		if max, ok := schema["exclusiveMaximum"].(float64); ok && v >= max {
+			fail("must be less than %v", max)
+		}
+		if multiple, ok := schema["multipleOf"].(float64); ok && multiple > 0 {
+			if quotient := v / multiple; quotient != math.Trunc(quotient) {
+				fail("must be a multiple of %v", multiple)
+			}
+		}
+	}
+
+	if all, ok := schema["allOf"].([]interface{}); ok {
+		for _, item := range all {
+			if nested, ok := item.(map[string]interface{}); ok {
+				errs = append(errs, validateJSONSchema(nested, value, path)...)
+			}
+		}
+	}
+	if anyOf, ok := schema["anyOf"].([]interface{}); ok && jsonSchemaMatchCount(anyOf, value, path) == 0 {
+		fail("must match at least one of the schemas of anyOf")
+	}
+	if oneOf, ok := schema["oneOf"].([]interface{}); ok && jsonSchemaMatchCount(oneOf, value, path) != 1 {
+		fail("must match exactly one of the schemas of oneOf")
+	}
+	if not, ok := schema["not"].(map[string]interface{}); ok && len(validateJSONSchema(not, value, path)) == 0 {
+		fail("must not match the schema of not")
+	}
+	return errs
+}
+
+func validateJSONSchemaObject(schema map[string]interface{}, value map[string]interface{}, path string) []string {
+	errs := []string{}
+	properties, _ := schema["properties"].(map[string]interface{})
+
+	for _, name := range expandStringArray(jsonSchemaList(schema["required"])) {
+		if _, ok := value[name]; !ok {
+			errs = append(errs, fmt.Sprintf("%s: missing required property %s", path, name))
+		}
+	}
+	if min, ok := schema["minProperties"].(float64); ok && float64(len(value)) < min {
+		errs = append(errs, fmt.Sprintf("%s: must have at least %v properties", path, min))
+	}
+	if max, ok := schema["maxProperties"].(float64); ok && float64(len(value)) > max {
+		errs = append(errs, fmt.Sprintf("%s: must have at most %v properties", path, max))
+	}
+
+	// Validate the properties in a fixed order, so the errors are stable
+	names := make([]string, 0, len(value))
+	for name := range value {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		propertyPath := path + "/" + name
+		if property, ok := properties[name].(map[string]interface{}); ok {
+			errs = append(errs, validateJSONSchema(property, value[name], propertyPath)...)
+			continue
+		}
+		if _, ok := properties[name]; ok {
+			continue
+		}
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				errs = append(errs, fmt.Sprintf("%s: additional property %s is not allowed", path, name))
+			}
+		case map[string]interface{}:
+			errs = append(errs, validateJSONSchema(additional, value[name], propertyPath)...)
+		}
+	}
+	return errs
+}
+
+func jsonSchemaMatchCount(schemas []interface{}, value interface{}, path string) int {
+	count := 0
+	for _, item := range schemas {
+		if nested, ok := item.(map[string]interface{}); ok && len(validateJSONSchema(nested, value, path)) == 0 {
+			count++
+		}
+	}
+	return count
+}
+
+func jsonSchemaList(value interface{}) []interface{} {
+	if list, ok := value.([]interface{}); ok {
+		return list
+	}
+	return nil
+}
+
+func jsonSchemaTypeMatches(types []string, value interface{}) bool {
+	name := jsonSchemaTypeName(value)
+	for _, t := range types {
+		if t == name || (t == "number" && name == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+func jsonSchemaTypeName(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case float64:
+		if v == math.Trunc(v) {
+			return "integer"
+		}
+		return "number"
+	case []interface{}:
+		return "array"
+	case map[string]interface{}:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
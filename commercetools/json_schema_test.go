@@ -0,0 +1,77 @@
+package commercetools
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseJSONSchema(t *testing.T) {
+	_, err := parseJSONSchema(`{"type": "object", "properties": {"name": {"type": "string"}}}`)
+	assert.NoError(t, err)
+
+	_, err = parseJSONSchema(`{"properties": {"name": {"$ref": "#/definitions/name"}}}`)
+	assert.EqualError(t, err, "the JSON schema keyword $ref at #/properties/name is not supported")
+
+	_, err = parseJSONSchema(`{"pattern": "("}`)
+	assert.Error(t, err)
+
+	_, err = parseJSONSchema(`[]`)
+	assert.Error(t, err)
+}
+
+func TestValidateJSONSchema(t *testing.T) {
+	schema, err := parseJSONSchema(`{
+		"type": "object",
+		"required": ["name", "retries"],
+		"additionalProperties": false,
+		"properties": {
+			"name": {"type": "string", "minLength": 2, "pattern": "^[a-z]+$"},
+			"retries": {"type": "integer", "minimum": 0, "maximum": 5},
+			"mode": {"enum": ["fast", "safe"]},
+			"tags": {"type": "array", "items": {"type": "string"}, "uniqueItems": true}
+		}
+	}`)
+	assert.NoError(t, err)
+
+	validate := func(input string) []string {
+		var value interface{}
+		assert.NoError(t, json.Unmarshal([]byte(input), &value))
+		return validateJSONSchema(schema, value, "#")
+	}
+
+	assert.Empty(t, validate(`{"name": "checkout", "retries": 3, "mode": "safe", "tags": ["a", "b"]}`))
+	assert.Equal(t, []string{"#: expected object, got array"}, validate(`[]`))
+	assert.Equal(t, []string{
+		"#: missing required property retries",
+		"#: additional property debug is not allowed",
+		"#/mode: must be one of the values of the enum",
+		"#/name: must match the pattern ^[a-z]+$",
+		"#/tags: items 0 and 1 are equal",
+		"#/tags/2: expected string, got integer",
+	}, validate(`{"name": "Checkout", "debug": true, "mode": "slow", "tags": ["a", "a", 1]}`))
+	assert.Equal(t, []string{
+		"#/retries: expected integer, got number",
+	}, validate(`{"name": "checkout", "retries": 1.5}`))
+	assert.Equal(t, []string{
+		"#/retries: must be at most 5",
+	}, validate(`{"name": "checkout", "retries": 10}`))
+}
+
+func TestValidateJSONSchemaCombinations(t *testing.T) {
+	schema, err := parseJSONSchema(`{
+		"oneOf": [{"type": "string"}, {"type": "number", "multipleOf": 5}],
+		"not": {"const": "none"}
+	}`)
+	assert.NoError(t, err)
+
+	assert.Empty(t, validateJSONSchema(schema, "all", "#"))
+	assert.Empty(t, validateJSONSchema(schema, float64(10), "#"))
+	assert.Equal(t, []string{
+		"#: must match exactly one of the schemas of oneOf",
+	}, validateJSONSchema(schema, float64(7), "#"))
+	assert.Equal(t, []string{
+		"#: must not match the schema of not",
+	}, validateJSONSchema(schema, "none", "#"))
+}
@@ -0,0 +1,100 @@
+package commercetools
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/labd/commercetools-go-sdk/commercetools"
+)
+
+// labelsFieldName is the custom field reserved for the labels of a resource.
+// The custom type of the resource needs a field with this name of the type
+// Set of String, every label is stored as `name=value` in the set.
+const labelsFieldName = "terraform_labels"
+
+// labelsSchema returns the schema for the `labels` map of resources which
+// support custom fields. commercetools has no tags, so the labels are stored
+// in a reserved custom field which can be used to filter resources.
+func labelsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeMap,
+		Optional: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+}
+
+// expandLabels returns the labels as a sorted list of `name=value` strings
+func expandLabels(labels map[string]interface{}) []string {
+	result := make([]string, 0, len(labels))
+	for name, value := range labels {
+		result = append(result, fmt.Sprintf("%s=%s", name, value))
+	}
+	sort.Strings(result)
+	return result
+}
+
+// expandLabelsFields adds the labels to the custom fields. It returns an error
+// when labels are set but the resource has no custom type.
+func expandLabelsFields(d *schema.ResourceData, fields *commercetools.FieldContainer, hasType bool) (*commercetools.FieldContainer, error) {
+	labels := d.Get("labels").(map[string]interface{})
+	if len(labels) == 0 {
+		return fields, nil
+	}
+	if !hasType {
+		return nil, fmt.Errorf(
+			"labels can only be set when the custom type is set, the type needs a %s field of the type Set of String",
+			labelsFieldName)
+	}
+	if fields == nil {
+		fields = &commercetools.FieldContainer{}
+	}
+	(*fields)[labelsFieldName] = expandLabels(labels)
+	return fields, nil
+}
+
+// labelsFieldValue returns the value for the setCustomField action of the
+// labels, nil removes the field
+func labelsFieldValue(d *schema.ResourceData) interface{} {
+	labels := d.Get("labels").(map[string]interface{})
+	if len(labels) == 0 {
+		return nil
+	}
+	return expandLabels(labels)
+}
+
+func flattenLabels(custom *commercetools.CustomFields) map[string]string {
+	result := map[string]string{}
+	if custom == nil || custom.Fields == nil {
+		return result
+	}
+	values, ok := (*custom.Fields)[labelsFieldName].([]interface{})
+	if !ok {
+		return result
+	}
+	for _, value := range values {
+		label, ok := value.(string)
+		if !ok {
+			continue
+		}
+		parts := strings.SplitN(label, "=", 2)
+		if len(parts) == 2 {
+			result[parts[0]] = parts[1]
+		} else {
+			result[parts[0]] = ""
+		}
+	}
+	return result
+}
+
+// labelsPredicate returns the where predicate which matches resources that
+// have all the given labels
+func labelsPredicate(labels map[string]interface{}) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(
+		"custom(fields(%s contains all (%s)))",
+		labelsFieldName, quotePredicateValues(expandLabels(labels)))
+}
@@ -0,0 +1,34 @@
+package commercetools
+
+import (
+	"testing"
+
+	"github.com/labd/commercetools-go-sdk/commercetools"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandLabels(t *testing.T) {
+	assert.Equal(t, []string{}, expandLabels(map[string]interface{}{}))
+	assert.Equal(t,
+		[]string{"env=prod", "team=checkout"},
+		expandLabels(map[string]interface{}{"team": "checkout", "env": "prod"}))
+}
+
+func TestFlattenLabels(t *testing.T) {
+	assert.Equal(t, map[string]string{}, flattenLabels(nil))
+
+	custom := &commercetools.CustomFields{
+		Type: &commercetools.TypeReference{ID: "type-id"},
+		Fields: &commercetools.FieldContainer{
+			"terraform_labels": []interface{}{"env=prod", "team=checkout"},
+			"discount_level":   float64(3),
+		},
+	}
+	assert.Equal(t,
+		map[string]string{"env": "prod", "team": "checkout"},
+		flattenLabels(custom))
+
+	// The labels are not part of the custom fields
+	fields := flattenCustomFields(custom)[0]["fields"].(map[string]interface{})
+	assert.Equal(t, map[string]interface{}{"discount_level": "3"}, fields)
+}
@@ -0,0 +1,90 @@
+package commercetools
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// merchantCenterPaths maps the resources to the path of their page in the
+// Merchant Center, relative to the project. Resources without a page of
+// their own (for example the activation resources) are not listed and have
+// no mc_url attribute.
+var merchantCenterPaths = map[string]string{
+	"commercetools_api_client":       "settings/developer/api-clients/%s",
+	"commercetools_cart_discount":    "discounts/carts/%s",
+	"commercetools_category":         "categories/%s",
+	"commercetools_channel":          "settings/project/channels/%s",
+	"commercetools_customer_group":   "customers/customer-groups/%s",
+	"commercetools_discount_code":    "discounts/codes/%s",
+	"commercetools_product_type":     "settings/product-types/%s",
+	"commercetools_project_settings": "settings/project/international",
+	"commercetools_shipping_method":  "settings/project/shipping-methods/%s",
+	"commercetools_shipping_zone":    "settings/project/zones/%s",
+	"commercetools_store":            "settings/project/stores/%s",
+	"commercetools_tax_category":     "settings/project/taxes/%s",
+	"commercetools_type":             "settings/types/%s",
+}
+
+// applyMerchantCenterURLs adds the computed mc_url attribute to the resources
+// with a page in the Merchant Center. The attribute is set after every create,
+// read and update, so it is also available for existing resources after
+// upgrading the provider.
+func applyMerchantCenterURLs(resources map[string]*schema.Resource) error {
+	for name, path := range merchantCenterPaths {
+		resource, ok := resources[name]
+		if !ok {
+			return fmt.Errorf("merchant center path for unknown resource %s", name)
+		}
+		resource.Schema["mc_url"] = &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The URL of the resource in the Merchant Center",
+		}
+		resource.Create = merchantCenterURLFunc(path, resource.Create)
+		resource.Read = merchantCenterURLFunc(path, resource.Read)
+		resource.Update = merchantCenterURLFunc(path, resource.Update)
+	}
+	return nil
+}
+
+func merchantCenterURLFunc(path string, f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+	if f == nil {
+		return nil
+	}
+	return func(d *schema.ResourceData, m interface{}) error {
+		if err := f(d, m); err != nil {
+			return err
+		}
+		// The resource is removed from the state when it no longer exists
+		if d.Id() == "" {
+			return nil
+		}
+		meta, ok := m.(*providerMeta)
+		if !ok || meta.merchantCenterURL == "" {
+			return d.Set("mc_url", "")
+		}
+		resourcePath := path
+		if strings.Contains(path, "%s") {
+			resourcePath = fmt.Sprintf(path, url.PathEscape(d.Id()))
+		}
+		return d.Set("mc_url", fmt.Sprintf("%s/%s", meta.merchantCenterURL, resourcePath))
+	}
+}
+
+// merchantCenterProjectURL returns the URL of the project in the Merchant
+// Center of the region of the API URL. The Merchant Center of a region is
+// hosted on the same domain as the API, so
+// https://api.europe-west1.gcp.commercetools.com results in
+// https://mc.europe-west1.gcp.commercetools.com/<project key>. An empty
+// string is returned for API URLs which are not in this format.
+func merchantCenterProjectURL(apiURL string, projectKey string) string {
+	parsed, err := url.Parse(apiURL)
+	if err != nil || !strings.HasPrefix(parsed.Host, "api.") {
+		return ""
+	}
+	host := "mc." + strings.TrimPrefix(parsed.Host, "api.")
+	return fmt.Sprintf("https://%s/%s", host, url.PathEscape(projectKey))
+}
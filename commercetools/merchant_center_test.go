@@ -0,0 +1,38 @@
+package commercetools
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerchantCenterProjectURL(t *testing.T) {
+	assert.Equal(t,
+		"https://mc.europe-west1.gcp.commercetools.com/my-project",
+		merchantCenterProjectURL("https://api.europe-west1.gcp.commercetools.com", "my-project"))
+	assert.Equal(t,
+		"https://mc.us-central1.gcp.commercetools.com/my-project",
+		merchantCenterProjectURL("https://api.us-central1.gcp.commercetools.com/", "my-project"))
+	assert.Equal(t, "", merchantCenterProjectURL("http://localhost:8989", "my-project"))
+}
+
+func TestApplyMerchantCenterURLs(t *testing.T) {
+	resources := Provider().(*schema.Provider).ResourcesMap
+	for name := range merchantCenterPaths {
+		assert.Contains(t, resources[name].Schema, "mc_url", name)
+	}
+	assert.NotContains(t, resources["commercetools_discount_activation"].Schema, "mc_url")
+
+	read := merchantCenterURLFunc(merchantCenterPaths["commercetools_type"], func(d *schema.ResourceData, m interface{}) error {
+		return nil
+	})
+	d := schema.TestResourceDataRaw(t, resources["commercetools_type"].Schema, map[string]interface{}{})
+	d.SetId("type-id")
+
+	assert.NoError(t, read(d, &providerMeta{merchantCenterURL: "https://mc.europe-west1.gcp.commercetools.com/my-project"}))
+	assert.Equal(t, "https://mc.europe-west1.gcp.commercetools.com/my-project/settings/types/type-id", d.Get("mc_url"))
+
+	assert.NoError(t, read(d, &providerMeta{}))
+	assert.Equal(t, "", d.Get("mc_url"))
+}
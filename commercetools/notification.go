@@ -0,0 +1,163 @@
+package commercetools
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// notificationChange is a single resource created, updated or deleted by the
+// provider
+type notificationChange struct {
+	Resource string `json:"resource"`
+	ID       string `json:"id,omitempty"`
+	Key      string `json:"key,omitempty"`
+}
+
+// notificationSummary is posted to the webhook during an apply
+type notificationSummary struct {
+	ProjectKey string               `json:"project_key"`
+	Created    []notificationChange `json:"created"`
+	Updated    []notificationChange `json:"updated"`
+	Deleted    []notificationChange `json:"deleted"`
+}
+
+// notifier records the resources changed by the create, update and delete
+// functions of the resources. Terraform has no hook at the end of an apply and
+// kills the provider shortly after it's finished, so the summary is posted
+// when the last change in progress is finished, before returning to
+// Terraform. A large apply can therefore result in multiple summaries, each
+// with the changes since the previous one, and summaries can be posted for an
+// apply which fails later on. Once a change fails no summaries are posted
+// anymore.
+type notifier struct {
+	webhookURL string
+	projectKey string
+	client     *http.Client
+
+	mu       sync.Mutex
+	inFlight int
+	failed   bool
+	summary  notificationSummary
+}
+
+func newNotifier(webhookURL string, projectKey string) *notifier {
+	n := &notifier{
+		webhookURL: webhookURL,
+		projectKey: projectKey,
+		client:     &http.Client{Timeout: 10 * time.Second},
+	}
+	n.reset()
+	return n
+}
+
+func (n *notifier) reset() {
+	n.summary = notificationSummary{
+		ProjectKey: n.projectKey,
+		Created:    []notificationChange{},
+		Updated:    []notificationChange{},
+		Deleted:    []notificationChange{},
+	}
+}
+
+func (n *notifier) begin() {
+	n.mu.Lock()
+	n.inFlight++
+	n.mu.Unlock()
+}
+
+// end records the result of a change and posts the summary when no other
+// changes are in progress
+func (n *notifier) end(changeType string, change notificationChange, err error) {
+	n.mu.Lock()
+	n.inFlight--
+	if err != nil {
+		n.failed = true
+	}
+	if n.failed {
+		n.mu.Unlock()
+		return
+	}
+
+	switch changeType {
+	case "created":
+		n.summary.Created = append(n.summary.Created, change)
+	case "updated":
+		n.summary.Updated = append(n.summary.Updated, change)
+	case "deleted":
+		n.summary.Deleted = append(n.summary.Deleted, change)
+	}
+	if n.inFlight > 0 {
+		n.mu.Unlock()
+		return
+	}
+	summary := n.summary
+	n.reset()
+	n.mu.Unlock()
+
+	n.send(summary)
+}
+
+func (n *notifier) send(summary notificationSummary) {
+	if len(summary.Created)+len(summary.Updated)+len(summary.Deleted) == 0 {
+		return
+	}
+
+	data, err := json.Marshal(summary)
+	if err != nil {
+		return
+	}
+
+	resp, err := n.client.Post(n.webhookURL, "application/json", bytes.NewReader(data))
+	if err != nil {
+		log.Printf("[WARN] Unable to send the notification webhook: %s", err)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		log.Printf("[WARN] The notification webhook returned status %d", resp.StatusCode)
+	}
+}
+
+// applyNotifications wraps the create, update and delete functions of the
+// resources to record the changes for the notification webhook. Data sources
+// are not wrapped, the objects they create and delete (for example the cart of
+// the cart discount preview) are not changes of the configuration.
+func applyNotifications(resources map[string]*schema.Resource) {
+	for name, resource := range resources {
+		resource.Create = notificationFunc(name, "created", resource.Create)
+		resource.Update = notificationFunc(name, "updated", resource.Update)
+		resource.Delete = notificationFunc(name, "deleted", resource.Delete)
+	}
+}
+
+func notificationFunc(name string, changeType string, f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+	if f == nil {
+		return nil
+	}
+	return func(d *schema.ResourceData, m interface{}) error {
+		meta, ok := m.(*providerMeta)
+		if !ok || meta.notifier == nil {
+			return f(d, m)
+		}
+
+		// The id is cleared by the delete
+		change := notificationChange{Resource: name, ID: d.Id()}
+		if key, ok := d.GetOk("key"); ok {
+			change.Key, _ = key.(string)
+		}
+
+		meta.notifier.begin()
+		err := f(d, m)
+		if changeType != "deleted" {
+			change.ID = d.Id()
+		}
+		meta.notifier.end(changeType, change, err)
+		return err
+	}
+}
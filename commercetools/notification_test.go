@@ -0,0 +1,84 @@
+package commercetools
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotificationFunc(t *testing.T) {
+	summaries := []notificationSummary{}
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		summary := notificationSummary{}
+		assert.NoError(t, json.Unmarshal(body, &summary))
+		summaries = append(summaries, summary)
+	}))
+	defer webhook.Close()
+
+	meta := &providerMeta{notifier: newNotifier(webhook.URL, "my-project")}
+	resource := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"key": {Type: schema.TypeString, Optional: true},
+		},
+	}
+	create := notificationFunc("commercetools_category", "created", func(d *schema.ResourceData, m interface{}) error {
+		d.SetId("1234")
+		return nil
+	})
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{"key": "shoes"})
+	assert.NoError(t, create(d, meta))
+
+	assert.Len(t, summaries, 1)
+	assert.Equal(t, "my-project", summaries[0].ProjectKey)
+	assert.Equal(t, []notificationChange{{Resource: "commercetools_category", ID: "1234", Key: "shoes"}}, summaries[0].Created)
+	assert.Empty(t, summaries[0].Updated)
+
+	// Resources without a key are supported as well
+	del := notificationFunc("commercetools_tax_category_rate", "deleted", func(d *schema.ResourceData, m interface{}) error {
+		d.SetId("")
+		return nil
+	})
+	d = schema.TestResourceDataRaw(t, map[string]*schema.Schema{}, map[string]interface{}{})
+	d.SetId("5678")
+	assert.NoError(t, del(d, meta))
+	assert.Len(t, summaries, 2)
+	assert.Equal(t, []notificationChange{{Resource: "commercetools_tax_category_rate", ID: "5678"}}, summaries[1].Deleted)
+
+	// Nothing is posted anymore once a change failed
+	update := notificationFunc("commercetools_category", "updated", func(d *schema.ResourceData, m interface{}) error {
+		return errors.New("failed")
+	})
+	d = schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{"key": "shoes"})
+	d.SetId("1234")
+	assert.Error(t, update(d, meta))
+	assert.NoError(t, create(d, meta))
+	assert.Len(t, summaries, 2)
+}
+
+func TestNotifierEnd(t *testing.T) {
+	posted := 0
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		posted++
+	}))
+	defer webhook.Close()
+
+	// The summary is posted when the last change in progress is finished
+	n := newNotifier(webhook.URL, "my-project")
+	n.begin()
+	n.begin()
+	n.end("created", notificationChange{Resource: "commercetools_channel", ID: "1"}, nil)
+	assert.Equal(t, 0, posted)
+	n.end("created", notificationChange{Resource: "commercetools_channel", ID: "2"}, nil)
+	assert.Equal(t, 1, posted)
+
+	// No request is sent when nothing was changed
+	n.send(notificationSummary{})
+	assert.Equal(t, 1, posted)
+}
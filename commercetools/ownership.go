@@ -0,0 +1,179 @@
+package commercetools
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// ownershipResources are the resources which expose the API clients which
+// created and last modified them
+var ownershipResources = []string{
+	"commercetools_api_extension",
+	"commercetools_cart_discount",
+	"commercetools_category",
+	"commercetools_channel",
+	"commercetools_customer_group",
+	"commercetools_discount_code",
+	"commercetools_product_selection",
+	"commercetools_product_type",
+	"commercetools_shipping_method",
+	"commercetools_shipping_zone",
+	"commercetools_state",
+	"commercetools_store",
+	"commercetools_subscription",
+	"commercetools_tax_category",
+	"commercetools_type",
+}
+
+// resourceOwnership holds the ids of the API clients which created and last
+// modified a resource
+type resourceOwnership struct {
+	CreatedBy      string
+	LastModifiedBy string
+}
+
+// ownershipTransport records the createdBy and lastModifiedBy client ids of
+// the resources returned by the API, so the resources can expose them without
+// fetching them again
+type ownershipTransport struct {
+	base http.RoundTripper
+
+	mu        sync.Mutex
+	resources map[string]resourceOwnership
+}
+
+func newOwnershipTransport(base http.RoundTripper) *ownershipTransport {
+	return &ownershipTransport{
+		base:      base,
+		resources: map[string]resourceOwnership{},
+	}
+}
+
+func (t *ownershipTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp, err
+	}
+	if !strings.Contains(resp.Header.Get("Content-Type"), "json") {
+		return resp, err
+	}
+
+	body, readErr := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if readErr != nil {
+		return resp, err
+	}
+
+	var data struct {
+		ID        string `json:"id"`
+		CreatedBy *struct {
+			ClientID string `json:"clientId"`
+		} `json:"createdBy"`
+		LastModifiedBy *struct {
+			ClientID string `json:"clientId"`
+		} `json:"lastModifiedBy"`
+	}
+	if json.Unmarshal(body, &data) != nil || data.ID == "" {
+		return resp, err
+	}
+
+	ownership := resourceOwnership{}
+	if data.CreatedBy != nil {
+		ownership.CreatedBy = data.CreatedBy.ClientID
+	}
+	if data.LastModifiedBy != nil {
+		ownership.LastModifiedBy = data.LastModifiedBy.ClientID
+	}
+
+	t.mu.Lock()
+	t.resources[data.ID] = ownership
+	t.mu.Unlock()
+	return resp, err
+}
+
+// get returns the ownership of the resource with the id, if it was returned
+// by the API
+func (t *ownershipTransport) get(id string) (resourceOwnership, bool) {
+	if t == nil {
+		return resourceOwnership{}, false
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	ownership, ok := t.resources[id]
+	return ownership, ok
+}
+
+// applyOwnership adds the created_by_client_id, last_modified_by_client_id
+// and expected_owner_client_id attributes to the resources. When the resource
+// was last modified by another client than the expected owner a warning is
+// logged, since the change was made outside of terraform.
+func applyOwnership(resources map[string]*schema.Resource) error {
+	for _, name := range ownershipResources {
+		resource, ok := resources[name]
+		if !ok {
+			return fmt.Errorf("ownership for unknown resource %s", name)
+		}
+		resource.Schema["created_by_client_id"] = &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The id of the API client which created the resource",
+		}
+		resource.Schema["last_modified_by_client_id"] = &schema.Schema{
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "The id of the API client which last modified the resource",
+		}
+		resource.Schema["expected_owner_client_id"] = &schema.Schema{
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The id of the API client used by terraform, a warning is logged when the resource was last modified by another client",
+		}
+		resource.Create = ownershipFunc(name, resource.Create)
+		resource.Read = ownershipFunc(name, resource.Read)
+		resource.Update = ownershipFunc(name, resource.Update)
+	}
+	return nil
+}
+
+func ownershipFunc(name string, f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+	if f == nil {
+		return nil
+	}
+	return func(d *schema.ResourceData, m interface{}) error {
+		if err := f(d, m); err != nil {
+			return err
+		}
+		// The resource is removed from the state when it no longer exists
+		if d.Id() == "" {
+			return nil
+		}
+		meta, ok := m.(*providerMeta)
+		if !ok {
+			return nil
+		}
+		ownership, ok := meta.ownership.get(d.Id())
+		if !ok {
+			return nil
+		}
+
+		expected := d.Get("expected_owner_client_id").(string)
+		if expected != "" && ownership.LastModifiedBy != "" && ownership.LastModifiedBy != expected {
+			log.Printf("[WARN] The %s %s was last modified by API client %s instead of %s, "+
+				"it was changed outside of terraform", name, d.Id(), ownership.LastModifiedBy, expected)
+		}
+
+		if err := d.Set("created_by_client_id", ownership.CreatedBy); err != nil {
+			return err
+		}
+		return d.Set("last_modified_by_client_id", ownership.LastModifiedBy)
+	}
+}
@@ -0,0 +1,95 @@
+package commercetools
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOwnershipTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		switch r.URL.Path {
+		case "/my-project/types/type-id":
+			fmt.Fprint(w, `{
+				"id": "type-id",
+				"version": 2,
+				"createdBy": {"clientId": "terraform"},
+				"lastModifiedBy": {"clientId": "merchant-center"}
+			}`)
+		default:
+			fmt.Fprint(w, `{"id": "other-id", "version": 1}`)
+		}
+	}))
+	defer server.Close()
+
+	transport := newOwnershipTransport(http.DefaultTransport)
+	client := &http.Client{Transport: transport}
+
+	for _, path := range []string{"/my-project/types/type-id", "/my-project/channels/other-id"} {
+		resp, err := client.Get(server.URL + path)
+		assert.NoError(t, err)
+		body, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		assert.NoError(t, err)
+		assert.Contains(t, string(body), `"version"`)
+	}
+
+	ownership, ok := transport.get("type-id")
+	assert.True(t, ok)
+	assert.Equal(t, resourceOwnership{CreatedBy: "terraform", LastModifiedBy: "merchant-center"}, ownership)
+
+	ownership, ok = transport.get("other-id")
+	assert.True(t, ok)
+	assert.Equal(t, resourceOwnership{}, ownership)
+
+	_, ok = transport.get("unknown")
+	assert.False(t, ok)
+
+	var nilTransport *ownershipTransport
+	_, ok = nilTransport.get("type-id")
+	assert.False(t, ok)
+}
+
+func TestOwnershipFunc(t *testing.T) {
+	resources := Provider().(*schema.Provider).ResourcesMap
+	for _, name := range ownershipResources {
+		assert.Contains(t, resources[name].Schema, "expected_owner_client_id", name)
+	}
+
+	transport := newOwnershipTransport(http.DefaultTransport)
+	transport.resources["type-id"] = resourceOwnership{CreatedBy: "terraform", LastModifiedBy: "merchant-center"}
+
+	read := ownershipFunc("commercetools_type", func(d *schema.ResourceData, m interface{}) error {
+		return nil
+	})
+	d := schema.TestResourceDataRaw(t, resources["commercetools_type"].Schema, map[string]interface{}{
+		"expected_owner_client_id": "terraform",
+	})
+	d.SetId("type-id")
+
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	assert.NoError(t, read(d, &providerMeta{ownership: transport}))
+	assert.Equal(t, "terraform", d.Get("created_by_client_id"))
+	assert.Equal(t, "merchant-center", d.Get("last_modified_by_client_id"))
+	assert.Contains(t, logs.String(),
+		"[WARN] The commercetools_type type-id was last modified by API client merchant-center instead of terraform")
+
+	// No warning when terraform made the last change
+	logs.Reset()
+	transport.resources["type-id"] = resourceOwnership{CreatedBy: "terraform", LastModifiedBy: "terraform"}
+	assert.NoError(t, read(d, &providerMeta{ownership: transport}))
+	assert.Equal(t, "terraform", d.Get("last_modified_by_client_id"))
+	assert.Empty(t, logs.String())
+}
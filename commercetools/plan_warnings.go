@@ -0,0 +1,95 @@
+package commercetools
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// planWarningsFunc returns the warnings for the planned change of a resource
+type planWarningsFunc func(d *schema.ResourceDiff, m interface{}) ([]string, error)
+
+// planWarningsResources are the resources which show warnings about the
+// planned change in the plan_warnings attribute. Terraform has no warnings
+// during the plan, and logged warnings are only visible with TF_LOG.
+var planWarningsResources = map[string]planWarningsFunc{
+	"commercetools_discount_code": resourceDiscountCodePlanWarnings,
+	"commercetools_product_type":  resourceProductTypePlanWarnings,
+	"commercetools_type":          resourceTypePlanWarnings,
+}
+
+// applyPlanWarnings adds the computed plan_warnings attribute to the resources
+// in planWarningsResources. During the plan the attribute contains the
+// warnings about the change, for example that removing a field removes its
+// values from all objects. The attribute is empty in the state.
+func applyPlanWarnings(resources map[string]*schema.Resource) error {
+	for name, f := range planWarningsResources {
+		resource, ok := resources[name]
+		if !ok {
+			return fmt.Errorf("plan warnings for unknown resource %s", name)
+		}
+		resource.Schema["plan_warnings"] = &schema.Schema{
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Warnings about the planned change, only set during the plan",
+		}
+
+		check := planWarningsCustomizeDiff(f)
+		if resource.CustomizeDiff != nil {
+			resource.CustomizeDiff = customdiff.All(resource.CustomizeDiff, check)
+		} else {
+			resource.CustomizeDiff = check
+		}
+		resource.Read = clearPlanWarningsFunc(resource.Read)
+	}
+	return nil
+}
+
+func planWarningsCustomizeDiff(f planWarningsFunc) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, m interface{}) error {
+		warnings, err := f(d, m)
+		if err != nil {
+			return err
+		}
+		if len(warnings) == 0 {
+			return nil
+		}
+		for _, warning := range warnings {
+			log.Printf("[WARN] %s", warning)
+		}
+		return d.SetNew("plan_warnings", warnings)
+	}
+}
+
+// clearPlanWarningsFunc wraps the read function of a resource, so the
+// warnings are removed from the state once the change is applied
+func clearPlanWarningsFunc(f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+	return func(d *schema.ResourceData, m interface{}) error {
+		if err := f(d, m); err != nil {
+			return err
+		}
+		if d.Id() == "" {
+			return nil
+		}
+		return d.Set("plan_warnings", []string{})
+	}
+}
+
+// updateActionCountWarning returns a warning when the change of a resource
+// results in a large number of update actions, so reviewers notice
+// potentially long running changes (for example reordering many enum values)
+func updateActionCountWarning(count int, m interface{}) []string {
+	threshold := defaultUpdateActionWarningThreshold
+	if meta, ok := m.(*providerMeta); ok && meta.updateActionWarningThreshold > 0 {
+		threshold = meta.updateActionWarningThreshold
+	}
+	if count <= threshold {
+		return nil
+	}
+	return []string{fmt.Sprintf(
+		"the change results in %d update actions, which is more than the threshold of %d",
+		count, threshold)}
+}
@@ -0,0 +1,17 @@
+package commercetools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateActionCountWarning(t *testing.T) {
+	assert.Empty(t, updateActionCountWarning(100, &providerMeta{}))
+	assert.Equal(t,
+		[]string{"the change results in 101 update actions, which is more than the threshold of 100"},
+		updateActionCountWarning(101, &providerMeta{}))
+	assert.Equal(t,
+		[]string{"the change results in 11 update actions, which is more than the threshold of 10"},
+		updateActionCountWarning(11, &providerMeta{updateActionWarningThreshold: 10}))
+}
@@ -0,0 +1,138 @@
+package commercetools
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// resourceChange is implemented by both schema.ResourceData and
+// schema.ResourceDiff, so the update actions of a resource can be computed
+// during the plan as well as during the apply
+type resourceChange interface {
+	Id() string
+	Get(key string) interface{}
+	GetChange(key string) (interface{}, interface{})
+	HasChange(key string) bool
+}
+
+// plannedActionsFunc returns the update actions for the changes of the
+// resource, as a slice of the update action type of the resource
+type plannedActionsFunc func(d resourceChange, m interface{}) (interface{}, error)
+
+// plannedActionsResources are the resources which show the update actions in
+// the planned_actions attribute during the plan
+var plannedActionsResources = map[string]plannedActionsFunc{
+	"commercetools_cart_discount": func(d resourceChange, m interface{}) (interface{}, error) {
+		return resourceCartDiscountUpdateActions(d)
+	},
+	"commercetools_discount_code": func(d resourceChange, m interface{}) (interface{}, error) {
+		return resourceDiscountCodeUpdateActions(d)
+	},
+	"commercetools_product_discount": func(d resourceChange, m interface{}) (interface{}, error) {
+		return resourceProductDiscountUpdateActions(d)
+	},
+	"commercetools_product_type": func(d resourceChange, m interface{}) (interface{}, error) {
+		return resourceProductTypeUpdateActions(d, getClient(m))
+	},
+	"commercetools_type": func(d resourceChange, m interface{}) (interface{}, error) {
+		if d.HasChange("resource_type_ids") {
+			// The type is replaced, which isn't done with update actions
+			return nil, nil
+		}
+		return resourceTypeUpdateActions(d)
+	},
+}
+
+// applyPlannedActions adds the computed planned_actions attribute to the
+// resources in plannedActionsResources. During the plan of an update the
+// attribute contains the update actions (as JSON) which will be sent to
+// commercetools, so reviewers can spot risky actions like
+// removeAttributeDefinition. The attribute is empty in the state.
+func applyPlannedActions(resources map[string]*schema.Resource) error {
+	for name, f := range plannedActionsResources {
+		resource, ok := resources[name]
+		if !ok {
+			return fmt.Errorf("planned actions for unknown resource %s", name)
+		}
+		resource.Schema["planned_actions"] = &schema.Schema{
+			Type:        schema.TypeList,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "The update actions which will be performed, only set during the plan",
+		}
+
+		check := plannedActionsCustomizeDiff(resource, f)
+		if resource.CustomizeDiff != nil {
+			resource.CustomizeDiff = customdiff.All(resource.CustomizeDiff, check)
+		} else {
+			resource.CustomizeDiff = check
+		}
+		resource.Read = clearPlannedActionsFunc(resource.Read)
+	}
+	return nil
+}
+
+func plannedActionsCustomizeDiff(resource *schema.Resource, f plannedActionsFunc) schema.CustomizeDiffFunc {
+	return func(d *schema.ResourceDiff, m interface{}) error {
+		if d.Id() == "" {
+			return nil
+		}
+		for key, attribute := range resource.Schema {
+			if (attribute.Optional || attribute.Required) && !d.NewValueKnown(key) {
+				// The actions depend on values which are only known during
+				// the apply
+				return d.SetNewComputed("planned_actions")
+			}
+		}
+
+		actions, err := f(d, m)
+		if err != nil {
+			// Invalid changes are reported when applying the change
+			log.Printf("[DEBUG] Unable to compute the planned actions: %s", err)
+			return d.SetNewComputed("planned_actions")
+		}
+		planned := formatPlannedActions(actions)
+		if len(planned) == 0 {
+			return nil
+		}
+		return d.SetNew("planned_actions", planned)
+	}
+}
+
+// clearPlannedActionsFunc wraps the read function of a resource, so the
+// planned actions are removed from the state once they are applied
+func clearPlannedActionsFunc(f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+	return func(d *schema.ResourceData, m interface{}) error {
+		if err := f(d, m); err != nil {
+			return err
+		}
+		if d.Id() == "" {
+			return nil
+		}
+		return d.Set("planned_actions", []string{})
+	}
+}
+
+// formatPlannedActions returns the actions, a slice of any update action
+// type, as JSON strings
+func formatPlannedActions(actions interface{}) []string {
+	value := reflect.ValueOf(actions)
+	if value.Kind() != reflect.Slice {
+		return []string{}
+	}
+	result := make([]string, 0, value.Len())
+	for i := 0; i < value.Len(); i++ {
+		data, err := json.Marshal(value.Index(i).Interface())
+		if err != nil {
+			result = append(result, fmt.Sprintf("%v", value.Index(i).Interface()))
+			continue
+		}
+		result = append(result, string(data))
+	}
+	return result
+}
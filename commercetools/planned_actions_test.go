@@ -0,0 +1,53 @@
+package commercetools
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/labd/commercetools-go-sdk/commercetools"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatPlannedActions(t *testing.T) {
+	assert.Equal(t, []string{
+		`{"action":"changeName","name":"Shirt"}`,
+		`{"action":"removeAttributeDefinition","name":"size"}`,
+	}, formatPlannedActions([]commercetools.ProductTypeUpdateAction{
+		&commercetools.ProductTypeChangeNameAction{Name: "Shirt"},
+		commercetools.ProductTypeRemoveAttributeDefinitionAction{Name: "size"},
+	}))
+	assert.Equal(t, []string{}, formatPlannedActions(nil))
+}
+
+func TestPlannedActionsDiff(t *testing.T) {
+	resource := Provider().(*schema.Provider).ResourcesMap["commercetools_product_type"]
+	state := &terraform.InstanceState{
+		ID: "1234",
+		Attributes: map[string]string{
+			"id":          "1234",
+			"name":        "Shirt",
+			"version":     "1",
+			"attribute.#": "0",
+			// Set by reading the resource
+			"planned_actions.#": "0",
+			"plan_warnings.#":   "0",
+			"mc_url":            "",
+		},
+	}
+	meta := &providerMeta{updateActionWarningThreshold: defaultUpdateActionWarningThreshold}
+
+	diff, err := resource.Diff(state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name": "T-shirt",
+	}), meta)
+	assert.NoError(t, err)
+	assert.Equal(t, "1", diff.Attributes["planned_actions.#"].New)
+	assert.Equal(t, `{"action":"changeName","name":"T-shirt"}`, diff.Attributes["planned_actions.0"].New)
+
+	// Without changes the attribute is not part of the plan
+	diff, err = resource.Diff(state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name": "Shirt",
+	}), meta)
+	assert.NoError(t, err)
+	assert.Nil(t, diff)
+}
@@ -0,0 +1,26 @@
+package commercetools
+
+import (
+	"sync"
+
+	"github.com/labd/commercetools-go-sdk/commercetools"
+)
+
+// projectCache fetches the project settings once per run of the provider, so
+// validations during the plan don't fetch the project for every resource
+type projectCache struct {
+	once    sync.Once
+	project *commercetools.Project
+	err     error
+}
+
+// get returns the project settings, a nil cache always fetches them
+func (c *projectCache) get(client *commercetools.Client) (*commercetools.Project, error) {
+	if c == nil {
+		return client.ProjectGet()
+	}
+	c.once.Do(func() {
+		c.project, c.err = client.ProjectGet()
+	})
+	return c.project, c.err
+}
@@ -0,0 +1,40 @@
+package commercetools
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labd/commercetools-go-sdk/commercetools"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectCache(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"key": "my-project", "languages": ["en", "nl"]}`))
+	}))
+	defer server.Close()
+
+	client := commercetools.New(&commercetools.Config{
+		ProjectKey: "my-project",
+		URL:        server.URL,
+		HTTPClient: server.Client(),
+	})
+
+	cache := &projectCache{}
+	for i := 0; i < 3; i++ {
+		project, err := cache.get(client)
+		assert.NoError(t, err)
+		assert.Equal(t, []commercetools.Locale{"en", "nl"}, project.Languages)
+	}
+	assert.Equal(t, 1, requests)
+
+	// Without a cache the project is fetched every time
+	var noCache *projectCache
+	_, err := noCache.get(client)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, requests)
+}
@@ -3,10 +3,13 @@ package commercetools
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"os"
 	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/mutexkv"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
 	"github.com/labd/commercetools-go-sdk/commercetools"
 	"golang.org/x/oauth2/clientcredentials"
@@ -14,22 +17,42 @@ import (
 
 // Provider returns a terraform.ResourceProvider.
 func Provider() terraform.ResourceProvider {
-	return &schema.Provider{
+	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"client_id": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("CTP_CLIENT_ID", nil),
 				Description: "The OAuth Client ID for a commercetools platform project. https://docs.commercetools.com/http-api-authorization",
 				Sensitive:   true,
 			},
 			"client_secret": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("CTP_CLIENT_SECRET", nil),
 				Description: "The OAuth Client Secret for a commercetools platform project. https://docs.commercetools.com/http-api-authorization",
 				Sensitive:   true,
 			},
+			"read_client_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("CTP_READ_CLIENT_ID", ""),
+				Description: "The OAuth Client ID used for requests which only read data, for example during a plan.",
+				Sensitive:   true,
+			},
+			"read_client_secret": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("CTP_READ_CLIENT_SECRET", ""),
+				Description: "The OAuth Client Secret used for requests which only read data.",
+				Sensitive:   true,
+			},
+			"read_scopes": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("CTP_READ_SCOPES", ""),
+				Description: "A list as string of the OAuth scopes of the read client, for example the view_* scopes.",
+			},
 			"project_key": {
 				Type:        schema.TypeString,
 				Required:    true,
@@ -39,7 +62,7 @@ func Provider() terraform.ResourceProvider {
 			},
 			"scopes": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("CTP_SCOPES", nil),
 				Description: "A list as string of OAuth scopes assigned to a project key, to access resources in a commercetools platform project. https://docs.commercetools.com/http-api-authorization",
 			},
@@ -55,32 +78,148 @@ func Provider() terraform.ResourceProvider {
 				DefaultFunc: schema.EnvDefaultFunc("CTP_AUTH_URL", nil),
 				Description: "The authentication URL of the commercetools platform. https://docs.commercetools.com/http-api-authorization",
 			},
+			"custom_object_encryption_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("CTP_CUSTOM_OBJECT_ENCRYPTION_KEY", nil),
+				Description: "The base64 encoded 32 byte key used to encrypt the values of custom objects with encrypted set.",
+			},
+			"audit_log_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("CTP_AUDIT_LOG_FILE", nil),
+				Description: "Append every mutating request to this file, one JSON object per line.",
+			},
+			"snapshot_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("CTP_SNAPSHOT_FILE", nil),
+				Description: "Write the remote representation of every object read or changed by the provider to this JSON file.",
+			},
+			"notification_webhook_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("CTP_NOTIFICATION_WEBHOOK_URL", nil),
+				Description: "Post a summary of the created, updated and deleted resources to this URL during an apply.",
+			},
+			"update_action_warning_threshold": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      defaultUpdateActionWarningThreshold,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Show a warning in the plan_warnings attribute when a single resource change results in more update actions than this threshold.",
+			},
+			"circuit_breaker_threshold": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("CTP_CIRCUIT_BREAKER_THRESHOLD", 0),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Stop sending requests to an endpoint of the API for 30 seconds after this number of consecutive failures. Disabled (0) by default.",
+			},
+			"auto_readopt_by_key": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("CTP_AUTO_READOPT_BY_KEY", false),
+				Description: "Re-adopt resources by their key when the id in the state no longer exists, for example after they were recreated outside of terraform.",
+			},
+			"validate_requests": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("CTP_VALIDATE_REQUESTS", false),
+				Description: "Validate the drafts and update actions of a few endpoints against partial, hand-written schemas before sending them.",
+			},
+			"serialize_resource_types": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Resource types, for example commercetools_type, of which the resources are created, updated and deleted one at a time.",
+			},
+			"experiments": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Experimental features to enable, these may change in any release.",
+			},
+		},
+		DataSourcesMap: map[string]*schema.Resource{
+			"commercetools_cart_discount_preview":     dataSourceCartDiscountPreview(),
+			"commercetools_customer_groups":           dataSourceCustomerGroups(),
+			"commercetools_key_references":            dataSourceKeyReferences(),
+			"commercetools_matching_shipping_methods": dataSourceMatchingShippingMethods(),
+			"commercetools_product":                   dataSourceProduct(),
+			"commercetools_product_type":              dataSourceProductType(),
+			"commercetools_provider_info":             dataSourceProviderInfo(),
+			"commercetools_search_index_status":       dataSourceSearchIndexStatus(),
+			"commercetools_states":                    dataSourceStates(),
+			"commercetools_type":                      dataSourceType(),
+			"commercetools_types":                     dataSourceTypes(),
+			"commercetools_zones":                     dataSourceZones(),
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"commercetools_api_client":         resourceAPIClient(),
-			"commercetools_api_extension":      resourceAPIExtension(),
-			"commercetools_cart_discount":      resourceCartDiscount(),
-			"commercetools_channel":            resourceChannel(),
-			"commercetools_custom_object":      resourceCustomObject(),
-			"commercetools_customer_group":     resourceCustomerGroup(),
-			"commercetools_discount_code":      resourceDiscountCode(),
-			"commercetools_product_type":       resourceProductType(),
-			"commercetools_project_settings":   resourceProjectSettings(),
-			"commercetools_shipping_method":    resourceShippingMethod(),
-			"commercetools_shipping_zone_rate": resourceShippingZoneRate(),
-			"commercetools_shipping_zone":      resourceShippingZone(),
-			"commercetools_state":              resourceState(),
-			"commercetools_store":              resourceStore(),
-			"commercetools_subscription":       resourceSubscription(),
-			"commercetools_tax_category_rate":  resourceTaxCategoryRate(),
-			"commercetools_tax_category":       resourceTaxCategory(),
-			"commercetools_type":               resourceType(),
+			"commercetools_api_client":            resourceAPIClient(),
+			"commercetools_api_extension":         resourceAPIExtension(),
+			"commercetools_cart_discount":         resourceCartDiscount(),
+			"commercetools_category":              resourceCategory(),
+			"commercetools_category_tree":         resourceCategoryTree(),
+			"commercetools_channel":               resourceChannel(),
+			"commercetools_custom_field_backfill": resourceCustomFieldBackfill(),
+			"commercetools_custom_object":         resourceCustomObject(),
+			"commercetools_customer_group":        resourceCustomerGroup(),
+			"commercetools_discount_activation":   resourceDiscountActivation(),
+			"commercetools_discount_code":         resourceDiscountCode(),
+			"commercetools_product_discount":      resourceProductDiscount(),
+			"commercetools_product_selection":     resourceProductSelection(),
+			"commercetools_product_type":          resourceProductType(),
+			"commercetools_product_tailoring":     resourceProductTailoring(),
+			"commercetools_project_settings":      resourceProjectSettings(),
+			"commercetools_search_activation":     resourceSearchActivation(),
+			"commercetools_shipping_method":       resourceShippingMethod(),
+			"commercetools_shipping_zone_rate":    resourceShippingZoneRate(),
+			"commercetools_shipping_zone":         resourceShippingZone(),
+			"commercetools_snapshot_restore":      resourceSnapshotRestore(),
+			"commercetools_state":                 resourceState(),
+			"commercetools_state_transitions":     resourceStateTransitions(),
+			"commercetools_store":                 resourceStore(),
+			"commercetools_subscription":          resourceSubscription(),
+			"commercetools_tax_category_rate":     resourceTaxCategoryRate(),
+			"commercetools_tax_category":          resourceTaxCategory(),
+			"commercetools_type":                  resourceType(),
 		},
-		ConfigureFunc: providerConfigure,
 	}
+
+	if err := applyDeprecations(provider.ResourcesMap); err != nil {
+		panic(err)
+	}
+	if err := applyExperiments(provider.ResourcesMap); err != nil {
+		panic(err)
+	}
+	if err := applyOwnership(provider.ResourcesMap); err != nil {
+		panic(err)
+	}
+	if err := applyReadopt(provider.ResourcesMap); err != nil {
+		panic(err)
+	}
+	if err := applyMerchantCenterURLs(provider.ResourcesMap); err != nil {
+		panic(err)
+	}
+	if err := applyPlannedActions(provider.ResourcesMap); err != nil {
+		panic(err)
+	}
+	if err := applyPlanWarnings(provider.ResourcesMap); err != nil {
+		panic(err)
+	}
+	applySerialization(provider.ResourcesMap)
+	applyNotifications(provider.ResourcesMap)
+	applyAuditLog(provider.ResourcesMap)
+	applySnapshot(provider.ResourcesMap)
+	provider.ConfigureFunc = func(d *schema.ResourceData) (interface{}, error) {
+		return providerConfigure(d, provider.ResourcesMap)
+	}
+	return provider
 }
 
-func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+func providerConfigure(d *schema.ResourceData, resources map[string]*schema.Resource) (interface{}, error) {
 	clientID := d.Get("client_id").(string)
 	clientSecret := d.Get("client_secret").(string)
 	projectKey := d.Get("project_key").(string)
@@ -88,15 +227,102 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	apiURL := d.Get("api_url").(string)
 	authURL := d.Get("token_url").(string)
 
-	oauthScopes := strings.Split(scopesRaw, " ")
+	readClientID := d.Get("read_client_id").(string)
+	readClientSecret := d.Get("read_client_secret").(string)
+	readScopesRaw := d.Get("read_scopes").(string)
+
+	if (clientID == "" || clientSecret == "") && readClientID == "" {
+		return nil, fmt.Errorf("client_id and client_secret are required")
+	}
+	if clientID != "" && scopesRaw == "" {
+		return nil, fmt.Errorf("scopes are required when client_id is set")
+	}
+	if readClientID != "" && (readClientSecret == "" || readScopesRaw == "") {
+		return nil, fmt.Errorf("read_client_secret and read_scopes are required when read_client_id is set")
+	}
+
+	var writeTransport http.RoundTripper
+	if clientID != "" {
+		oauth2Config := &clientcredentials.Config{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			Scopes:       strings.Split(scopesRaw, " "),
+			TokenURL:     fmt.Sprintf("%s/oauth/token", authURL),
+		}
+		writeTransport = oauth2Config.Client(context.TODO()).Transport
+	}
+
+	httpClient := &http.Client{Transport: writeTransport}
+	if readClientID != "" {
+		readConfig := &clientcredentials.Config{
+			ClientID:     readClientID,
+			ClientSecret: readClientSecret,
+			Scopes:       strings.Split(readScopesRaw, " "),
+			TokenURL:     fmt.Sprintf("%s/oauth/token", authURL),
+		}
+		httpClient.Transport = newReadWriteTransport(readConfig.Client(context.TODO()).Transport, writeTransport)
+	}
+	httpClient.Transport = newAPIDeprecationTransport(httpClient.Transport)
+	ownership := newOwnershipTransport(httpClient.Transport)
+	httpClient.Transport = ownership
+
+	if threshold := d.Get("circuit_breaker_threshold").(int); threshold > 0 {
+		httpClient.Transport = newCircuitBreakerTransport(httpClient.Transport, threshold)
+	}
+
+	var auditLog *auditLogTransport
+	if auditLogFile := d.Get("audit_log_file").(string); auditLogFile != "" {
+		transport, err := newAuditLogTransport(httpClient.Transport, auditLogFile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to open audit log file: %w", err)
+		}
+		httpClient.Transport = transport
+		auditLog = transport
+	}
 
-	oauth2Config := &clientcredentials.Config{
-		ClientID:     clientID,
-		ClientSecret: clientSecret,
-		Scopes:       oauthScopes,
-		TokenURL:     fmt.Sprintf("%s/oauth/token", authURL),
+	var snapshot *snapshotTransport
+	if snapshotFile := d.Get("snapshot_file").(string); snapshotFile != "" {
+		transport, err := newSnapshotTransport(httpClient.Transport, snapshotFile, projectKey)
+		if err != nil {
+			return nil, fmt.Errorf("unable to write snapshot file: %w", err)
+		}
+		httpClient.Transport = transport
+		snapshot = transport
+	}
+
+	// The scope report is meant for diagnosing the scopes of the API client,
+	// so it can only be enabled via the environment
+	if scopeReportFile := os.Getenv("CTP_SCOPE_REPORT_FILE"); scopeReportFile != "" {
+		httpClient.Transport = newScopeReportTransport(httpClient.Transport, scopeReportFile, projectKey)
+	}
+
+	// Validate the requests first, so invalid requests are not recorded by
+	// the other transports
+	if d.Get("validate_requests").(bool) {
+		httpClient.Transport = newRequestValidationTransport(httpClient.Transport)
+	}
+
+	customObjectEncryptionKey, err := expandCustomObjectEncryptionKey(
+		d.Get("custom_object_encryption_key").(string))
+	if err != nil {
+		return nil, err
+	}
+
+	serializedResourceTypes, err := expandSerializedResourceTypes(
+		d.Get("serialize_resource_types").(*schema.Set).List(), resources)
+	if err != nil {
+		return nil, err
+	}
+
+	enabledExperiments := map[string]bool{}
+	for _, raw := range d.Get("experiments").(*schema.Set).List() {
+		name := raw.(string)
+		if !stringInSlice(name, experimentNames()) {
+			return nil, fmt.Errorf("unknown experiment %s, the available experiments are %s",
+				name, strings.Join(experimentNames(), ", "))
+		}
+		enabledExperiments[name] = true
 	}
-	httpClient := oauth2Config.Client(context.TODO())
 
 	client := commercetools.New(&commercetools.Config{
 		ProjectKey:   projectKey,
@@ -107,7 +333,47 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 		ContactEmail: "opensource@labdigital.nl",
 	})
 
-	return client, nil
+	var notifications *notifier
+	if webhookURL := d.Get("notification_webhook_url").(string); webhookURL != "" {
+		notifications = newNotifier(webhookURL, projectKey)
+	}
+
+	return &providerMeta{
+		client:                       client,
+		rest:                         newRestClient(httpClient, apiURL, projectKey),
+		updateActionWarningThreshold: d.Get("update_action_warning_threshold").(int),
+		serializedResourceTypes:      serializedResourceTypes,
+		experiments:                  enabledExperiments,
+		merchantCenterURL:            merchantCenterProjectURL(apiURL, projectKey),
+		discountCodeDeleter:          newDiscountCodeDeleter(),
+		customObjectEncryptionKey:    customObjectEncryptionKey,
+		projectCache:                 &projectCache{},
+		autoReadoptByKey:             d.Get("auto_readopt_by_key").(bool),
+		ownership:                    ownership,
+		notifier:                     notifications,
+		auditLog:                     auditLog,
+		snapshot:                     snapshot,
+	}, nil
+}
+
+// providerMeta is passed to all resources and data sources, use getClient
+// and getRestClient to retrieve the clients.
+type providerMeta struct {
+	client *commercetools.Client
+	rest   *restClient
+
+	updateActionWarningThreshold int
+	serializedResourceTypes      map[string]bool
+	experiments                  map[string]bool
+	merchantCenterURL            string
+	discountCodeDeleter          *discountCodeDeleter
+	customObjectEncryptionKey    []byte
+	projectCache                 *projectCache
+	autoReadoptByKey             bool
+	ownership                    *ownershipTransport
+	notifier                     *notifier
+	auditLog                     *auditLogTransport
+	snapshot                     *snapshotTransport
 }
 
 // This is a global MutexKV for use within this plugin.
@@ -0,0 +1,45 @@
+package commercetools
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// readOnlyError is returned for requests which change data when the provider
+// is only configured with the read credentials, these are not retried
+type readOnlyError struct {
+	Method string
+	Path   string
+}
+
+func (e *readOnlyError) Error() string {
+	return fmt.Sprintf(
+		"unable to send %s %s, the provider is only configured with read credentials, "+
+			"set client_id and client_secret to change resources", e.Method, e.Path)
+}
+
+// readWriteTransport sends the requests which only read data with the read
+// credentials and all other requests with the write credentials. The OAuth
+// tokens are requested on the first request, so a plan which only reads never
+// requests a token with the write credentials.
+type readWriteTransport struct {
+	read  http.RoundTripper
+	write http.RoundTripper
+}
+
+func newReadWriteTransport(read http.RoundTripper, write http.RoundTripper) *readWriteTransport {
+	return &readWriteTransport{read: read, write: write}
+}
+
+func (t *readWriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method == "GET" || req.Method == "HEAD" {
+		return t.read.RoundTrip(req)
+	}
+	if t.write == nil {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, &readOnlyError{Method: req.Method, Path: req.URL.Path}
+	}
+	return t.write.RoundTrip(req)
+}
@@ -0,0 +1,98 @@
+package commercetools
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingTransport struct {
+	name     string
+	requests *[]string
+}
+
+func (t recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	*t.requests = append(*t.requests, t.name+" "+req.Method)
+	recorder := httptest.NewRecorder()
+	recorder.WriteHeader(http.StatusOK)
+	return recorder.Result(), nil
+}
+
+func TestReadWriteTransport(t *testing.T) {
+	requests := []string{}
+	transport := newReadWriteTransport(
+		recordingTransport{name: "read", requests: &requests},
+		recordingTransport{name: "write", requests: &requests})
+	client := &http.Client{Transport: transport}
+
+	_, err := client.Get("http://localhost/my-project/types/1234")
+	assert.NoError(t, err)
+	_, err = client.Post("http://localhost/my-project/types/1234", "application/json", strings.NewReader("{}"))
+	assert.NoError(t, err)
+	req, _ := http.NewRequest("DELETE", "http://localhost/my-project/types/1234?version=1", nil)
+	_, err = client.Do(req)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"read GET", "write POST", "write DELETE"}, requests)
+}
+
+func TestReadWriteTransportReadOnly(t *testing.T) {
+	requests := []string{}
+	client := &http.Client{Transport: newReadWriteTransport(recordingTransport{name: "read", requests: &requests}, nil)}
+
+	_, err := client.Get("http://localhost/my-project/types/1234")
+	assert.NoError(t, err)
+
+	_, err = client.Post("http://localhost/my-project/types/1234", "application/json", strings.NewReader("{}"))
+	var readOnlyErr *readOnlyError
+	assert.True(t, errors.As(err, &readOnlyErr))
+	assert.Equal(t, "/my-project/types/1234", readOnlyErr.Path)
+	assert.False(t, handleCommercetoolsError(err).Retryable)
+
+	assert.Equal(t, []string{"read GET"}, requests)
+}
+
+func TestProviderConfigureReadClient(t *testing.T) {
+	// The credentials default to the environment of the acceptance tests
+	if os.Getenv("CTP_CLIENT_ID") != "" || os.Getenv("CTP_READ_CLIENT_ID") != "" {
+		t.Skip("the credentials are set in the environment")
+	}
+	provider := Provider().(*schema.Provider)
+	configure := func(raw map[string]interface{}) error {
+		config := map[string]interface{}{
+			"project_key": "my-project",
+			"api_url":     "https://api.europe-west1.gcp.commercetools.com",
+			"token_url":   "https://auth.europe-west1.gcp.commercetools.com",
+		}
+		for key, value := range raw {
+			config[key] = value
+		}
+		d := schema.TestResourceDataRaw(t, provider.Schema, config)
+		_, err := providerConfigure(d, provider.ResourcesMap)
+		return err
+	}
+
+	assert.NoError(t, configure(map[string]interface{}{
+		"read_client_id":     "read-client",
+		"read_client_secret": "secret",
+		"read_scopes":        "view_types:my-project",
+	}))
+	assert.NoError(t, configure(map[string]interface{}{
+		"client_id":          "write-client",
+		"client_secret":      "secret",
+		"scopes":             "manage_project:my-project",
+		"read_client_id":     "read-client",
+		"read_client_secret": "secret",
+		"read_scopes":        "view_types:my-project",
+	}))
+	assert.EqualError(t, configure(map[string]interface{}{}), "client_id and client_secret are required")
+	assert.EqualError(t, configure(map[string]interface{}{
+		"read_client_id": "read-client",
+	}), "read_client_secret and read_scopes are required when read_client_id is set")
+}
@@ -0,0 +1,94 @@
+package commercetools
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/labd/commercetools-go-sdk/commercetools"
+)
+
+// readoptEndpoints maps the resources which can be re-adopted to the
+// endpoint where they can be fetched by key. Resources of which the key is
+// not unique within the project (like product tailorings) are not listed.
+var readoptEndpoints = map[string]string{
+	"commercetools_api_extension":     "extensions",
+	"commercetools_cart_discount":     "cart-discounts",
+	"commercetools_category":          "categories",
+	"commercetools_channel":           "channels",
+	"commercetools_customer_group":    "customer-groups",
+	"commercetools_product_selection": "product-selections",
+	"commercetools_product_type":      "product-types",
+	"commercetools_shipping_method":   "shipping-methods",
+	"commercetools_shipping_zone":     "zones",
+	"commercetools_state":             "states",
+	"commercetools_store":             "stores",
+	"commercetools_subscription":      "subscriptions",
+	"commercetools_tax_category":      "tax-categories",
+	"commercetools_type":              "types",
+}
+
+// applyReadopt wraps the read of the resources which can be re-adopted. When
+// auto_readopt_by_key is enabled and the id in the state no longer exists,
+// the resource is looked up by its key. If it was recreated outside of
+// terraform the state is bound to the new id, instead of planning a create
+// which fails on the duplicate key.
+func applyReadopt(resources map[string]*schema.Resource) error {
+	for name, endpoint := range readoptEndpoints {
+		resource, ok := resources[name]
+		if !ok {
+			return fmt.Errorf("readopt endpoint for unknown resource %s", name)
+		}
+		if _, ok := resource.Schema["key"]; !ok {
+			return fmt.Errorf("resource %s has no key and can't be re-adopted", name)
+		}
+		resource.Read = readoptFunc(name, endpoint, resource.Read)
+	}
+	return nil
+}
+
+func readoptFunc(name string, endpoint string, read func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+	return func(d *schema.ResourceData, m interface{}) error {
+		id := d.Id()
+		key := d.Get("key").(string)
+		if err := read(d, m); err != nil {
+			return err
+		}
+
+		meta, ok := m.(*providerMeta)
+		if !ok || !meta.autoReadoptByKey || d.Id() != "" || id == "" || key == "" {
+			return nil
+		}
+
+		newID, err := readoptLookupKey(getRestClient(m), endpoint, key)
+		if err != nil {
+			return err
+		}
+		if newID == "" || newID == id {
+			return nil
+		}
+
+		log.Printf("[WARN] The %s with id %s no longer exists, re-adopting %s with the same key %s",
+			name, id, newID, key)
+		d.SetId(newID)
+		return read(d, m)
+	}
+}
+
+// readoptLookupKey returns the id of the resource with the key, or an empty
+// string if it doesn't exist
+func readoptLookupKey(client *restClient, endpoint string, key string) (string, error) {
+	result := struct {
+		ID string `json:"id"`
+	}{}
+	err := client.get(context.Background(), fmt.Sprintf("%s/key=%s", endpoint, url.PathEscape(key)), nil, &result)
+	if err != nil {
+		if ctErr, ok := err.(commercetools.ErrorResponse); ok && ctErr.StatusCode == 404 {
+			return "", nil
+		}
+		return "", err
+	}
+	return result.ID, nil
+}
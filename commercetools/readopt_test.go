@@ -0,0 +1,70 @@
+package commercetools
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyReadopt(t *testing.T) {
+	resources := Provider().(*schema.Provider).ResourcesMap
+	for name := range readoptEndpoints {
+		assert.Contains(t, resources[name].Schema, "key", name)
+	}
+	assert.Error(t, applyReadopt(map[string]*schema.Resource{}))
+}
+
+func TestReadoptFunc(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/my-project/types/key=order":
+			fmt.Fprint(w, `{"id": "new-id", "version": 1}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// The read removes the resource from the state unless it has the new id
+	reads := []string{}
+	read := readoptFunc("commercetools_type", "types", func(d *schema.ResourceData, m interface{}) error {
+		reads = append(reads, d.Id())
+		if d.Id() != "new-id" {
+			d.SetId("")
+		}
+		return nil
+	})
+	resource := resourceType()
+	newData := func(key string) *schema.ResourceData {
+		d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{"key": key})
+		d.SetId("old-id")
+		return d
+	}
+	meta := &providerMeta{
+		rest:             newRestClient(server.Client(), server.URL, "my-project"),
+		autoReadoptByKey: true,
+	}
+
+	d := newData("order")
+	assert.NoError(t, read(d, meta))
+	assert.Equal(t, "new-id", d.Id())
+	assert.Equal(t, []string{"old-id", "new-id"}, reads)
+
+	// No resource exists with the key
+	reads = []string{}
+	d = newData("customer")
+	assert.NoError(t, read(d, meta))
+	assert.Equal(t, "", d.Id())
+	assert.Equal(t, []string{"old-id"}, reads)
+
+	// Disabled
+	reads = []string{}
+	d = newData("order")
+	assert.NoError(t, read(d, &providerMeta{rest: meta.rest}))
+	assert.Equal(t, "", d.Id())
+	assert.Equal(t, []string{"old-id"}, reads)
+}
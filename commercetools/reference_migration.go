@@ -0,0 +1,69 @@
+package commercetools
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+)
+
+// referenceMigrationBatchSize is the number of objects fetched per request
+// while moving objects to another type or tax category
+const referenceMigrationBatchSize = 100
+
+// referenceMigrationObject is an object of any endpoint which references the
+// object being replaced
+type referenceMigrationObject struct {
+	ID      string `json:"id"`
+	Version int    `json:"version"`
+	Custom  *struct {
+		Fields map[string]interface{} `json:"fields"`
+	} `json:"custom"`
+}
+
+type referenceMigrationPage struct {
+	Results []referenceMigrationObject `json:"results"`
+}
+
+// migrateReferences applies the update action returned by action to all
+// objects of the endpoint matching the where predicate. The action needs to
+// move the object to the new reference, so moved objects no longer match the
+// predicate and the first page is fetched until it is empty. When a page
+// doesn't contain any object which could be moved the migration is aborted.
+func migrateReferences(client *restClient, endpoint string, where string, action func(referenceMigrationObject) map[string]interface{}) (int, error) {
+	query := url.Values{}
+	query.Set("where", where)
+	query.Set("sort", "id asc")
+	query.Set("limit", strconv.Itoa(referenceMigrationBatchSize))
+
+	migrated := 0
+	for {
+		page := &referenceMigrationPage{}
+		if err := client.get(context.Background(), endpoint, query, page); err != nil {
+			return migrated, err
+		}
+		if len(page.Results) == 0 {
+			return migrated, nil
+		}
+
+		var lastErr error
+		pageMigrated := 0
+		for _, object := range page.Results {
+			err := client.update(
+				context.Background(), fmt.Sprintf("%s/%s", endpoint, object.ID), nil,
+				object.Version, []interface{}{action(object)}, nil)
+			if err != nil {
+				log.Printf("[DEBUG] Failed to move %s %s: %s", endpoint, object.ID, err)
+				lastErr = err
+				continue
+			}
+			pageMigrated++
+		}
+
+		migrated += pageMigrated
+		if pageMigrated == 0 {
+			return migrated, lastErr
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package commercetools
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrateReferencesAborts(t *testing.T) {
+	updates := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == "GET" {
+			assert.Equal(t, `taxCategory(id = "old")`, r.URL.Query().Get("where"))
+			w.Write([]byte(`{"results": [{"id": "1", "version": 1}]}`))
+			return
+		}
+		// The object can't be moved, so it keeps matching the predicate
+		updates++
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"statusCode": 400, "message": "invalid"}`))
+	}))
+	defer server.Close()
+
+	client := newRestClient(server.Client(), server.URL, "my-project")
+	migrated, err := migrateReferences(client, "products", `taxCategory(id = "old")`,
+		func(object referenceMigrationObject) map[string]interface{} {
+			return map[string]interface{}{"action": "setTaxCategory"}
+		})
+	assert.Error(t, err)
+	assert.Equal(t, 0, migrated)
+	assert.Equal(t, 1, updates)
+}
@@ -0,0 +1,194 @@
+package commercetools
+
+// The drafts and update actions below are hand-written JSON schemas for the
+// validator in json_schema.go, they are not generated from the commercetools
+// API specification. They only describe the required fields and the enum
+// literals of a few endpoints, the API itself remains the authority on
+// everything else. Endpoints and update actions which are not listed here are
+// not validated.
+
+const requestSchemaLocalizedString = `{"type": "object", "additionalProperties": {"type": "string"}}`
+
+const requestSchemaFieldType = `{
+	"type": "object",
+	"required": ["name"],
+	"properties": {
+		"name": {"enum": ["Boolean", "String", "LocalizedString", "Enum", "LocalizedEnum", "Number",
+			"Money", "Date", "Time", "DateTime", "Reference", "Set"]},
+		"values": {"type": "array", "items": {"type": "object", "required": ["key", "label"]}},
+		"referenceTypeId": {"type": "string", "minLength": 1}
+	}
+}`
+
+const requestSchemaFieldDefinition = `{
+	"type": "object",
+	"required": ["type", "name", "label", "required"],
+	"properties": {
+		"type": ` + requestSchemaFieldType + `,
+		"name": {"type": "string", "minLength": 1},
+		"label": ` + requestSchemaLocalizedString + `,
+		"required": {"type": "boolean"},
+		"inputHint": {"enum": ["SingleLine", "MultiLine"]}
+	}
+}`
+
+const requestSchemaChannelRoles = `{
+	"type": "array",
+	"items": {"enum": ["InventorySupply", "ProductDistribution", "OrderExport", "OrderImport", "Primary"]}
+}`
+
+const requestSchemaStateRoles = `{
+	"type": "array",
+	"items": {"enum": ["ReviewIncludedInStatistics", "Return"]}
+}`
+
+const requestSchemaStateType = `{"enum": ["OrderState", "LineItemState", "ProductState", "ReviewState", "PaymentState"]}`
+
+// requestSchemaUpdate is the body of all update requests
+const requestSchemaUpdate = `{
+	"type": "object",
+	"required": ["version", "actions"],
+	"properties": {
+		"version": {"type": "integer", "minimum": 1},
+		"actions": {
+			"type": "array",
+			"minItems": 1,
+			"maxItems": 500,
+			"items": {
+				"type": "object",
+				"required": ["action"],
+				"properties": {"action": {"type": "string", "minLength": 1}}
+			}
+		}
+	}
+}`
+
+// requestSchemaDrafts are the drafts used to create resources, by endpoint
+var requestSchemaDrafts = map[string]string{
+	"channels": `{
+		"type": "object",
+		"required": ["key"],
+		"properties": {
+			"key": {"type": "string", "minLength": 1},
+			"roles": ` + requestSchemaChannelRoles + `
+		}
+	}`,
+	"custom-objects": `{
+		"type": "object",
+		"required": ["container", "key", "value"],
+		"properties": {
+			"container": {"type": "string", "pattern": "^[-_~.a-zA-Z0-9]+$"},
+			"key": {"type": "string", "minLength": 1}
+		}
+	}`,
+	"customer-groups": `{
+		"type": "object",
+		"required": ["groupName"],
+		"properties": {
+			"groupName": {"type": "string", "minLength": 1}
+		}
+	}`,
+	"states": `{
+		"type": "object",
+		"required": ["key", "type"],
+		"properties": {
+			"key": {"type": "string", "minLength": 1},
+			"type": ` + requestSchemaStateType + `,
+			"roles": ` + requestSchemaStateRoles + `
+		}
+	}`,
+	"stores": `{
+		"type": "object",
+		"required": ["key"],
+		"properties": {
+			"key": {"type": "string", "minLength": 1},
+			"languages": {"type": "array", "items": {"type": "string"}}
+		}
+	}`,
+	"tax-categories": `{
+		"type": "object",
+		"required": ["name"],
+		"properties": {
+			"name": {"type": "string", "minLength": 1},
+			"rates": {
+				"type": ["array", "null"],
+				"items": {
+					"type": "object",
+					"required": ["name", "includedInPrice", "country"],
+					"properties": {
+						"amount": {"type": "number", "minimum": 0, "maximum": 1},
+						"country": {"type": "string", "pattern": "^[A-Z]{2}$"}
+					}
+				}
+			}
+		}
+	}`,
+	"types": `{
+		"type": "object",
+		"required": ["key", "name", "resourceTypeIds"],
+		"properties": {
+			"key": {"type": "string", "minLength": 1},
+			"name": ` + requestSchemaLocalizedString + `,
+			"resourceTypeIds": {"type": "array", "items": {"type": "string"}},
+			"fieldDefinitions": {"type": "array", "items": ` + requestSchemaFieldDefinition + `}
+		}
+	}`,
+	"zones": `{
+		"type": "object",
+		"required": ["name"],
+		"properties": {
+			"name": {"type": "string", "minLength": 1},
+			"locations": {
+				"type": ["array", "null"],
+				"items": {"type": "object", "required": ["country"]}
+			}
+		}
+	}`,
+}
+
+// requestSchemaActions are the update actions by endpoint and action name
+var requestSchemaActions = map[string]map[string]string{
+	"channels": {
+		"addRoles":    `{"required": ["roles"], "properties": {"roles": ` + requestSchemaChannelRoles + `}}`,
+		"changeKey":   `{"required": ["key"], "properties": {"key": {"type": "string", "minLength": 1}}}`,
+		"removeRoles": `{"required": ["roles"], "properties": {"roles": ` + requestSchemaChannelRoles + `}}`,
+		"setRoles":    `{"required": ["roles"], "properties": {"roles": ` + requestSchemaChannelRoles + `}}`,
+	},
+	"states": {
+		"addRoles":    `{"required": ["roles"], "properties": {"roles": ` + requestSchemaStateRoles + `}}`,
+		"changeKey":   `{"required": ["key"], "properties": {"key": {"type": "string", "minLength": 1}}}`,
+		"changeType":  `{"required": ["type"], "properties": {"type": ` + requestSchemaStateType + `}}`,
+		"removeRoles": `{"required": ["roles"], "properties": {"roles": ` + requestSchemaStateRoles + `}}`,
+		"setRoles":    `{"required": ["roles"], "properties": {"roles": ` + requestSchemaStateRoles + `}}`,
+	},
+	"types": {
+		"addEnumValue": `{
+			"required": ["fieldName", "value"],
+			"properties": {"value": {"type": "object", "required": ["key", "label"], "properties": {"label": {"type": "string"}}}}
+		}`,
+		"addFieldDefinition": `{"required": ["fieldDefinition"], "properties": {"fieldDefinition": ` + requestSchemaFieldDefinition + `}}`,
+		"addLocalizedEnumValue": `{
+			"required": ["fieldName", "value"],
+			"properties": {"value": {"type": "object", "required": ["key", "label"], "properties": {"label": ` + requestSchemaLocalizedString + `}}}
+		}`,
+		"changeEnumValueLabel": `{
+			"required": ["fieldName", "value"],
+			"properties": {"value": {"type": "object", "required": ["key", "label"], "properties": {"label": {"type": "string"}}}}
+		}`,
+		"changeEnumValueOrder":       `{"required": ["fieldName", "keys"], "properties": {"keys": {"type": "array", "items": {"type": "string"}}}}`,
+		"changeFieldDefinitionOrder": `{"required": ["fieldNames"], "properties": {"fieldNames": {"type": "array", "items": {"type": "string"}}}}`,
+		"changeInputHint": `{
+			"required": ["fieldName", "inputHint"],
+			"properties": {"inputHint": {"enum": ["SingleLine", "MultiLine"]}}
+		}`,
+		"changeKey":   `{"required": ["key"], "properties": {"key": {"type": "string", "minLength": 1}}}`,
+		"changeLabel": `{"required": ["fieldName", "label"], "properties": {"label": ` + requestSchemaLocalizedString + `}}`,
+		"changeLocalizedEnumValueLabel": `{
+			"required": ["fieldName", "value"],
+			"properties": {"value": {"type": "object", "required": ["key", "label"], "properties": {"label": ` + requestSchemaLocalizedString + `}}}
+		}`,
+		"changeLocalizedEnumValueOrder": `{"required": ["fieldName", "keys"], "properties": {"keys": {"type": "array", "items": {"type": "string"}}}}`,
+		"changeName":                    `{"required": ["name"], "properties": {"name": ` + requestSchemaLocalizedString + `}}`,
+		"removeFieldDefinition":         `{"required": ["fieldName"], "properties": {"fieldName": {"type": "string", "minLength": 1}}}`,
+	},
+}
@@ -0,0 +1,137 @@
+package commercetools
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// requestValidationError is returned for requests which don't match the
+// request schemas, these are not sent and not retried
+type requestValidationError struct {
+	Endpoint   string
+	Violations []string
+}
+
+func (e *requestValidationError) Error() string {
+	return fmt.Sprintf(
+		"the request to the %s endpoint doesn't match the request schema of the provider, "+
+			"this is likely a bug in the provider:\n  - %s",
+		e.Endpoint, strings.Join(e.Violations, "\n  - "))
+}
+
+// requestValidationTransport validates the drafts and update actions sent to
+// the API against the partial schemas in request_schemas.go before sending
+// them
+type requestValidationTransport struct {
+	base http.RoundTripper
+}
+
+func newRequestValidationTransport(base http.RoundTripper) *requestValidationTransport {
+	return &requestValidationTransport{base: base}
+}
+
+func (t *requestValidationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != "POST" || req.Body == nil {
+		return t.base.RoundTrip(req)
+	}
+
+	body, err := ioutil.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	// Only creating (a POST on the endpoint) and updating (a POST on the id or
+	// key) of resources is validated, not for example searches
+	parts := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	if len(parts) == 2 || len(parts) == 3 {
+		endpoint := parts[1]
+		if violations := validateAPIRequest(endpoint, len(parts) == 3, body); len(violations) > 0 {
+			return nil, &requestValidationError{Endpoint: endpoint, Violations: violations}
+		}
+	}
+	return t.base.RoundTrip(req)
+}
+
+var (
+	requestSchemasOnce   sync.Once
+	requestSchemasParsed map[string]map[string]interface{}
+	requestSchemasErr    error
+)
+
+// loadRequestSchemas parses the request schemas once, they are keyed
+// by the endpoint and the name of the update action (or draft)
+func loadRequestSchemas() (map[string]map[string]interface{}, error) {
+	requestSchemasOnce.Do(func() {
+		schemas := map[string]map[string]interface{}{}
+		parse := func(key string, input string) {
+			if requestSchemasErr != nil {
+				return
+			}
+			schemas[key], requestSchemasErr = parseJSONSchema(input)
+			if requestSchemasErr != nil {
+				requestSchemasErr = fmt.Errorf("invalid request schema of %s: %s", key, requestSchemasErr)
+			}
+		}
+
+		parse("update", requestSchemaUpdate)
+		for endpoint, draft := range requestSchemaDrafts {
+			parse(endpoint+"/draft", draft)
+		}
+		for endpoint, actions := range requestSchemaActions {
+			for action, input := range actions {
+				parse(endpoint+"/"+action, input)
+			}
+		}
+		requestSchemasParsed = schemas
+	})
+	return requestSchemasParsed, requestSchemasErr
+}
+
+// validateAPIRequest returns the violations of the request schemas by the
+// body of a request which creates (a draft) or updates a resource. Endpoints
+// without a request schema are not validated.
+func validateAPIRequest(endpoint string, update bool, body []byte) []string {
+	_, hasDraft := requestSchemaDrafts[endpoint]
+	_, hasActions := requestSchemaActions[endpoint]
+	if !hasDraft && !hasActions {
+		return nil
+	}
+
+	schemas, err := loadRequestSchemas()
+	if err != nil {
+		return []string{err.Error()}
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return []string{fmt.Sprintf("invalid JSON: %s", err)}
+	}
+
+	if !update {
+		if schema, ok := schemas[endpoint+"/draft"]; ok {
+			return validateJSONSchema(schema, value, "#")
+		}
+		return nil
+	}
+
+	violations := validateJSONSchema(schemas["update"], value, "#")
+	if len(violations) > 0 {
+		return violations
+	}
+	actions, _ := value.(map[string]interface{})["actions"].([]interface{})
+	for i, item := range actions {
+		action := item.(map[string]interface{})
+		if schema, ok := schemas[endpoint+"/"+action["action"].(string)]; ok {
+			path := fmt.Sprintf("#/actions/%d(%s)", i, action["action"])
+			violations = append(violations, validateJSONSchema(schema, action, path)...)
+		}
+	}
+	return violations
+}
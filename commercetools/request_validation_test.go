@@ -0,0 +1,119 @@
+package commercetools
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labd/commercetools-go-sdk/commercetools"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadAPISpec(t *testing.T) {
+	schemas, err := loadRequestSchemas()
+	assert.NoError(t, err)
+	assert.Contains(t, schemas, "update")
+	assert.Contains(t, schemas, "types/draft")
+	assert.Contains(t, schemas, "types/addFieldDefinition")
+}
+
+func TestValidateAPIRequestDraft(t *testing.T) {
+	label := commercetools.LocalizedString{"en": "Note"}
+	draft := commercetools.TypeDraft{
+		Key:             "order",
+		Name:            &commercetools.LocalizedString{"en": "Order"},
+		ResourceTypeIds: []commercetools.ResourceTypeID{"order"},
+		FieldDefinitions: []commercetools.FieldDefinition{
+			{
+				Name:      "note",
+				Label:     &label,
+				Type:      commercetools.CustomFieldStringType{},
+				InputHint: commercetools.TypeTextInputHintMultiLine,
+			},
+		},
+	}
+	data, err := json.Marshal(draft)
+	assert.NoError(t, err)
+	assert.Empty(t, validateAPIRequest("types", false, data))
+
+	// A field without a label and with an unknown input hint
+	draft.FieldDefinitions[0].Label = nil
+	draft.FieldDefinitions[0].InputHint = "Multiline"
+	data, err = json.Marshal(draft)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"#/fieldDefinitions/0/inputHint: must be one of the values of the enum",
+		"#/fieldDefinitions/0/label: expected object, got null",
+	}, validateAPIRequest("types", false, data))
+
+	data, err = json.Marshal(commercetools.ChannelDraft{
+		Key:   "warehouse",
+		Roles: []commercetools.ChannelRoleEnum{"InventorySupply", "Inventory"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t,
+		[]string{"#/roles/1: must be one of the values of the enum"},
+		validateAPIRequest("channels", false, data))
+
+	// Endpoints without a request schema are not validated
+	assert.Empty(t, validateAPIRequest("graphql", false, []byte("query")))
+}
+
+func TestValidateAPIRequestUpdate(t *testing.T) {
+	assert.Empty(t, validateAPIRequest("types", true, []byte(`{
+		"version": 2,
+		"actions": [
+			{"action": "changeKey", "key": "order-fields"},
+			{"action": "setDescription"}
+		]
+	}`)))
+
+	assert.Equal(t,
+		[]string{"#/actions: must have at least 1 items"},
+		validateAPIRequest("types", true, []byte(`{"version": 2, "actions": []}`)))
+
+	assert.Equal(t,
+		[]string{"#/actions/1(changeInputHint)/inputHint: must be one of the values of the enum"},
+		validateAPIRequest("types", true, []byte(`{
+			"version": 2,
+			"actions": [
+				{"action": "changeKey", "key": "order-fields"},
+				{"action": "changeInputHint", "fieldName": "note", "inputHint": "multiLine"}
+			]
+		}`)))
+}
+
+func TestRequestValidationTransport(t *testing.T) {
+	requests := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path)
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Write([]byte(`{"id": "1234", "version": 1}`))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: newRequestValidationTransport(http.DefaultTransport)}
+	post := func(path string, body string) error {
+		resp, err := client.Post(server.URL+path, "application/json", strings.NewReader(body))
+		if err == nil {
+			resp.Body.Close()
+		}
+		return err
+	}
+
+	assert.NoError(t, post("/my-project/channels", `{"key": "warehouse", "roles": ["InventorySupply"]}`))
+	assert.NoError(t, post("/my-project/product-projections/search", `filter=categories.id:"1234"`))
+
+	err := post("/my-project/channels/1234", `{"version": 1, "actions": [{"action": "addRoles", "roles": ["Inventory"]}]}`)
+	assert.Error(t, err)
+	var validationErr *requestValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, "channels", validationErr.Endpoint)
+	assert.False(t, handleCommercetoolsError(err).Retryable)
+
+	// The invalid request isn't sent
+	assert.Equal(t, []string{"/my-project/channels", "/my-project/product-projections/search"}, requests)
+}
@@ -2,15 +2,32 @@ package commercetools
 
 import (
 	"context"
+	"fmt"
 	"sort"
 	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/labd/commercetools-go-sdk/commercetools"
 )
 
+// apiClientDraft is the draft of an API client including the token validity
+// settings, which are not supported by the commercetools-go-sdk yet
+type apiClientDraft struct {
+	Name                        string `json:"name"`
+	Scope                       string `json:"scope"`
+	AccessTokenValiditySeconds  int    `json:"accessTokenValiditySeconds,omitempty"`
+	RefreshTokenValiditySeconds int    `json:"refreshTokenValiditySeconds,omitempty"`
+}
+
+type apiClientWithValidity struct {
+	commercetools.APIClient
+	AccessTokenValiditySeconds  int `json:"accessTokenValiditySeconds,omitempty"`
+	RefreshTokenValiditySeconds int `json:"refreshTokenValiditySeconds,omitempty"`
+}
+
 func resourceAPIClient() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceAPIClientCreate,
@@ -31,9 +48,31 @@ func resourceAPIClient() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
+			"access_token_validity_seconds": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The expiration time of the access tokens obtained by the client, the default of commercetools is used when not set",
+			},
+			"refresh_token_validity_seconds": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "The expiration time of the refresh tokens obtained by the client, the default of commercetools is used when not set",
+			},
+			"rotation_trigger": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Arbitrary values which create a new API client, and therefore a new secret, when changed",
+			},
 			"secret": {
-				Type:     schema.TypeString,
-				Computed: true,
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
 			},
 		},
 	}
@@ -48,19 +87,21 @@ func resourceAPIClientCreate(d *schema.ResourceData, m interface{}) error {
 		scopeParts = append(scopeParts, scopes[i].(string))
 	}
 
-	draft := &commercetools.APIClientDraft{
-		Name:  name,
-		Scope: strings.Join(scopeParts, " "),
+	draft := &apiClientDraft{
+		Name:                        name,
+		Scope:                       strings.Join(scopeParts, " "),
+		AccessTokenValiditySeconds:  d.Get("access_token_validity_seconds").(int),
+		RefreshTokenValiditySeconds: d.Get("refresh_token_validity_seconds").(int),
 	}
 
-	client := getClient(m)
+	client := getRestClient(m)
 
-	var apiClient *commercetools.APIClient
+	apiClient := &apiClientWithValidity{}
 
+	// The API client is created with the restClient since the draft of the
+	// commercetools-go-sdk doesn't support the token validity settings
 	err := resource.Retry(20*time.Second, func() *resource.RetryError {
-		var err error
-
-		apiClient, err = client.APIClientCreate(context.Background(), draft)
+		err := client.create(context.Background(), "api-clients", nil, draft, apiClient)
 		if err != nil {
 			return handleCommercetoolsError(err)
 		}
@@ -78,8 +119,9 @@ func resourceAPIClientCreate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceAPIClientRead(d *schema.ResourceData, m interface{}) error {
-	client := getClient(m)
-	apiClient, err := client.APIClientGetWithID(context.Background(), d.Id())
+	client := getRestClient(m)
+	apiClient := &apiClientWithValidity{}
+	err := client.get(context.Background(), fmt.Sprintf("api-clients/%s", d.Id()), nil, apiClient)
 
 	if err != nil {
 		if ctErr, ok := err.(commercetools.ErrorResponse); ok {
@@ -96,6 +138,8 @@ func resourceAPIClientRead(d *schema.ResourceData, m interface{}) error {
 	scopes := strings.Split(apiClient.Scope, " ")
 	sort.Strings(scopes)
 	d.Set("scope", scopes)
+	d.Set("access_token_validity_seconds", apiClient.AccessTokenValiditySeconds)
+	d.Set("refresh_token_validity_seconds", apiClient.RefreshTokenValiditySeconds)
 	return nil
 }
 
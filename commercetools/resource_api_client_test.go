@@ -0,0 +1,34 @@
+package commercetools
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceAPIClientRead(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/my-project/api-clients/1234", r.URL.Path)
+		w.Write([]byte(`{
+			"id": "1234",
+			"name": "machine client",
+			"scope": "view_products:my-project manage_orders:my-project",
+			"accessTokenValiditySeconds": 3600,
+			"refreshTokenValiditySeconds": 86400
+		}`))
+	}))
+	defer server.Close()
+
+	meta := &providerMeta{rest: newRestClient(server.Client(), server.URL, "my-project")}
+	d := schema.TestResourceDataRaw(t, resourceAPIClient().Schema, map[string]interface{}{})
+	d.SetId("1234")
+
+	assert.NoError(t, resourceAPIClientRead(d, meta))
+	assert.Equal(t, "machine client", d.Get("name"))
+	assert.Equal(t, 2, d.Get("scope").(*schema.Set).Len())
+	assert.Equal(t, 3600, d.Get("access_token_validity_seconds"))
+	assert.Equal(t, 86400, d.Get("refresh_token_validity_seconds"))
+}
@@ -2,6 +2,7 @@ package commercetools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"strings"
@@ -11,8 +12,11 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 )
 
+const maxExtensionTimeoutInMs = 10000
+
 func resourceAPIExtension() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceAPIExtensionCreate,
@@ -28,6 +32,10 @@ func resourceAPIExtension() *schema.Resource {
 			"destination": {
 				Type:     schema.TypeMap,
 				Required: true,
+				// The destination contains secrets like the access secret of
+				// the AWS Lambda destination. Since the secrets are stored in
+				// a map the complete destination is marked as sensitive.
+				Sensitive: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"type": {
@@ -35,7 +43,7 @@ func resourceAPIExtension() *schema.Resource {
 							Required:     true,
 							ValidateFunc: validateDestinationType,
 						},
-						// HTTP specific fields
+						// HTTP / GoogleCloudFunction specific fields
 						"url": {
 							Type:     schema.TypeString,
 							Optional: true,
@@ -65,6 +73,12 @@ func resourceAPIExtension() *schema.Resource {
 					},
 				},
 			},
+			"rotate_secret_trigger": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Changing this value sends the destination to commercetools again, " +
+					"for example after the secret of the destination is rotated",
+			},
 			"trigger": {
 				Type:     schema.TypeList,
 				Required: true,
@@ -79,12 +93,40 @@ func resourceAPIExtension() *schema.Resource {
 							Required: true,
 							Elem:     &schema.Schema{Type: schema.TypeString},
 						},
+						"condition": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Predicate which has to match for the extension to be called",
+						},
+					},
+				},
+			},
+			"rollout": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Only call the extension for a percentage of the carts",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"field": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Custom field of the cart with a number between 0 and 99",
+						},
+						"percentage": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(0, 100),
+						},
 					},
 				},
 			},
 			"timeout_in_ms": {
 				Type:     schema.TypeInt,
 				Optional: true,
+				// The default maximum is 2000 ms, commercetools support can
+				// raise the maximum to 10000 ms for a project.
+				ValidateFunc: validation.IntBetween(1, maxExtensionTimeoutInMs),
 			},
 			"version": {
 				Type:     schema.TypeInt,
@@ -101,7 +143,8 @@ func validateDestinationType(val interface{}, key string) (warns []string, errs
 	case
 		"http",
 		"awslambda",
-		"azurefunctions":
+		"azurefunctions",
+		"googlecloudfunction":
 		return
 	default:
 		errs = append(errs, fmt.Errorf("%q not a valid value for %q", val, key))
@@ -110,8 +153,8 @@ func validateDestinationType(val interface{}, key string) (warns []string, errs
 }
 
 func resourceAPIExtensionCreate(d *schema.ResourceData, m interface{}) error {
-	client := getClient(m)
-	var extension *commercetools.Extension
+	client := getRestClient(m)
+	extension := &commercetools.Extension{}
 
 	triggers := resourceAPIExtensionGetTriggers(d)
 	destination, err := resourceAPIExtensionGetDestination(d)
@@ -119,17 +162,17 @@ func resourceAPIExtensionCreate(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 
-	draft := &commercetools.ExtensionDraft{
+	draft := &extensionDraft{
 		Key:         d.Get("key").(string),
 		Destination: destination,
 		Triggers:    triggers,
 		TimeoutInMs: d.Get("timeout_in_ms").(int),
 	}
 
+	// The extension is created with the restClient since the draft of the
+	// commercetools-go-sdk doesn't support trigger conditions
 	err = resource.Retry(20*time.Second, func() *resource.RetryError {
-		var err error
-
-		extension, err = client.ExtensionCreate(context.Background(), draft)
+		err := client.create(context.Background(), "extensions", nil, draft, extension)
 		if err != nil {
 			return handleCommercetoolsError(err)
 		}
@@ -140,7 +183,7 @@ func resourceAPIExtensionCreate(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 
-	if extension == nil {
+	if extension.ID == "" {
 		return fmt.Errorf("Error creating extension")
 	}
 
@@ -171,11 +214,12 @@ func resourceAPIExtensionRead(d *schema.ResourceData, m interface{}) error {
 		d.SetId("")
 	} else {
 		log.Print("[DEBUG] Found following extensions:")
-		log.Print(stringFormatObject(extension))
+		log.Print(stringFormatObject(redactAPIExtension(*extension)))
 
 		d.Set("version", extension.Version)
 		d.Set("key", extension.Key)
-		d.Set("destination", extension.Destination)
+		d.Set("destination", flattenAPIExtensionDestination(
+			extension.Destination, d.Get("destination").(map[string]interface{})))
 		d.Set("trigger", extension.Triggers)
 		d.Set("timeout_in_ms", extension.TimeoutInMs)
 	}
@@ -198,14 +242,17 @@ func resourceAPIExtensionUpdate(d *schema.ResourceData, m interface{}) error {
 			&commercetools.ExtensionSetKeyAction{Key: newKey})
 	}
 
-	if d.HasChange("trigger") {
+	if d.HasChange("trigger") || d.HasChange("rollout") {
 		triggers := resourceAPIExtensionGetTriggers(d)
 		input.Actions = append(
 			input.Actions,
-			&commercetools.ExtensionChangeTriggersAction{Triggers: triggers})
+			&extensionChangeTriggersAction{Triggers: triggers})
 	}
 
-	if d.HasChange("destination") {
+	// commercetools masks the secrets of the destination, so a rotated secret
+	// can't be detected by reading the extension. Changing the rotation
+	// trigger sends the destination with the new secret.
+	if d.HasChange("destination") || d.HasChange("rotate_secret_trigger") {
 		destination, err := resourceAPIExtensionGetDestination(d)
 		if err != nil {
 			return err
@@ -222,6 +269,10 @@ func resourceAPIExtensionUpdate(d *schema.ResourceData, m interface{}) error {
 			&commercetools.ExtensionSetTimeoutInMsAction{TimeoutInMs: newTimeout})
 	}
 
+	log.Printf(
+		"[DEBUG] Will perform update operation with the following actions:\n%s",
+		stringFormatActions(redactAPIExtensionActions(input.Actions)))
+
 	_, err := client.ExtensionUpdateWithID(context.Background(), input)
 	if err != nil {
 		return err
@@ -258,16 +309,122 @@ func resourceAPIExtensionGetDestination(d *schema.ResourceData) (commercetools.D
 			Authentication: auth,
 		}, nil
 	case "awslambda":
+		for _, field := range []string{"arn", "access_key", "access_secret"} {
+			if value, _ := input[field].(string); value == "" {
+				return nil, fmt.Errorf("The AWSLambda destination requires %s to be set", field)
+			}
+		}
 		return commercetools.ExtensionAWSLambdaDestination{
 			Arn:          input["arn"].(string),
 			AccessKey:    input["access_key"].(string),
 			AccessSecret: input["access_secret"].(string),
 		}, nil
+	case "googlecloudfunction":
+		url, _ := input["url"].(string)
+		if url == "" {
+			return nil, fmt.Errorf("The GoogleCloudFunction destination requires url to be set")
+		}
+		return extensionGoogleCloudFunctionDestination{URL: url}, nil
 	default:
 		return nil, fmt.Errorf("Extension type %s not implemented", input["type"])
 	}
 }
 
+// extensionGoogleCloudFunctionDestination is the Google Cloud Function
+// destination, which is not supported by the commercetools-go-sdk yet. The
+// function is authenticated by granting the commercetools service account
+// the Cloud Functions Invoker role, so only the url is needed.
+type extensionGoogleCloudFunctionDestination struct {
+	URL string `json:"url"`
+}
+
+// MarshalJSON override to set the discriminator value
+func (obj extensionGoogleCloudFunctionDestination) MarshalJSON() ([]byte, error) {
+	type Alias extensionGoogleCloudFunctionDestination
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		*Alias
+	}{Type: "GoogleCloudFunction", Alias: (*Alias)(&obj)})
+}
+
+// flattenAPIExtensionDestination returns the destination as stored in the
+// state. commercetools masks the secrets of the destination, so these are
+// kept from the current state. The SDK returns no destination for
+// destination types it doesn't support (for example GoogleCloudFunction), the
+// current state is returned as is in that case.
+func flattenAPIExtensionDestination(destination commercetools.ExtensionDestination, current map[string]interface{}) map[string]interface{} {
+	keep := func(result map[string]interface{}, fields ...string) map[string]interface{} {
+		for _, field := range fields {
+			if value, ok := current[field]; ok {
+				result[field] = value
+			}
+		}
+		return result
+	}
+	currentType, _ := current["type"].(string)
+
+	switch d := destination.(type) {
+	case commercetools.ExtensionHTTPDestination:
+		destinationType := "HTTP"
+		if strings.EqualFold(currentType, destinationType) {
+			destinationType = currentType
+		}
+		return keep(map[string]interface{}{
+			"type": destinationType,
+			"url":  d.URL,
+		}, "authorization_header", "azure_authentication")
+	case commercetools.ExtensionAWSLambdaDestination:
+		destinationType := "AWSLambda"
+		if strings.EqualFold(currentType, destinationType) {
+			destinationType = currentType
+		}
+		return keep(map[string]interface{}{
+			"type":       destinationType,
+			"arn":        d.Arn,
+			"access_key": d.AccessKey,
+		}, "access_secret")
+	}
+	return current
+}
+
+// redactAPIExtension returns a copy of the extension with the secrets of the
+// destination removed, for use in debug logs
+func redactAPIExtension(extension commercetools.Extension) commercetools.Extension {
+	extension.Destination = redactAPIExtensionDestination(extension.Destination)
+	return extension
+}
+
+// redactAPIExtensionActions returns a copy of the update actions with the
+// secrets of the changed destination removed, for logging
+func redactAPIExtensionActions(actions []commercetools.ExtensionUpdateAction) []commercetools.ExtensionUpdateAction {
+	result := make([]commercetools.ExtensionUpdateAction, len(actions))
+	for i, action := range actions {
+		if a, ok := action.(*commercetools.ExtensionChangeDestinationAction); ok {
+			action = &commercetools.ExtensionChangeDestinationAction{
+				Destination: redactAPIExtensionDestination(a.Destination),
+			}
+		}
+		result[i] = action
+	}
+	return result
+}
+
+func redactAPIExtensionDestination(destination commercetools.ExtensionDestination) commercetools.ExtensionDestination {
+	const redacted = "<redacted>"
+
+	switch d := destination.(type) {
+	case commercetools.ExtensionAWSLambdaDestination:
+		d.AccessSecret = redacted
+		return d
+	case commercetools.ExtensionHTTPDestination:
+		if d.Authentication != nil {
+			d.Authentication = redacted
+		}
+		return d
+	}
+	return destination
+}
+
 func resourceAPIExtensionGetAuthentication(destInput map[string]interface{}) (commercetools.ExtensionHTTPDestinationAuthentication, error) {
 	authKeys := [2]string{"authorization_header", "azure_authentication"}
 	count := 0
@@ -295,24 +452,81 @@ func resourceAPIExtensionGetAuthentication(destInput map[string]interface{}) (co
 	return nil, nil
 }
 
-func resourceAPIExtensionGetTriggers(d *schema.ResourceData) []commercetools.ExtensionTrigger {
+// extensionTrigger is the trigger of an extension including the condition,
+// which is not supported by the commercetools-go-sdk yet
+type extensionTrigger struct {
+	ResourceTypeID string   `json:"resourceTypeId"`
+	Actions        []string `json:"actions"`
+	Condition      string   `json:"condition,omitempty"`
+}
+
+type extensionDraft struct {
+	Key         string                             `json:"key,omitempty"`
+	Destination commercetools.ExtensionDestination `json:"destination"`
+	Triggers    []extensionTrigger                 `json:"triggers"`
+	TimeoutInMs int                                `json:"timeoutInMs,omitempty"`
+}
+
+type extensionChangeTriggersAction struct {
+	Triggers []extensionTrigger `json:"triggers"`
+}
+
+// MarshalJSON override to set the discriminator value
+func (obj extensionChangeTriggersAction) MarshalJSON() ([]byte, error) {
+	type Alias extensionChangeTriggersAction
+	return json.Marshal(struct {
+		Action string `json:"action"`
+		*Alias
+	}{Action: "changeTriggers", Alias: (*Alias)(&obj)})
+}
+
+func resourceAPIExtensionGetTriggers(d *schema.ResourceData) []extensionTrigger {
 	input := d.Get("trigger").([]interface{})
-	var result []commercetools.ExtensionTrigger
+	rollout := resourceAPIExtensionGetRolloutCondition(d.Get("rollout").([]interface{}))
+	var result []extensionTrigger
 
 	for _, raw := range input {
 		i := raw.(map[string]interface{})
 		typeID := i["resource_type_id"].(string)
+		condition, _ := i["condition"].(string)
 
-		actions := []commercetools.ExtensionAction{}
-		for _, item := range expandStringArray(i["actions"].([]interface{})) {
-			actions = append(actions, commercetools.ExtensionAction(item))
+		// The rollout only applies to carts, since the bucket is stored in a
+		// custom field of the cart
+		if typeID == "cart" && rollout != "" {
+			condition = combineConditions(condition, rollout)
 		}
 
-		result = append(result, commercetools.ExtensionTrigger{
-			ResourceTypeID: commercetools.ExtensionResourceTypeID(typeID),
-			Actions:        actions,
+		result = append(result, extensionTrigger{
+			ResourceTypeID: typeID,
+			Actions:        expandStringArray(i["actions"].([]interface{})),
+			Condition:      condition,
 		})
 	}
 
 	return result
 }
+
+// resourceAPIExtensionGetRolloutCondition returns the condition which matches
+// the given percentage of carts. Every cart needs a custom field with a
+// number between 0 and 99 (for example derived from a hash of the cart id),
+// the extension is called for carts with a number below the percentage.
+func resourceAPIExtensionGetRolloutCondition(input []interface{}) string {
+	if len(input) == 0 || input[0] == nil {
+		return ""
+	}
+	rollout := input[0].(map[string]interface{})
+	percentage := rollout["percentage"].(int)
+	if percentage >= 100 {
+		return ""
+	}
+	return fmt.Sprintf("custom(fields(%s < %d))", rollout["field"].(string), percentage)
+}
+
+// combineConditions returns a condition which matches when both conditions
+// match
+func combineConditions(a string, b string) string {
+	if a == "" {
+		return b
+	}
+	return fmt.Sprintf("(%s) and (%s)", a, b)
+}
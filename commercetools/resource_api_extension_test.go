@@ -2,6 +2,7 @@ package commercetools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"strconv"
 	"testing"
@@ -40,6 +41,145 @@ func TestAPIExtensionGetDestination(t *testing.T) {
 	assert.Equal(t, lambdaDestination.AccessSecret, "****abc/")
 }
 
+func TestAPIExtensionGetDestinationLambdaMissingSecret(t *testing.T) {
+	resourceDataMap := map[string]interface{}{
+		"destination": map[string]interface{}{
+			"type":       "AWSLambda",
+			"arn":        "arn:aws:lambda:eu-west-1:111111111:function:api_extensions",
+			"access_key": "ABCSDF123123123",
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceAPIExtension().Schema, resourceDataMap)
+	_, err := resourceAPIExtensionGetDestination(d)
+	assert.EqualError(t, err, "The AWSLambda destination requires access_secret to be set")
+}
+
+func TestAPIExtensionGetDestinationGoogleCloudFunction(t *testing.T) {
+	resourceDataMap := map[string]interface{}{
+		"destination": map[string]interface{}{
+			"type": "GoogleCloudFunction",
+			"url":  "https://europe-west1-my-project.cloudfunctions.net/extension",
+		},
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceAPIExtension().Schema, resourceDataMap)
+	destination, err := resourceAPIExtensionGetDestination(d)
+	assert.NoError(t, err)
+
+	data, err := json.Marshal(destination)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "GoogleCloudFunction",
+		"url": "https://europe-west1-my-project.cloudfunctions.net/extension"
+	}`, string(data))
+}
+
+func TestFlattenAPIExtensionDestination(t *testing.T) {
+	current := map[string]interface{}{
+		"type":          "awslambda",
+		"arn":           "arn:aws:lambda:eu-west-1:111111111:function:old",
+		"access_key":    "ABCSDF123123123",
+		"access_secret": "secret",
+	}
+	result := flattenAPIExtensionDestination(commercetools.ExtensionAWSLambdaDestination{
+		Arn:          "arn:aws:lambda:eu-west-1:111111111:function:api_extensions",
+		AccessKey:    "ABCSDF123123123",
+		AccessSecret: "****cret",
+	}, current)
+
+	assert.Equal(t, map[string]interface{}{
+		"type":          "awslambda",
+		"arn":           "arn:aws:lambda:eu-west-1:111111111:function:api_extensions",
+		"access_key":    "ABCSDF123123123",
+		"access_secret": "secret",
+	}, result)
+}
+
+func TestRedactAPIExtension(t *testing.T) {
+	extension := commercetools.Extension{
+		Destination: commercetools.ExtensionAWSLambdaDestination{
+			Arn:          "arn:aws:lambda:eu-west-1:111111111:function:api_extensions",
+			AccessSecret: "secret",
+		},
+	}
+	result := redactAPIExtension(extension)
+	assert.Equal(t, "<redacted>", result.Destination.(commercetools.ExtensionAWSLambdaDestination).AccessSecret)
+}
+
+func TestRedactAPIExtensionActions(t *testing.T) {
+	destination := commercetools.ExtensionHTTPDestination{
+		URL: "https://example.com",
+		Authentication: &commercetools.ExtensionAuthorizationHeaderAuthentication{
+			HeaderValue: "Bearer secret",
+		},
+	}
+	actions := []commercetools.ExtensionUpdateAction{
+		&commercetools.ExtensionSetKeyAction{Key: "my-extension"},
+		&commercetools.ExtensionChangeDestinationAction{Destination: destination},
+	}
+
+	result := redactAPIExtensionActions(actions)
+	assert.Equal(t, actions[0], result[0])
+	redacted := result[1].(*commercetools.ExtensionChangeDestinationAction).Destination
+	assert.Equal(t, "<redacted>", redacted.(commercetools.ExtensionHTTPDestination).Authentication)
+
+	// The actions sent to commercetools keep the secret
+	original := actions[1].(*commercetools.ExtensionChangeDestinationAction).Destination
+	assert.Equal(t, destination, original)
+}
+
+func TestAPIExtensionGetTriggersRollout(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceAPIExtension().Schema, map[string]interface{}{
+		"trigger": []interface{}{
+			map[string]interface{}{
+				"resource_type_id": "cart",
+				"actions":          []interface{}{"Create", "Update"},
+				"condition":        `country = "DE"`,
+			},
+			map[string]interface{}{
+				"resource_type_id": "order",
+				"actions":          []interface{}{"Create"},
+			},
+		},
+		"rollout": []interface{}{
+			map[string]interface{}{
+				"field":      "rollout_bucket",
+				"percentage": 10,
+			},
+		},
+	})
+
+	triggers := resourceAPIExtensionGetTriggers(d)
+	assert.Equal(t, []extensionTrigger{
+		{
+			ResourceTypeID: "cart",
+			Actions:        []string{"Create", "Update"},
+			Condition:      `(country = "DE") and (custom(fields(rollout_bucket < 10)))`,
+		},
+		{
+			ResourceTypeID: "order",
+			Actions:        []string{"Create"},
+		},
+	}, triggers)
+
+	data, err := json.Marshal(&extensionChangeTriggersAction{Triggers: triggers[1:]})
+	assert.NoError(t, err)
+	assert.JSONEq(t,
+		`{"action": "changeTriggers", "triggers": [{"resourceTypeId": "order", "actions": ["Create"]}]}`,
+		string(data))
+}
+
+func TestAPIExtensionGetRolloutCondition(t *testing.T) {
+	assert.Equal(t, "", resourceAPIExtensionGetRolloutCondition([]interface{}{}))
+	assert.Equal(t, "", resourceAPIExtensionGetRolloutCondition([]interface{}{
+		map[string]interface{}{"field": "bucket", "percentage": 100},
+	}))
+	assert.Equal(t, "custom(fields(bucket < 0))", resourceAPIExtensionGetRolloutCondition([]interface{}{
+		map[string]interface{}{"field": "bucket", "percentage": 0},
+	}))
+}
+
 func TestAPIExtensionGetAuthentication(t *testing.T) {
 	var input map[string]interface{}
 	input = map[string]interface{}{
@@ -63,6 +203,19 @@ func TestAPIExtensionGetAuthentication(t *testing.T) {
 	assert.Nil(t, err)
 }
 
+func TestAPIExtensionValidateTimeout(t *testing.T) {
+	validate := resourceAPIExtension().Schema["timeout_in_ms"].ValidateFunc
+
+	_, errs := validate(2000, "timeout_in_ms")
+	assert.Empty(t, errs)
+
+	_, errs = validate(0, "timeout_in_ms")
+	assert.NotEmpty(t, errs)
+
+	_, errs = validate(10001, "timeout_in_ms")
+	assert.NotEmpty(t, errs)
+}
+
 func TestAccAPIExtension_basic(t *testing.T) {
 	name := fmt.Sprintf("extension_%s", acctest.RandString(5))
 	timeoutInMs := acctest.RandIntRange(200, 1800)
@@ -120,12 +120,15 @@ func resourceCartDiscount() *schema.Resource {
 				Default:  true,
 			},
 			"valid_from": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validateValidFrom,
+				DiffSuppressFunc: diffSuppressValidFrom,
 			},
 			"valid_until": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: diffSuppressEquivalentDate,
 			},
 			"requires_discount_code": {
 				Type:     schema.TypeBool,
@@ -224,7 +227,7 @@ func resourceCartDiscountCreate(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if val := d.Get("valid_from").(string); len(val) > 0 {
-		validFrom, err := expandDate(val)
+		validFrom, err := expandValidFrom(val)
 		if err != nil {
 			return err
 		}
@@ -291,13 +294,13 @@ func resourceCartDiscountRead(d *schema.ResourceData, m interface{}) error {
 		d.Set("key", cartDiscount.Key)
 		d.Set("name", cartDiscount.Name)
 		d.Set("description", cartDiscount.Description)
-		d.Set("value", cartDiscount.Value)
+		d.Set("value", flattenCartDiscountValue(cartDiscount.Value))
 		d.Set("predicate", cartDiscount.CartPredicate)
 		d.Set("target", cartDiscount.Target)
 		d.Set("sort_order", cartDiscount.SortOrder)
 		d.Set("is_active", cartDiscount.IsActive)
-		d.Set("valid_from", cartDiscount.ValidFrom)
-		d.Set("valid_until", cartDiscount.ValidUntil)
+		d.Set("valid_from", flattenDate(cartDiscount.ValidFrom))
+		d.Set("valid_until", flattenDate(cartDiscount.ValidUntil))
 		d.Set("requires_discount_code", cartDiscount.RequiresDiscountCode)
 		d.Set("stacking_mode", cartDiscount.StackingMode)
 	}
@@ -312,49 +315,74 @@ func resourceCartDiscountUpdate(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 
+	actions, err := resourceCartDiscountUpdateActions(d)
+	if err != nil {
+		return err
+	}
+
 	input := &commercetools.CartDiscountUpdateWithIDInput{
 		ID:      d.Id(),
 		Version: cartDiscount.Version,
-		Actions: []commercetools.CartDiscountUpdateAction{},
+		Actions: actions,
 	}
 
+	log.Printf(
+		"[DEBUG] Will perform update operation with the following actions:\n%s",
+		stringFormatActions(input.Actions))
+
+	_, err = client.CartDiscountUpdateWithID(context.Background(), input)
+	if err != nil {
+		if ctErr, ok := err.(commercetools.ErrorResponse); ok {
+			log.Printf("[DEBUG] %v: %v", ctErr, stringFormatErrorExtras(ctErr))
+		}
+		return err
+	}
+
+	return resourceCartDiscountRead(d, m)
+}
+
+// resourceCartDiscountUpdateActions returns the update actions for the changes,
+// both when applying the changes and for the planned_actions during the plan
+func resourceCartDiscountUpdateActions(d resourceChange) ([]commercetools.CartDiscountUpdateAction, error) {
+	actions := []commercetools.CartDiscountUpdateAction{}
+
 	if d.HasChange("key") {
 		newKey := d.Get("key").(string)
-		input.Actions = append(
-			input.Actions,
+		actions = append(
+			actions,
 			&commercetools.CartDiscountSetKeyAction{Key: newKey})
 	}
 
 	if d.HasChange("name") {
 		newName := commercetools.LocalizedString(
 			expandStringMap(d.Get("name").(map[string]interface{})))
-		input.Actions = append(
-			input.Actions,
+		actions = append(
+			actions,
 			&commercetools.CartDiscountChangeNameAction{Name: &newName})
 	}
 
 	if d.HasChange("description") {
 		newDescription := commercetools.LocalizedString(
 			expandStringMap(d.Get("description").(map[string]interface{})))
-		input.Actions = append(
-			input.Actions,
+		actions = append(
+			actions,
 			&commercetools.CartDiscountSetDescriptionAction{Description: &newDescription})
 	}
 
 	if d.HasChange("value") {
 		value, err := resourceCartDiscountGetValue(d)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		input.Actions = append(
-			input.Actions,
+		actions = append(
+			actions,
 			&commercetools.CartDiscountChangeValueAction{Value: value})
 	}
 
 	if d.HasChange("predicate") {
 		newPredicate := d.Get("predicate").(string)
-		input.Actions = append(
-			input.Actions,
+		actions = append(
+			actions,
 			&commercetools.CartDiscountChangeCartPredicateAction{CartPredicate: newPredicate})
 	}
 
@@ -362,43 +390,43 @@ func resourceCartDiscountUpdate(d *schema.ResourceData, m interface{}) error {
 		if val := d.Get("target").(map[string]interface{}); len(val) > 0 {
 			target, err := resourceCartDiscountGetTarget(d)
 			if err != nil {
-				return err
+				return nil, err
 			}
-			input.Actions = append(
-				input.Actions,
+			actions = append(
+				actions,
 				&commercetools.CartDiscountChangeTargetAction{Target: target})
 		} else {
-			return errors.New("Cannot change target to empty")
+			return nil, errors.New("Cannot change target to empty")
 		}
 
 	}
 
 	if d.HasChange("sort_order") {
 		newSortOrder := d.Get("sort_order").(string)
-		input.Actions = append(
-			input.Actions,
+		actions = append(
+			actions,
 			&commercetools.CartDiscountChangeSortOrderAction{SortOrder: newSortOrder})
 	}
 
 	if d.HasChange("is_active") {
 		newIsActive := d.Get("is_active").(bool)
-		input.Actions = append(
-			input.Actions,
+		actions = append(
+			actions,
 			&commercetools.CartDiscountChangeIsActiveAction{IsActive: newIsActive})
 	}
 
 	if d.HasChange("valid_from") {
 		if val := d.Get("valid_from").(string); len(val) > 0 {
-			newValidFrom, err := expandDate(d.Get("valid_from").(string))
+			newValidFrom, err := expandValidFrom(val)
 			if err != nil {
-				return err
+				return nil, err
 			}
-			input.Actions = append(
-				input.Actions,
+			actions = append(
+				actions,
 				&commercetools.CartDiscountSetValidFromAction{ValidFrom: &newValidFrom})
 		} else {
-			input.Actions = append(
-				input.Actions,
+			actions = append(
+				actions,
 				&commercetools.CartDiscountSetValidFromAction{})
 		}
 	}
@@ -407,48 +435,36 @@ func resourceCartDiscountUpdate(d *schema.ResourceData, m interface{}) error {
 		if val := d.Get("valid_until").(string); len(val) > 0 {
 			newValidUntil, err := expandDate(d.Get("valid_until").(string))
 			if err != nil {
-				return err
+				return nil, err
 			}
-			input.Actions = append(
-				input.Actions,
+			actions = append(
+				actions,
 				&commercetools.CartDiscountSetValidUntilAction{ValidUntil: &newValidUntil})
 		} else {
-			input.Actions = append(
-				input.Actions,
+			actions = append(
+				actions,
 				&commercetools.CartDiscountSetValidUntilAction{})
 		}
 	}
 
 	if d.HasChange("requires_discount_code") {
 		newRequiresDiscountCode := d.Get("requires_discount_code").(bool)
-		input.Actions = append(
-			input.Actions,
+		actions = append(
+			actions,
 			&commercetools.CartDiscountChangeRequiresDiscountCodeAction{RequiresDiscountCode: newRequiresDiscountCode})
 	}
 
 	if d.HasChange("stacking_mode") {
 		newStackingMode, err := resourceCartDiscountGetStackingMode(d)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		input.Actions = append(
-			input.Actions,
+		actions = append(
+			actions,
 			&commercetools.CartDiscountChangeStackingModeAction{StackingMode: newStackingMode})
 	}
 
-	log.Printf(
-		"[DEBUG] Will perform update operation with the following actions:\n%s",
-		stringFormatActions(input.Actions))
-
-	_, err = client.CartDiscountUpdateWithID(context.Background(), input)
-	if err != nil {
-		if ctErr, ok := err.(commercetools.ErrorResponse); ok {
-			log.Printf("[DEBUG] %v: %v", ctErr, stringFormatErrorExtras(ctErr))
-		}
-		return err
-	}
-
-	return resourceCartDiscountRead(d, m)
+	return actions, nil
 }
 
 func resourceCartDiscountDelete(d *schema.ResourceData, m interface{}) error {
@@ -461,7 +477,7 @@ func resourceCartDiscountDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
-func resourceCartDiscountGetValue(d *schema.ResourceData) (commercetools.CartDiscountValueDraft, error) {
+func resourceCartDiscountGetValue(d resourceChange) (commercetools.CartDiscountValueDraft, error) {
 	value := d.Get("value").([]interface{})[0].(map[string]interface{})
 	switch value["type"].(string) {
 	case "relative":
@@ -496,6 +512,66 @@ func resourceCartDiscountGetValue(d *schema.ResourceData) (commercetools.CartDis
 	}
 }
 
+func flattenCartDiscountValue(value commercetools.CartDiscountValue) []map[string]interface{} {
+	switch v := value.(type) {
+	case commercetools.CartDiscountValueRelative:
+		return []map[string]interface{}{{
+			"type":      "relative",
+			"permyriad": v.Permyriad,
+		}}
+	case commercetools.CartDiscountValueAbsolute:
+		money := make([]map[string]interface{}, len(v.Money))
+		for i, item := range v.Money {
+			money[i] = flattenCartDiscountMoney(item)
+		}
+		return []map[string]interface{}{{
+			"type":  "absolute",
+			"money": money,
+		}}
+	case commercetools.CartDiscountValueGiftLineItem:
+		result := map[string]interface{}{
+			"type":    "giftLineItem",
+			"variant": v.VariantID,
+		}
+		if v.Product != nil {
+			result["product_id"] = v.Product.ID
+		}
+		if v.SupplyChannel != nil {
+			result["supply_channel_id"] = v.SupplyChannel.ID
+		}
+		if v.DistributionChannel != nil {
+			result["distribution_channel_id"] = v.DistributionChannel.ID
+		}
+		return []map[string]interface{}{result}
+	}
+	log.Printf("[WARN] Cart discount value %T is not supported", value)
+	return nil
+}
+
+// flattenCartDiscountMoney returns the money of an absolute discount. The
+// amounts are normalized to int since money which is not decoded by the SDK
+// has float64 amounts.
+func flattenCartDiscountMoney(money commercetools.TypedMoney) map[string]interface{} {
+	switch m := money.(type) {
+	case commercetools.CentPrecisionMoney:
+		return map[string]interface{}{
+			"currency_code": string(m.CurrencyCode),
+			"cent_amount":   m.CentAmount,
+		}
+	case commercetools.HighPrecisionMoney:
+		return map[string]interface{}{
+			"currency_code": string(m.CurrencyCode),
+			"cent_amount":   m.CentAmount,
+		}
+	case map[string]interface{}:
+		return map[string]interface{}{
+			"currency_code": m["currencyCode"],
+			"cent_amount":   flattenInt(m["centAmount"]),
+		}
+	}
+	return map[string]interface{}{}
+}
+
 func resourceCartDiscountGetMoney(d map[string]interface{}) []commercetools.Money {
 	input := d["money"].([]interface{})
 	var result []commercetools.Money
@@ -513,7 +589,7 @@ func resourceCartDiscountGetMoney(d map[string]interface{}) []commercetools.Mone
 	return result
 }
 
-func resourceCartDiscountGetTarget(d *schema.ResourceData) (commercetools.CartDiscountTarget, error) {
+func resourceCartDiscountGetTarget(d resourceChange) (commercetools.CartDiscountTarget, error) {
 	input := d.Get("target").(map[string]interface{})
 
 	switch input["type"].(string) {
@@ -533,7 +609,7 @@ func resourceCartDiscountGetTarget(d *schema.ResourceData) (commercetools.CartDi
 
 }
 
-func resourceCartDiscountGetStackingMode(d *schema.ResourceData) (commercetools.StackingMode, error) {
+func resourceCartDiscountGetStackingMode(d resourceChange) (commercetools.StackingMode, error) {
 	switch d.Get("stacking_mode").(string) {
 	case "Stacking":
 		return commercetools.StackingModeStacking, nil
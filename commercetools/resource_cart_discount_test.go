@@ -5,8 +5,42 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/labd/commercetools-go-sdk/commercetools"
+	"github.com/stretchr/testify/assert"
 )
 
+func TestFlattenCartDiscountValue(t *testing.T) {
+	assert.Equal(t,
+		[]map[string]interface{}{{"type": "relative", "permyriad": 1000}},
+		flattenCartDiscountValue(commercetools.CartDiscountValueRelative{Permyriad: 1000}))
+
+	assert.Equal(t,
+		[]map[string]interface{}{{
+			"type": "absolute",
+			"money": []map[string]interface{}{
+				{"currency_code": "EUR", "cent_amount": 1500},
+				{"currency_code": "USD", "cent_amount": 1500},
+			},
+		}},
+		flattenCartDiscountValue(commercetools.CartDiscountValueAbsolute{
+			Money: []commercetools.TypedMoney{
+				commercetools.CentPrecisionMoney{CurrencyCode: "EUR", CentAmount: 1500},
+				map[string]interface{}{"currencyCode": "USD", "centAmount": 1500.0},
+			},
+		}))
+
+	assert.Equal(t,
+		[]map[string]interface{}{{
+			"type":       "giftLineItem",
+			"variant":    1,
+			"product_id": "product",
+		}},
+		flattenCartDiscountValue(commercetools.CartDiscountValueGiftLineItem{
+			VariantID: 1,
+			Product:   &commercetools.ProductReference{ID: "product"},
+		}))
+}
+
 func TestAccCartDiscountCreate_basic(t *testing.T) {
 
 	resource.Test(t, resource.TestCase{
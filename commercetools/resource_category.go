@@ -0,0 +1,370 @@
+package commercetools
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/labd/commercetools-go-sdk/commercetools"
+)
+
+func resourceCategory() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCategoryCreate,
+		Read:   resourceCategoryRead,
+		Update: resourceCategoryUpdate,
+		Delete: resourceCategoryDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"name": {
+				Type:     TypeLocalizedString,
+				Required: true,
+			},
+			"slug": {
+				Type:     TypeLocalizedString,
+				Required: true,
+			},
+			"description": {
+				Type:     TypeLocalizedString,
+				Optional: true,
+			},
+			"parent": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"order_hint": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"auto_order_hint": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Generate an order hint which doesn't collide with the siblings when order_hint is not set",
+			},
+			"generated_order_hint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"ancestor_ids": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The ids of the ancestors of the category, starting at the root",
+			},
+			"ancestor_keys": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The keys of the ancestors of the category, starting at the root",
+			},
+			"depth": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The depth of the category in the tree, root categories have depth 0",
+			},
+			"version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+		CustomizeDiff: customdiff.All(
+			// commercetools has no action to remove the parent of a category,
+			// so moving a category to the root means recreating it.
+			customdiff.ForceNewIfChange("parent", func(old, new, meta interface{}) bool {
+				return old.(string) != "" && new.(string) == ""
+			}),
+			// Moving the category changes its ancestors
+			customdiff.ComputedIf("ancestor_ids", categoryParentChanged),
+			customdiff.ComputedIf("ancestor_keys", categoryParentChanged),
+			customdiff.ComputedIf("depth", categoryParentChanged),
+		),
+	}
+}
+
+func categoryParentChanged(d *schema.ResourceDiff, meta interface{}) bool {
+	return d.HasChange("parent")
+}
+
+func resourceCategoryCreate(d *schema.ResourceData, m interface{}) error {
+	client := getClient(m)
+	var category *commercetools.Category
+
+	name := commercetools.LocalizedString(
+		expandStringMap(d.Get("name").(map[string]interface{})))
+	slug := commercetools.LocalizedString(
+		expandStringMap(d.Get("slug").(map[string]interface{})))
+	description := commercetools.LocalizedString(
+		expandStringMap(d.Get("description").(map[string]interface{})))
+
+	draft := &commercetools.CategoryDraft{
+		Key:         d.Get("key").(string),
+		Name:        &name,
+		Slug:        &slug,
+		Description: &description,
+		OrderHint:   d.Get("order_hint").(string),
+	}
+
+	parentID := d.Get("parent").(string)
+	if parentID != "" {
+		draft.Parent = &commercetools.CategoryResourceIdentifier{ID: parentID}
+	}
+
+	if draft.OrderHint == "" && d.Get("auto_order_hint").(bool) {
+		// Lock on the parent so categories created in parallel under the same
+		// parent get different order hints
+		lockKey := "category-order-hint:" + parentID
+		ctMutexKV.Lock(lockKey)
+		defer ctMutexKV.Unlock(lockKey)
+
+		orderHint, err := categoryNextOrderHint(client, parentID)
+		if err != nil {
+			return err
+		}
+		draft.OrderHint = orderHint
+		d.Set("generated_order_hint", orderHint)
+	}
+
+	err := resource.Retry(1*time.Minute, func() *resource.RetryError {
+		var err error
+
+		category, err = client.CategoryCreate(context.Background(), draft)
+		if err != nil {
+			return handleCommercetoolsError(err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	if category == nil {
+		log.Fatal("No category created?")
+	}
+
+	d.SetId(category.ID)
+	d.Set("version", category.Version)
+
+	return resourceCategoryRead(d, m)
+}
+
+func resourceCategoryRead(d *schema.ResourceData, m interface{}) error {
+	log.Printf("[DEBUG] Reading category from commercetools, with category id: %s", d.Id())
+
+	client := getClient(m)
+
+	category, err := client.CategoryGetWithID(
+		context.Background(), d.Id(), commercetools.WithReferenceExpansion("ancestors[*]"))
+
+	if err != nil {
+		if ctErr, ok := err.(commercetools.ErrorResponse); ok {
+			if ctErr.StatusCode == 404 {
+				d.SetId("")
+				return nil
+			}
+		}
+		return err
+	}
+
+	if category == nil {
+		log.Print("[DEBUG] No category found")
+		d.SetId("")
+	} else {
+		log.Print("[DEBUG] Found following category:")
+		log.Print(stringFormatObject(category))
+
+		d.Set("version", category.Version)
+		d.Set("key", category.Key)
+		if category.Name != nil {
+			d.Set("name", *category.Name)
+		}
+		if category.Slug != nil {
+			d.Set("slug", *category.Slug)
+		}
+		if category.Description != nil {
+			d.Set("description", *category.Description)
+		} else {
+			d.Set("description", nil)
+		}
+		if category.Parent != nil {
+			d.Set("parent", category.Parent.ID)
+		} else {
+			d.Set("parent", "")
+		}
+		ancestorIDs, ancestorKeys := flattenCategoryAncestors(category.Ancestors)
+		d.Set("ancestor_ids", ancestorIDs)
+		d.Set("ancestor_keys", ancestorKeys)
+		d.Set("depth", len(category.Ancestors))
+
+		// The generated order hint is not set in order_hint, since that would
+		// result in a diff with the configuration
+		if category.OrderHint != d.Get("generated_order_hint").(string) || category.OrderHint == "" {
+			d.Set("order_hint", category.OrderHint)
+		}
+	}
+
+	return nil
+}
+
+// flattenCategoryAncestors returns the ids and keys of the (expanded)
+// ancestors. Ancestors without a key have an empty key, so both lists have
+// the same length.
+func flattenCategoryAncestors(ancestors []commercetools.CategoryReference) ([]string, []string) {
+	ids := make([]string, len(ancestors))
+	keys := make([]string, len(ancestors))
+	for i, ancestor := range ancestors {
+		ids[i] = ancestor.ID
+		if ancestor.Obj != nil {
+			keys[i] = ancestor.Obj.Key
+		}
+	}
+	return ids, keys
+}
+
+// categoryNextOrderHint returns an order hint which is higher than the order
+// hints of the current children of the parent. When the parent is empty the
+// root categories are used.
+func categoryNextOrderHint(client *commercetools.Client, parentID string) (string, error) {
+	where := "parent is not defined"
+	if parentID != "" {
+		where = fmt.Sprintf("parent(id = %q)", parentID)
+	}
+
+	hints := []string{}
+	for offset := 0; ; offset += keyedPageSize {
+		result, err := client.CategoryQuery(context.Background(), &commercetools.QueryInput{
+			Where:  where,
+			Limit:  keyedPageSize,
+			Offset: offset,
+		})
+		if err != nil {
+			return "", err
+		}
+		for _, category := range result.Results {
+			hints = append(hints, category.OrderHint)
+		}
+		if len(result.Results) < keyedPageSize {
+			break
+		}
+	}
+	return nextOrderHint(hints), nil
+}
+
+// orderHintSpacing is the space between generated order hints
+const orderHintSpacing = 0.01
+
+// nextOrderHint returns an order hint after the given hints. The hints are
+// spaced so categories can be ordered in between manually. When the space
+// runs out the hint is placed halfway between the highest hint and 1.
+func nextOrderHint(hints []string) string {
+	highest := 0.0
+	for _, hint := range hints {
+		value, err := strconv.ParseFloat(hint, 64)
+		if err == nil && value > highest && value < 1 {
+			highest = value
+		}
+	}
+
+	next := highest + orderHintSpacing
+	if next >= 1 {
+		next = (highest + 1) / 2
+	}
+	// Round to prevent floating point artifacts like 0.30000000000000004
+	next = math.Round(next*1e9) / 1e9
+	return strconv.FormatFloat(next, 'f', -1, 64)
+}
+
+func resourceCategoryUpdate(d *schema.ResourceData, m interface{}) error {
+	client := getClient(m)
+
+	input := &commercetools.CategoryUpdateWithIDInput{
+		ID:      d.Id(),
+		Version: d.Get("version").(int),
+		Actions: []commercetools.CategoryUpdateAction{},
+	}
+
+	if d.HasChange("key") {
+		newKey := d.Get("key").(string)
+		input.Actions = append(
+			input.Actions,
+			&commercetools.CategorySetKeyAction{Key: newKey})
+	}
+
+	if d.HasChange("name") {
+		newName := commercetools.LocalizedString(
+			expandStringMap(d.Get("name").(map[string]interface{})))
+		input.Actions = append(
+			input.Actions,
+			&commercetools.CategoryChangeNameAction{Name: &newName})
+	}
+
+	if d.HasChange("slug") {
+		newSlug := commercetools.LocalizedString(
+			expandStringMap(d.Get("slug").(map[string]interface{})))
+		input.Actions = append(
+			input.Actions,
+			&commercetools.CategoryChangeSlugAction{Slug: &newSlug})
+	}
+
+	if d.HasChange("description") {
+		newDescription := commercetools.LocalizedString(
+			expandStringMap(d.Get("description").(map[string]interface{})))
+		input.Actions = append(
+			input.Actions,
+			&commercetools.CategorySetDescriptionAction{Description: &newDescription})
+	}
+
+	if d.HasChange("parent") {
+		newParent := d.Get("parent").(string)
+		input.Actions = append(
+			input.Actions,
+			&commercetools.CategoryChangeParentAction{
+				Parent: &commercetools.CategoryResourceIdentifier{ID: newParent},
+			})
+	}
+
+	if d.HasChange("order_hint") {
+		newOrderHint := d.Get("order_hint").(string)
+		input.Actions = append(
+			input.Actions,
+			&commercetools.CategoryChangeOrderHintAction{OrderHint: newOrderHint})
+	}
+
+	log.Printf(
+		"[DEBUG] Will perform update operation with the following actions:\n%s",
+		stringFormatActions(input.Actions))
+
+	_, err := client.CategoryUpdateWithID(context.Background(), input)
+	if err != nil {
+		if ctErr, ok := err.(commercetools.ErrorResponse); ok {
+			log.Printf("[DEBUG] %v: %v", ctErr, stringFormatErrorExtras(ctErr))
+		}
+		return err
+	}
+
+	return resourceCategoryRead(d, m)
+}
+
+func resourceCategoryDelete(d *schema.ResourceData, m interface{}) error {
+	client := getClient(m)
+	version := d.Get("version").(int)
+	_, err := client.CategoryDeleteWithID(context.Background(), d.Id(), version)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
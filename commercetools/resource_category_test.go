@@ -0,0 +1,105 @@
+package commercetools
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/labd/commercetools-go-sdk/commercetools"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextOrderHint(t *testing.T) {
+	assert.Equal(t, "0.01", nextOrderHint([]string{}))
+	assert.Equal(t, "0.31", nextOrderHint([]string{"0.1", "0.3", "", "invalid", "0.2"}))
+	assert.Equal(t, "0.995", nextOrderHint([]string{"0.99"}))
+}
+
+func TestFlattenCategoryAncestors(t *testing.T) {
+	ids, keys := flattenCategoryAncestors([]commercetools.CategoryReference{
+		{ID: "1", Obj: &commercetools.Category{ID: "1", Key: "men"}},
+		{ID: "2"},
+	})
+	assert.Equal(t, []string{"1", "2"}, ids)
+	assert.Equal(t, []string{"men", ""}, keys)
+
+	ids, keys = flattenCategoryAncestors(nil)
+	assert.Empty(t, ids)
+	assert.Empty(t, keys)
+}
+
+func TestAccCategory_createAndUpdate(t *testing.T) {
+	rName := acctest.RandString(5)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckCategoryDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccCategoryConfig(rName, "Shoes", "0.1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"commercetools_category.parent", "name.en", "Clothing",
+					),
+					resource.TestCheckResourceAttr(
+						"commercetools_category.child", "name.en", "Shoes",
+					),
+					resource.TestCheckResourceAttr(
+						"commercetools_category.child", "order_hint", "0.1",
+					),
+					resource.TestCheckResourceAttrPair(
+						"commercetools_category.child", "parent",
+						"commercetools_category.parent", "id",
+					),
+				),
+			},
+			{
+				Config: testAccCategoryConfig(rName, "Sneakers", "0.2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"commercetools_category.child", "name.en", "Sneakers",
+					),
+					resource.TestCheckResourceAttr(
+						"commercetools_category.child", "order_hint", "0.2",
+					),
+				),
+			},
+		},
+	})
+}
+
+func testAccCategoryConfig(rName string, childName string, orderHint string) string {
+	return fmt.Sprintf(`
+resource "commercetools_category" "parent" {
+	key = "clothing-%[1]s"
+	name = {
+		en = "Clothing"
+	}
+	slug = {
+		en = "clothing-%[1]s"
+	}
+}
+
+resource "commercetools_category" "child" {
+	key = "shoes-%[1]s"
+	name = {
+		en = "%[2]s"
+	}
+	slug = {
+		en = "shoes-%[1]s"
+	}
+	description = {
+		en = "All shoes"
+	}
+	parent     = commercetools_category.parent.id
+	order_hint = "%[3]s"
+}
+`, rName, childName, orderHint)
+}
+
+func testAccCheckCategoryDestroy(s *terraform.State) error {
+	return nil
+}
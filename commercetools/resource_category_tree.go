@@ -0,0 +1,605 @@
+package commercetools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/labd/commercetools-go-sdk/commercetools"
+)
+
+// categoryTreeNode is a category in the tree, the children are nested in the
+// category
+type categoryTreeNode struct {
+	Key         string             `json:"key"`
+	Name        map[string]string  `json:"name"`
+	Slug        map[string]string  `json:"slug"`
+	Description map[string]string  `json:"description,omitempty"`
+	OrderHint   string             `json:"order_hint,omitempty"`
+	Children    []categoryTreeNode `json:"children,omitempty"`
+}
+
+// categoryTreeItem is a category of the flattened tree
+type categoryTreeItem struct {
+	Node      categoryTreeNode
+	ParentKey string
+	Depth     int
+}
+
+const (
+	categoryTreeCreate = "create"
+	categoryTreeUpdate = "update"
+	categoryTreeDelete = "delete"
+)
+
+// categoryTreeOperation is a single category which needs to be created,
+// updated or deleted to change the tree
+type categoryTreeOperation struct {
+	Type      string
+	Key       string
+	OldKey    string
+	Node      categoryTreeNode
+	ParentKey string
+	Changes   []string
+}
+
+// resourceCategoryTree manages a complete tree of categories in a single
+// resource. The tree is passed as JSON since the schema can't describe a
+// recursive structure.
+func resourceCategoryTree() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCategoryTreeCreate,
+		Read:   resourceCategoryTreeRead,
+		Update: resourceCategoryTreeUpdate,
+		Delete: resourceCategoryTreeDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"parent_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The category under which the root categories of the tree are created",
+			},
+			"tree": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ValidateFunc:     validateCategoryTree,
+				DiffSuppressFunc: diffSuppressCategoryTree,
+				Description:      "The categories as a JSON list, every category can contain children",
+			},
+			"ids": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+		},
+	}
+}
+
+func validateCategoryTree(val interface{}, key string) (warns []string, errs []error) {
+	nodes, err := expandCategoryTree(val.(string))
+	if err != nil {
+		errs = append(errs, fmt.Errorf("%s is not a valid category tree: %w", key, err))
+		return
+	}
+
+	keys := map[string]bool{}
+	for _, item := range flattenCategoryTreeNodes(nodes) {
+		switch {
+		case item.Node.Key == "":
+			errs = append(errs, fmt.Errorf("every category in %s needs a key", key))
+		case keys[item.Node.Key]:
+			errs = append(errs, fmt.Errorf("category %s is defined more than once in %s", item.Node.Key, key))
+		case len(item.Node.Name) == 0 || len(item.Node.Slug) == 0:
+			errs = append(errs, fmt.Errorf("category %s in %s needs a name and slug", item.Node.Key, key))
+		}
+		keys[item.Node.Key] = true
+	}
+	return
+}
+
+// diffSuppressCategoryTree ignores differences in formatting and in the order
+// of the categories, the order is determined by the order hint. Categories
+// without an order hint get one assigned by commercetools, so an empty order
+// hint matches the order hint which was read.
+func diffSuppressCategoryTree(k, old, new string, d *schema.ResourceData) bool {
+	oldNodes, err := expandCategoryTree(old)
+	if err != nil {
+		return false
+	}
+	newNodes, err := expandCategoryTree(new)
+	if err != nil {
+		return false
+	}
+	orderHints := map[string]string{}
+	for _, item := range flattenCategoryTreeNodes(oldNodes) {
+		orderHints[item.Node.Key] = item.Node.OrderHint
+	}
+	newNodes = fillCategoryTreeOrderHints(newNodes, orderHints)
+	return reflect.DeepEqual(normalizeCategoryTree(oldNodes), normalizeCategoryTree(newNodes))
+}
+
+// fillCategoryTreeOrderHints sets the order hints of the categories without
+// an order hint to the given order hints
+func fillCategoryTreeOrderHints(nodes []categoryTreeNode, orderHints map[string]string) []categoryTreeNode {
+	result := make([]categoryTreeNode, len(nodes))
+	for i, node := range nodes {
+		if node.OrderHint == "" {
+			node.OrderHint = orderHints[node.Key]
+		}
+		node.Children = fillCategoryTreeOrderHints(node.Children, orderHints)
+		result[i] = node
+	}
+	return result
+}
+
+func expandCategoryTree(input string) ([]categoryTreeNode, error) {
+	nodes := []categoryTreeNode{}
+	if input == "" {
+		return nodes, nil
+	}
+	if err := json.Unmarshal([]byte(input), &nodes); err != nil {
+		return nil, err
+	}
+	return nodes, nil
+}
+
+// normalizeCategoryTree sorts the categories by order hint and key and
+// removes empty values
+func normalizeCategoryTree(nodes []categoryTreeNode) []categoryTreeNode {
+	result := make([]categoryTreeNode, len(nodes))
+	for i, node := range nodes {
+		if len(node.Description) == 0 {
+			node.Description = nil
+		}
+		node.Children = normalizeCategoryTree(node.Children)
+		if len(node.Children) == 0 {
+			node.Children = nil
+		}
+		result[i] = node
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].OrderHint != result[j].OrderHint {
+			return result[i].OrderHint < result[j].OrderHint
+		}
+		return result[i].Key < result[j].Key
+	})
+	return result
+}
+
+// flattenCategoryTreeNodes returns all categories of the tree, parents are
+// always returned before their children
+func flattenCategoryTreeNodes(nodes []categoryTreeNode) []categoryTreeItem {
+	result := []categoryTreeItem{}
+	var walk func(nodes []categoryTreeNode, parentKey string, depth int)
+	walk = func(nodes []categoryTreeNode, parentKey string, depth int) {
+		for _, node := range nodes {
+			result = append(result, categoryTreeItem{Node: node, ParentKey: parentKey, Depth: depth})
+			walk(node.Children, node.Key, depth+1)
+		}
+	}
+	walk(nodes, "", 0)
+	return result
+}
+
+// categoryTreeOperations returns the operations needed to change the old
+// tree into the new tree. A removed category with the same slug as an added
+// category is the same category with a new key, the keys are changed first.
+// Then new categories are created (parents before children), existing
+// categories are updated or moved and finally the removed categories are
+// deleted (children before parents).
+func categoryTreeOperations(old []categoryTreeNode, new []categoryTreeNode) []categoryTreeOperation {
+	oldItems := map[string]categoryTreeItem{}
+	for _, item := range flattenCategoryTreeNodes(old) {
+		oldItems[item.Node.Key] = item
+	}
+	newItems := flattenCategoryTreeNodes(new)
+	newKeys := map[string]bool{}
+	for _, item := range newItems {
+		newKeys[item.Node.Key] = true
+	}
+
+	// Creating the category with the new key would fail on the slug of the
+	// category with the old key, which is only deleted at the end
+	renamed := map[string]string{}
+	newKeysByOldKey := map[string]string{}
+	for _, oldItem := range flattenCategoryTreeNodes(old) {
+		if newKeys[oldItem.Node.Key] {
+			continue
+		}
+		for _, item := range newItems {
+			_, exists := oldItems[item.Node.Key]
+			if exists || renamed[item.Node.Key] != "" {
+				continue
+			}
+			if reflect.DeepEqual(oldItem.Node.Slug, item.Node.Slug) {
+				renamed[item.Node.Key] = oldItem.Node.Key
+				newKeysByOldKey[oldItem.Node.Key] = item.Node.Key
+				break
+			}
+		}
+	}
+
+	renames := []categoryTreeOperation{}
+	creates := []categoryTreeOperation{}
+	updates := []categoryTreeOperation{}
+	for _, item := range newItems {
+		oldItem, ok := oldItems[item.Node.Key]
+		if oldKey, isRenamed := renamed[item.Node.Key]; isRenamed {
+			oldItem, ok = oldItems[oldKey], true
+			renames = append(renames, categoryTreeOperation{
+				Type:    categoryTreeUpdate,
+				Key:     item.Node.Key,
+				OldKey:  oldKey,
+				Node:    item.Node,
+				Changes: []string{"key"},
+			})
+		}
+		if !ok {
+			creates = append(creates, categoryTreeOperation{
+				Type:      categoryTreeCreate,
+				Key:       item.Node.Key,
+				Node:      item.Node,
+				ParentKey: item.ParentKey,
+			})
+			continue
+		}
+
+		oldParentKey := oldItem.ParentKey
+		if newKey, isRenamed := newKeysByOldKey[oldParentKey]; isRenamed {
+			oldParentKey = newKey
+		}
+		changes := []string{}
+		if oldParentKey != item.ParentKey {
+			changes = append(changes, "parent")
+		}
+		if !reflect.DeepEqual(oldItem.Node.Name, item.Node.Name) {
+			changes = append(changes, "name")
+		}
+		if !reflect.DeepEqual(oldItem.Node.Slug, item.Node.Slug) {
+			changes = append(changes, "slug")
+		}
+		if len(oldItem.Node.Description)+len(item.Node.Description) > 0 &&
+			!reflect.DeepEqual(oldItem.Node.Description, item.Node.Description) {
+			changes = append(changes, "description")
+		}
+		// Without an order hint commercetools assigns one
+		if item.Node.OrderHint != "" && oldItem.Node.OrderHint != item.Node.OrderHint {
+			changes = append(changes, "order_hint")
+		}
+		if len(changes) > 0 {
+			updates = append(updates, categoryTreeOperation{
+				Type:      categoryTreeUpdate,
+				Key:       item.Node.Key,
+				Node:      item.Node,
+				ParentKey: item.ParentKey,
+				Changes:   changes,
+			})
+		}
+	}
+
+	deleted := []categoryTreeItem{}
+	for _, item := range flattenCategoryTreeNodes(old) {
+		if !newKeys[item.Node.Key] && newKeysByOldKey[item.Node.Key] == "" {
+			deleted = append(deleted, item)
+		}
+	}
+	sort.SliceStable(deleted, func(i, j int) bool {
+		return deleted[i].Depth > deleted[j].Depth
+	})
+	deletes := make([]categoryTreeOperation, len(deleted))
+	for i, item := range deleted {
+		deletes[i] = categoryTreeOperation{
+			Type:      categoryTreeDelete,
+			Key:       item.Node.Key,
+			Node:      item.Node,
+			ParentKey: item.ParentKey,
+		}
+	}
+
+	result := append(renames, creates...)
+	result = append(result, updates...)
+	return append(result, deletes...)
+}
+
+func resourceCategoryTreeCreate(d *schema.ResourceData, m interface{}) error {
+	nodes, err := expandCategoryTree(d.Get("tree").(string))
+	if err != nil {
+		return err
+	}
+
+	ids := map[string]string{}
+	d.SetId(resource.PrefixedUniqueId("category-tree-"))
+	err = resourceCategoryTreeApply(d, m, categoryTreeOperations(nil, nodes), ids)
+	d.Set("ids", ids)
+	if err != nil {
+		if len(ids) == 0 {
+			d.SetId("")
+		} else if readErr := resourceCategoryTreeRead(d, m); readErr != nil {
+			log.Printf("[ERROR] Unable to read category tree %s: %s", d.Id(), readErr)
+		}
+		return err
+	}
+	return resourceCategoryTreeRead(d, m)
+}
+
+func resourceCategoryTreeRead(d *schema.ResourceData, m interface{}) error {
+	client := getClient(m)
+	ids := expandStringMap(d.Get("ids").(map[string]interface{}))
+
+	categories := []commercetools.Category{}
+	idList := make([]string, 0, len(ids))
+	for _, id := range ids {
+		idList = append(idList, id)
+	}
+	sort.Strings(idList)
+	for start := 0; start < len(idList); start += keyedPageSize {
+		end := start + keyedPageSize
+		if end > len(idList) {
+			end = len(idList)
+		}
+		result, err := client.CategoryQuery(context.Background(), &commercetools.QueryInput{
+			Where: fmt.Sprintf("id in (%s)", quotePredicateValues(idList[start:end])),
+			Limit: keyedPageSize,
+		})
+		if err != nil {
+			return err
+		}
+		categories = append(categories, result.Results...)
+	}
+
+	if len(categories) == 0 && len(ids) > 0 {
+		log.Printf("[DEBUG] None of the categories of tree %s exist anymore", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	nodes, readIDs := flattenCategoryTree(categories)
+	data, err := json.Marshal(nodes)
+	if err != nil {
+		return err
+	}
+	d.Set("tree", string(data))
+	d.Set("ids", readIDs)
+	return nil
+}
+
+// flattenCategoryTree returns the tree of the given categories, categories
+// whose parent is not in the list are the roots of the tree
+func flattenCategoryTree(categories []commercetools.Category) ([]categoryTreeNode, map[string]string) {
+	ids := map[string]string{}
+	keys := map[string]string{}
+	for _, category := range categories {
+		ids[category.Key] = category.ID
+		keys[category.ID] = category.Key
+	}
+
+	children := map[string][]commercetools.Category{}
+	for _, category := range categories {
+		parentKey := ""
+		if category.Parent != nil {
+			parentKey = keys[category.Parent.ID]
+		}
+		children[parentKey] = append(children[parentKey], category)
+	}
+
+	var build func(parentKey string) []categoryTreeNode
+	build = func(parentKey string) []categoryTreeNode {
+		nodes := []categoryTreeNode{}
+		for _, category := range children[parentKey] {
+			node := categoryTreeNode{
+				Key:       category.Key,
+				OrderHint: category.OrderHint,
+				Children:  build(category.Key),
+			}
+			if category.Name != nil {
+				node.Name = *category.Name
+			}
+			if category.Slug != nil {
+				node.Slug = *category.Slug
+			}
+			if category.Description != nil && len(*category.Description) > 0 {
+				node.Description = *category.Description
+			}
+			nodes = append(nodes, node)
+		}
+		return nodes
+	}
+	return normalizeCategoryTree(build("")), ids
+}
+
+func resourceCategoryTreeUpdate(d *schema.ResourceData, m interface{}) error {
+	if !d.HasChange("tree") {
+		return resourceCategoryTreeRead(d, m)
+	}
+
+	old, new := d.GetChange("tree")
+	oldNodes, err := expandCategoryTree(old.(string))
+	if err != nil {
+		return err
+	}
+	newNodes, err := expandCategoryTree(new.(string))
+	if err != nil {
+		return err
+	}
+
+	ids := expandStringMap(d.Get("ids").(map[string]interface{}))
+	err = resourceCategoryTreeApply(d, m, categoryTreeOperations(oldNodes, newNodes), ids)
+	d.Set("ids", ids)
+	if err != nil {
+		// Read the tree so the state contains the operations which succeeded
+		// and the next plan only contains the remaining changes
+		if readErr := resourceCategoryTreeRead(d, m); readErr != nil {
+			log.Printf("[ERROR] Unable to read category tree %s: %s", d.Id(), readErr)
+		}
+		return err
+	}
+	return resourceCategoryTreeRead(d, m)
+}
+
+func resourceCategoryTreeDelete(d *schema.ResourceData, m interface{}) error {
+	nodes, err := expandCategoryTree(d.Get("tree").(string))
+	if err != nil {
+		return err
+	}
+	ids := expandStringMap(d.Get("ids").(map[string]interface{}))
+	return resourceCategoryTreeApply(d, m, categoryTreeOperations(nodes, nil), ids)
+}
+
+// resourceCategoryTreeApply performs the operations in order, the ids map is
+// updated with the created and deleted categories
+func resourceCategoryTreeApply(d *schema.ResourceData, m interface{}, operations []categoryTreeOperation, ids map[string]string) error {
+	client := getClient(m)
+	ctx := context.Background()
+
+	parentID := func(parentKey string) string {
+		if parentKey == "" {
+			return d.Get("parent_id").(string)
+		}
+		return ids[parentKey]
+	}
+
+	for _, operation := range operations {
+		log.Printf("[DEBUG] Category tree %s: %s category %s", d.Id(), operation.Type, operation.Key)
+
+		switch operation.Type {
+		case categoryTreeCreate:
+			node := operation.Node
+			name := commercetools.LocalizedString(node.Name)
+			slug := commercetools.LocalizedString(node.Slug)
+			draft := &commercetools.CategoryDraft{
+				Key:       node.Key,
+				Name:      &name,
+				Slug:      &slug,
+				OrderHint: node.OrderHint,
+			}
+			if len(node.Description) > 0 {
+				description := commercetools.LocalizedString(node.Description)
+				draft.Description = &description
+			}
+			if id := parentID(operation.ParentKey); id != "" {
+				draft.Parent = &commercetools.CategoryResourceIdentifier{ID: id}
+			}
+
+			var category *commercetools.Category
+			err := resource.Retry(1*time.Minute, func() *resource.RetryError {
+				var err error
+				category, err = client.CategoryCreate(ctx, draft)
+				if err != nil {
+					return handleCommercetoolsError(err)
+				}
+				return nil
+			})
+			if err != nil {
+				return fmt.Errorf("unable to create category %s: %w", node.Key, err)
+			}
+			ids[node.Key] = category.ID
+
+		case categoryTreeUpdate:
+			currentKey := operation.Key
+			if operation.OldKey != "" {
+				currentKey = operation.OldKey
+			}
+			id, ok := ids[currentKey]
+			if !ok {
+				return fmt.Errorf("category %s is not managed by this tree", currentKey)
+			}
+			actions, err := categoryTreeUpdateActions(operation, parentID(operation.ParentKey))
+			if err != nil {
+				return err
+			}
+			category, err := client.CategoryGetWithID(ctx, id)
+			if err != nil {
+				return err
+			}
+			log.Printf(
+				"[DEBUG] Will perform update operation with the following actions:\n%s",
+				stringFormatActions(actions))
+			_, err = client.CategoryUpdateWithID(ctx, &commercetools.CategoryUpdateWithIDInput{
+				ID:      id,
+				Version: category.Version,
+				Actions: actions,
+			})
+			if err != nil {
+				if ctErr, ok := err.(commercetools.ErrorResponse); ok {
+					log.Printf("[DEBUG] %v: %v", ctErr, stringFormatErrorExtras(ctErr))
+				}
+				return fmt.Errorf("unable to update category %s: %w", operation.Key, err)
+			}
+			if currentKey != operation.Key {
+				delete(ids, currentKey)
+				ids[operation.Key] = id
+			}
+
+		case categoryTreeDelete:
+			id, ok := ids[operation.Key]
+			if !ok {
+				continue
+			}
+			category, err := client.CategoryGetWithID(ctx, id)
+			if err != nil {
+				if ctErr, ok := err.(commercetools.ErrorResponse); ok && ctErr.StatusCode == 404 {
+					delete(ids, operation.Key)
+					continue
+				}
+				return err
+			}
+			if _, err := client.CategoryDeleteWithID(ctx, id, category.Version); err != nil {
+				return fmt.Errorf("unable to delete category %s: %w", operation.Key, err)
+			}
+			delete(ids, operation.Key)
+		}
+	}
+	return nil
+}
+
+// categoryTreeUpdateActions returns the update actions for the changes of an
+// existing category
+func categoryTreeUpdateActions(operation categoryTreeOperation, parentID string) ([]commercetools.CategoryUpdateAction, error) {
+	node := operation.Node
+	actions := []commercetools.CategoryUpdateAction{}
+	for _, change := range operation.Changes {
+		switch change {
+		case "parent":
+			// commercetools has no action to remove the parent of a category
+			if parentID == "" {
+				return nil, fmt.Errorf(
+					"category %s can't be moved to the root of the tree, remove it and add it again in a separate apply instead",
+					node.Key)
+			}
+			actions = append(actions, &commercetools.CategoryChangeParentAction{
+				Parent: &commercetools.CategoryResourceIdentifier{ID: parentID},
+			})
+		case "key":
+			actions = append(actions, &commercetools.CategorySetKeyAction{Key: node.Key})
+		case "name":
+			name := commercetools.LocalizedString(node.Name)
+			actions = append(actions, &commercetools.CategoryChangeNameAction{Name: &name})
+		case "slug":
+			slug := commercetools.LocalizedString(node.Slug)
+			actions = append(actions, &commercetools.CategoryChangeSlugAction{Slug: &slug})
+		case "description":
+			action := &commercetools.CategorySetDescriptionAction{}
+			if len(node.Description) > 0 {
+				description := commercetools.LocalizedString(node.Description)
+				action.Description = &description
+			}
+			actions = append(actions, action)
+		case "order_hint":
+			actions = append(actions, &commercetools.CategoryChangeOrderHintAction{OrderHint: node.OrderHint})
+		default:
+			return nil, fmt.Errorf("unsupported change %s of category %s", change, node.Key)
+		}
+	}
+	return actions, nil
+}
@@ -0,0 +1,205 @@
+package commercetools
+
+import (
+	"testing"
+
+	"github.com/labd/commercetools-go-sdk/commercetools"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateCategoryTree(t *testing.T) {
+	_, errs := validateCategoryTree(`[
+		{"key": "men", "name": {"en": "Men"}, "slug": {"en": "men"}, "children": [
+			{"key": "shoes", "name": {"en": "Shoes"}, "slug": {"en": "men-shoes"}}
+		]}
+	]`, "tree")
+	assert.Empty(t, errs)
+
+	_, errs = validateCategoryTree(`[
+		{"key": "men", "name": {"en": "Men"}, "slug": {"en": "men"}, "children": [
+			{"key": "men", "name": {"en": "Men"}, "slug": {"en": "men-men"}},
+			{"name": {"en": "Shoes"}, "slug": {"en": "shoes"}},
+			{"key": "shirts", "name": {"en": "Shirts"}}
+		]}
+	]`, "tree")
+	assert.Len(t, errs, 3)
+
+	_, errs = validateCategoryTree(`{"key": "men"}`, "tree")
+	assert.Len(t, errs, 1)
+}
+
+func TestDiffSuppressCategoryTree(t *testing.T) {
+	old := `[
+		{"key": "b", "name": {"en": "B"}, "slug": {"en": "b"}},
+		{"key": "a", "name": {"en": "A"}, "slug": {"en": "a"}, "description": {}}
+	]`
+	new := `[{"key":"a","name":{"en":"A"},"slug":{"en":"a"}},{"key":"b","name":{"en":"B"},"slug":{"en":"b"},"children":[]}]`
+	assert.True(t, diffSuppressCategoryTree("tree", old, new, nil))
+
+	changed := `[{"key":"a","name":{"en":"A"},"slug":{"en":"a"}},{"key":"b","name":{"en":"Bee"},"slug":{"en":"b"}}]`
+	assert.False(t, diffSuppressCategoryTree("tree", old, changed, nil))
+
+	// The order hints assigned by commercetools are read back
+	read := `[
+		{"key": "a", "name": {"en": "A"}, "slug": {"en": "a"}, "order_hint": "0.000016"},
+		{"key": "b", "name": {"en": "B"}, "slug": {"en": "b"}, "order_hint": "0.000015"}
+	]`
+	assert.True(t, diffSuppressCategoryTree("tree", read, new, nil))
+
+	reordered := `[{"key":"a","name":{"en":"A"},"slug":{"en":"a"},"order_hint":"0.1"},{"key":"b","name":{"en":"B"},"slug":{"en":"b"}}]`
+	assert.False(t, diffSuppressCategoryTree("tree", read, reordered, nil))
+}
+
+func TestCategoryTreeOperations(t *testing.T) {
+	old, _ := expandCategoryTree(`[
+		{"key": "men", "name": {"en": "Men"}, "slug": {"en": "men"}, "children": [
+			{"key": "shoes", "name": {"en": "Shoes"}, "slug": {"en": "men-shoes"}, "children": [
+				{"key": "boots", "name": {"en": "Boots"}, "slug": {"en": "men-boots"}}
+			]},
+			{"key": "shirts", "name": {"en": "Shirts"}, "slug": {"en": "men-shirts"}}
+		]}
+	]`)
+	new, _ := expandCategoryTree(`[
+		{"key": "men", "name": {"en": "Men"}, "slug": {"en": "men"}, "children": [
+			{"key": "shirts", "name": {"en": "Shirts & Tops"}, "slug": {"en": "men-shirts"}}
+		]},
+		{"key": "women", "name": {"en": "Women"}, "slug": {"en": "women"}, "children": [
+			{"key": "women-shoes", "name": {"en": "Shoes"}, "slug": {"en": "women-shoes"}, "children": [
+				{"key": "boots", "name": {"en": "Boots"}, "slug": {"en": "men-boots"}}
+			]}
+		]}
+	]`)
+
+	operations := categoryTreeOperations(old, new)
+	summary := []string{}
+	for _, operation := range operations {
+		summary = append(summary, operation.Type+" "+operation.Key)
+	}
+	assert.Equal(t, []string{
+		"create women",
+		"create women-shoes",
+		"update shirts",
+		"update boots",
+		"delete shoes",
+	}, summary)
+
+	assert.Equal(t, []string{"name"}, operations[2].Changes)
+	assert.Equal(t, []string{"parent"}, operations[3].Changes)
+	assert.Equal(t, "women-shoes", operations[3].ParentKey)
+
+	// Deleting the tree deletes the children before the parents
+	summary = []string{}
+	for _, operation := range categoryTreeOperations(old, nil) {
+		summary = append(summary, operation.Type+" "+operation.Key)
+	}
+	assert.Equal(t, []string{"delete boots", "delete shoes", "delete shirts", "delete men"}, summary)
+}
+
+func TestCategoryTreeOperationsOrderHint(t *testing.T) {
+	old, _ := expandCategoryTree(`[
+		{"key": "men", "name": {"en": "Men"}, "slug": {"en": "men"}, "order_hint": "0.000016"},
+		{"key": "women", "name": {"en": "Women"}, "slug": {"en": "women"}, "order_hint": "0.000015"}
+	]`)
+	new, _ := expandCategoryTree(`[
+		{"key": "men", "name": {"en": "Men & Boys"}, "slug": {"en": "men"}},
+		{"key": "women", "name": {"en": "Women"}, "slug": {"en": "women"}, "order_hint": "0.1"}
+	]`)
+
+	operations := categoryTreeOperations(old, new)
+	assert.Len(t, operations, 2)
+	assert.Equal(t, []string{"name"}, operations[0].Changes)
+	assert.Equal(t, []string{"order_hint"}, operations[1].Changes)
+}
+
+func TestCategoryTreeOperationsChangeKey(t *testing.T) {
+	old, _ := expandCategoryTree(`[
+		{"key": "men", "name": {"en": "Men"}, "slug": {"en": "men"}, "children": [
+			{"key": "shoes", "name": {"en": "Shoes"}, "slug": {"en": "men-shoes"}}
+		]}
+	]`)
+	new, _ := expandCategoryTree(`[
+		{"key": "men-v2", "name": {"en": "Men"}, "slug": {"en": "men"}, "children": [
+			{"key": "shoes", "name": {"en": "Shoes"}, "slug": {"en": "men-shoes"}},
+			{"key": "boots", "name": {"en": "Boots"}, "slug": {"en": "men-boots"}}
+		]}
+	]`)
+
+	operations := categoryTreeOperations(old, new)
+	summary := []string{}
+	for _, operation := range operations {
+		summary = append(summary, operation.Type+" "+operation.Key)
+	}
+	assert.Equal(t, []string{"update men-v2", "create boots"}, summary)
+	assert.Equal(t, "men", operations[0].OldKey)
+	assert.Equal(t, []string{"key"}, operations[0].Changes)
+	assert.Equal(t, "men-v2", operations[1].ParentKey)
+}
+
+func TestCategoryTreeUpdateActions(t *testing.T) {
+	operation := categoryTreeOperation{
+		Key:     "shoes",
+		Node:    categoryTreeNode{Key: "shoes", OrderHint: "0.5"},
+		Changes: []string{"parent", "order_hint"},
+	}
+	actions, err := categoryTreeUpdateActions(operation, "parent-id")
+	assert.NoError(t, err)
+	assert.Equal(t, []commercetools.CategoryUpdateAction{
+		&commercetools.CategoryChangeParentAction{
+			Parent: &commercetools.CategoryResourceIdentifier{ID: "parent-id"},
+		},
+		&commercetools.CategoryChangeOrderHintAction{OrderHint: "0.5"},
+	}, actions)
+
+	_, err = categoryTreeUpdateActions(operation, "")
+	assert.Error(t, err)
+
+	operation = categoryTreeOperation{
+		Key:     "shoes-v2",
+		OldKey:  "shoes",
+		Node:    categoryTreeNode{Key: "shoes-v2"},
+		Changes: []string{"key"},
+	}
+	actions, err = categoryTreeUpdateActions(operation, "parent-id")
+	assert.NoError(t, err)
+	assert.Equal(t, []commercetools.CategoryUpdateAction{
+		&commercetools.CategorySetKeyAction{Key: "shoes-v2"},
+	}, actions)
+}
+
+func TestFlattenCategoryTree(t *testing.T) {
+	categories := []commercetools.Category{
+		{
+			ID:   "2",
+			Key:  "shoes",
+			Name: &commercetools.LocalizedString{"en": "Shoes"},
+			Slug: &commercetools.LocalizedString{"en": "men-shoes"},
+			Parent: &commercetools.CategoryReference{
+				ID: "1",
+			},
+		},
+		{
+			ID:     "1",
+			Key:    "men",
+			Name:   &commercetools.LocalizedString{"en": "Men"},
+			Slug:   &commercetools.LocalizedString{"en": "men"},
+			Parent: &commercetools.CategoryReference{ID: "external"},
+		},
+	}
+
+	nodes, ids := flattenCategoryTree(categories)
+	assert.Equal(t, map[string]string{"men": "1", "shoes": "2"}, ids)
+	assert.Equal(t, []categoryTreeNode{
+		{
+			Key:  "men",
+			Name: map[string]string{"en": "Men"},
+			Slug: map[string]string{"en": "men"},
+			Children: []categoryTreeNode{
+				{
+					Key:  "shoes",
+					Name: map[string]string{"en": "Shoes"},
+					Slug: map[string]string{"en": "men-shoes"},
+				},
+			},
+		},
+	}, nodes)
+}
@@ -2,10 +2,13 @@ package commercetools
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/labd/commercetools-go-sdk/commercetools"
 )
 
@@ -26,7 +29,10 @@ func resourceChannel() *schema.Resource {
 			"roles": {
 				Type:     schema.TypeList,
 				Required: true,
-				Elem:     &schema.Schema{Type: schema.TypeString},
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(channelRoles, false),
+				},
 			},
 			"name": {
 				Type:     TypeLocalizedString,
@@ -41,9 +47,38 @@ func resourceChannel() *schema.Resource {
 				Computed: true,
 			},
 		},
+		CustomizeDiff: customdiff.ValidateValue("roles", func(value, meta interface{}) error {
+			return validateChannelRoles(expandStringArray(value.([]interface{})))
+		}),
 	}
 }
 
+var channelRoles = []string{
+	string(commercetools.ChannelRoleEnumInventorySupply),
+	string(commercetools.ChannelRoleEnumProductDistribution),
+	string(commercetools.ChannelRoleEnumOrderExport),
+	string(commercetools.ChannelRoleEnumOrderImport),
+	string(commercetools.ChannelRoleEnumPrimary),
+}
+
+// validateChannelRoles checks the combination of roles, a role can only be
+// given once and the Primary role marks the primary inventory supply channel
+func validateChannelRoles(roles []string) error {
+	seen := map[string]bool{}
+	for _, role := range roles {
+		if seen[role] {
+			return fmt.Errorf("the role %s is given more than once", role)
+		}
+		seen[role] = true
+	}
+	if seen[string(commercetools.ChannelRoleEnumPrimary)] && !seen[string(commercetools.ChannelRoleEnumInventorySupply)] {
+		return fmt.Errorf(
+			"the role %s can only be used together with the role %s",
+			commercetools.ChannelRoleEnumPrimary, commercetools.ChannelRoleEnumInventorySupply)
+	}
+	return nil
+}
+
 func resourceChannelCreate(d *schema.ResourceData, m interface{}) error {
 	name := commercetools.LocalizedString(
 		expandStringMap(d.Get("name").(map[string]interface{})))
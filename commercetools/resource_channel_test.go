@@ -0,0 +1,18 @@
+package commercetools
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateChannelRoles(t *testing.T) {
+	assert.NoError(t, validateChannelRoles([]string{"InventorySupply", "ProductDistribution"}))
+	assert.NoError(t, validateChannelRoles([]string{"InventorySupply", "Primary"}))
+	assert.EqualError(t,
+		validateChannelRoles([]string{"ProductDistribution", "Primary"}),
+		"the role Primary can only be used together with the role InventorySupply")
+	assert.EqualError(t,
+		validateChannelRoles([]string{"OrderExport", "OrderExport"}),
+		"the role OrderExport is given more than once")
+}
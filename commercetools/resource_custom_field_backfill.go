@@ -0,0 +1,245 @@
+package commercetools
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/labd/commercetools-go-sdk/commercetools"
+)
+
+const (
+	backfillResourceOrder    = "order"
+	backfillResourceCustomer = "customer"
+)
+
+// backfillObject is an order or customer which is missing the custom field
+type backfillObject struct {
+	ID      string
+	Version int
+}
+
+// backfillTarget fetches the objects which are missing the field and sets the
+// field on a single object
+type backfillTarget interface {
+	query(input *commercetools.QueryInput) ([]backfillObject, error)
+	setField(object backfillObject, name string, value interface{}) error
+}
+
+// resourceCustomFieldBackfill sets a default value for a custom field on all
+// existing orders or customers which use the type but don't have a value for
+// the field yet. This allows adding a new field to a type while application
+// code can assume the field is always set.
+//
+// The backfill runs once when the resource is created (or recreated when one
+// of the arguments changes). Objects are updated in batches and the number of
+// requests per second is limited to not interfere with other API clients.
+func resourceCustomFieldBackfill() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceCustomFieldBackfillCreate,
+		Read:   resourceCustomFieldBackfillRead,
+		Delete: resourceCustomFieldBackfillDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"resource_type_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					backfillResourceOrder,
+					backfillResourceCustomer,
+				}, false),
+			},
+			"type_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"field_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"value": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The value to set, decoded as JSON when possible",
+			},
+			"batch_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      100,
+				ValidateFunc: validation.IntBetween(1, 500),
+			},
+			"requests_per_second": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      10,
+				ValidateFunc: validation.IntBetween(1, 100),
+			},
+			"updated_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceCustomFieldBackfillCreate(d *schema.ResourceData, m interface{}) error {
+	client := getClient(m)
+	resourceTypeID := d.Get("resource_type_id").(string)
+	typeID := d.Get("type_id").(string)
+	fieldName := d.Get("field_name").(string)
+	customType, err := client.TypeGetWithID(context.Background(), typeID)
+	if err != nil {
+		return err
+	}
+	value := _decodeCustomFieldValue(d.Get("value").(string), _customFieldsStringFields(customType)[fieldName])
+
+	var target backfillTarget
+	switch resourceTypeID {
+	case backfillResourceOrder:
+		target = &orderBackfillTarget{client: client}
+	case backfillResourceCustomer:
+		target = &customerBackfillTarget{client: client}
+	}
+
+	input := &commercetools.QueryInput{
+		Where: backfillPredicate(typeID, fieldName),
+		Sort:  []string{"id asc"},
+		Limit: d.Get("batch_size").(int),
+	}
+	interval := time.Second / time.Duration(d.Get("requests_per_second").(int))
+
+	updated, err := runBackfill(
+		target, input, fieldName, value, interval, time.Now().Add(d.Timeout(schema.TimeoutCreate)))
+	log.Printf("[DEBUG] Backfilled custom field %s on %d %s objects", fieldName, updated, resourceTypeID)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s:%s:%s", resourceTypeID, typeID, fieldName))
+	d.Set("updated_count", updated)
+	return resourceCustomFieldBackfillRead(d, m)
+}
+
+// The backfill is a one-off operation, there is nothing to read back
+func resourceCustomFieldBackfillRead(d *schema.ResourceData, m interface{}) error {
+	return nil
+}
+
+// Deleting the backfill only removes it from the state, the values which are
+// set are kept.
+func resourceCustomFieldBackfillDelete(d *schema.ResourceData, m interface{}) error {
+	d.SetId("")
+	return nil
+}
+
+// backfillPredicate matches all objects using the type which don't have a
+// value for the field
+func backfillPredicate(typeID string, fieldName string) string {
+	return fmt.Sprintf(
+		"custom(type(id = %q)) and custom(fields(%s is not defined))", typeID, fieldName)
+}
+
+// runBackfill sets the field on all objects matching the query, a batch at a
+// time. Updated objects no longer match the query so the first page is
+// fetched until it is empty. Objects which could not be updated (for example
+// due to a concurrent modification) are retried in the next batch; when a
+// batch doesn't update any object the backfill is aborted.
+func runBackfill(target backfillTarget, input *commercetools.QueryInput, name string, value interface{}, interval time.Duration, deadline time.Time) (int, error) {
+	updated := 0
+	for {
+		objects, err := target.query(input)
+		if err != nil {
+			return updated, err
+		}
+		if len(objects) == 0 {
+			return updated, nil
+		}
+
+		var lastErr error
+		batchUpdated := 0
+		for _, object := range objects {
+			if time.Now().After(deadline) {
+				return updated, fmt.Errorf("timeout while backfilling custom field %s, %d objects updated", name, updated)
+			}
+
+			start := time.Now()
+			if err := target.setField(object, name, value); err != nil {
+				log.Printf("[DEBUG] Failed to backfill custom field %s on %s: %s", name, object.ID, err)
+				lastErr = err
+			} else {
+				batchUpdated++
+			}
+			time.Sleep(interval - time.Since(start))
+		}
+
+		updated += batchUpdated
+		if batchUpdated == 0 {
+			return updated, fmt.Errorf("failed to backfill custom field %s: %s", name, lastErr)
+		}
+	}
+}
+
+type orderBackfillTarget struct {
+	client *commercetools.Client
+}
+
+func (t *orderBackfillTarget) query(input *commercetools.QueryInput) ([]backfillObject, error) {
+	result, err := t.client.OrderQuery(context.Background(), input)
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]backfillObject, len(result.Results))
+	for i, order := range result.Results {
+		objects[i] = backfillObject{ID: order.ID, Version: order.Version}
+	}
+	return objects, nil
+}
+
+func (t *orderBackfillTarget) setField(object backfillObject, name string, value interface{}) error {
+	_, err := t.client.OrderUpdateWithID(context.Background(), &commercetools.OrderUpdateWithIDInput{
+		ID:      object.ID,
+		Version: object.Version,
+		Actions: []commercetools.OrderUpdateAction{
+			&commercetools.OrderSetCustomFieldAction{Name: name, Value: value},
+		},
+	})
+	return err
+}
+
+type customerBackfillTarget struct {
+	client *commercetools.Client
+}
+
+func (t *customerBackfillTarget) query(input *commercetools.QueryInput) ([]backfillObject, error) {
+	result, err := t.client.CustomerQuery(context.Background(), input)
+	if err != nil {
+		return nil, err
+	}
+	objects := make([]backfillObject, len(result.Results))
+	for i, customer := range result.Results {
+		objects[i] = backfillObject{ID: customer.ID, Version: customer.Version}
+	}
+	return objects, nil
+}
+
+func (t *customerBackfillTarget) setField(object backfillObject, name string, value interface{}) error {
+	_, err := t.client.CustomerUpdateWithID(context.Background(), &commercetools.CustomerUpdateWithIDInput{
+		ID:      object.ID,
+		Version: object.Version,
+		Actions: []commercetools.CustomerUpdateAction{
+			&commercetools.CustomerSetCustomFieldAction{Name: name, Value: value},
+		},
+	})
+	return err
+}
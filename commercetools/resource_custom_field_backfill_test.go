@@ -0,0 +1,71 @@
+package commercetools
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/labd/commercetools-go-sdk/commercetools"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeBackfillTarget keeps the objects which are missing the field, setting
+// the field removes the object unless it is configured to fail
+type fakeBackfillTarget struct {
+	missing []backfillObject
+	failing map[string]bool
+	values  map[string]interface{}
+}
+
+func (t *fakeBackfillTarget) query(input *commercetools.QueryInput) ([]backfillObject, error) {
+	if len(t.missing) > input.Limit {
+		return t.missing[:input.Limit], nil
+	}
+	return t.missing, nil
+}
+
+func (t *fakeBackfillTarget) setField(object backfillObject, name string, value interface{}) error {
+	if t.failing[object.ID] {
+		return errors.New("concurrent modification")
+	}
+	t.values[object.ID] = value
+	for i, item := range t.missing {
+		if item.ID == object.ID {
+			t.missing = append(t.missing[:i], t.missing[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func TestRunBackfill(t *testing.T) {
+	target := &fakeBackfillTarget{
+		missing: []backfillObject{{ID: "1"}, {ID: "2"}, {ID: "3"}},
+		values:  map[string]interface{}{},
+	}
+	input := &commercetools.QueryInput{Limit: 2}
+
+	updated, err := runBackfill(target, input, "loyalty", true, 0, time.Now().Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, updated)
+	assert.Equal(t, map[string]interface{}{"1": true, "2": true, "3": true}, target.values)
+}
+
+func TestRunBackfillFailure(t *testing.T) {
+	target := &fakeBackfillTarget{
+		missing: []backfillObject{{ID: "1"}, {ID: "2"}},
+		failing: map[string]bool{"2": true},
+		values:  map[string]interface{}{},
+	}
+	input := &commercetools.QueryInput{Limit: 2}
+
+	updated, err := runBackfill(target, input, "loyalty", true, 0, time.Now().Add(time.Minute))
+	assert.EqualError(t, err, "failed to backfill custom field loyalty: concurrent modification")
+	assert.Equal(t, 1, updated)
+}
+
+func TestBackfillPredicate(t *testing.T) {
+	assert.Equal(t,
+		`custom(type(id = "type-id")) and custom(fields(loyalty is not defined))`,
+		backfillPredicate("type-id", "loyalty"))
+}
@@ -3,9 +3,13 @@ package commercetools
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"io/ioutil"
 	"log"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/labd/commercetools-go-sdk/commercetools"
 )
 
@@ -28,20 +32,85 @@ func resourceCustomObject() *schema.Resource {
 				Required: true,
 			},
 			"value": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: diffSuppressEquivalentJSON,
+			},
+			"encrypted": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Encrypt the value with the custom_object_encryption_key of the provider before storing it",
+			},
+			"json_schema": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.StringIsJSON,
+				DiffSuppressFunc: diffSuppressEquivalentJSON,
+				ConflictsWith:    []string{"json_schema_file"},
+				Description:      "A JSON schema the value is validated against during the plan",
+			},
+			"json_schema_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"json_schema"},
+				Description:   "The path of a file with a JSON schema the value is validated against during the plan",
 			},
 			"version": {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
 		},
+		CustomizeDiff: resourceCustomObjectValidateValue,
 	}
 }
 
+// resourceCustomObjectValidateValue validates the value against the JSON
+// schema, so a malformed value fails the plan instead of the application
+// reading the custom object
+func resourceCustomObjectValidateValue(d *schema.ResourceDiff, m interface{}) error {
+	for _, key := range []string{"value", "json_schema", "json_schema_file"} {
+		if !d.NewValueKnown(key) {
+			return nil
+		}
+	}
+
+	input := d.Get("json_schema").(string)
+	if path := d.Get("json_schema_file").(string); path != "" {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read the JSON schema: %s", err)
+		}
+		input = string(data)
+	}
+	if input == "" {
+		return nil
+	}
+	return validateCustomObjectValue(input, d.Get("value").(string))
+}
+
+func validateCustomObjectValue(schemaInput string, valueInput string) error {
+	jsonSchema, err := parseJSONSchema(schemaInput)
+	if err != nil {
+		return err
+	}
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(valueInput), &value); err != nil {
+		return fmt.Errorf("the value is not valid JSON: %s", err)
+	}
+	if errs := validateJSONSchema(jsonSchema, value, "#"); len(errs) > 0 {
+		return fmt.Errorf("the value doesn't match the JSON schema:\n%s", strings.Join(errs, "\n"))
+	}
+	return nil
+}
+
 func resourceCustomObjectCreate(d *schema.ResourceData, m interface{}) error {
 	client := getClient(m)
-	value := _decodeCustomObjectValue(d.Get("value").(string))
+	value, err := resourceCustomObjectValue(d, m)
+	if err != nil {
+		return err
+	}
 
 	draft := commercetools.CustomObjectDraft{
 		Container: d.Get("container").(string),
@@ -59,13 +128,56 @@ func resourceCustomObjectCreate(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
+// resourceCustomObjectRead only reads encrypted custom objects, which are
+// decrypted so changes of the value outside of Terraform are detected
 func resourceCustomObjectRead(d *schema.ResourceData, m interface{}) error {
+	if !d.Get("encrypted").(bool) {
+		return nil
+	}
+	client := getClient(m)
+	container := d.Get("container").(string)
+	key := d.Get("key").(string)
+
+	customObject, err := client.CustomObjectGetWithContainerAndKey(context.Background(), container, key)
+	if err != nil {
+		if ctErr, ok := err.(commercetools.ErrorResponse); ok {
+			if ctErr.StatusCode == 404 {
+				d.SetId("")
+				return nil
+			}
+		}
+		return err
+	}
+
+	data, err := json.Marshal(customObject.Value)
+	if err != nil {
+		return err
+	}
+	stored := &encryptedCustomObjectValue{}
+	if err := json.Unmarshal(data, stored); err != nil || stored.Encrypted == "" {
+		// The value isn't encrypted, so it is replaced during the next update
+		log.Printf("[WARN] The value of custom object %s/%s is not encrypted", container, key)
+		d.Set("value", string(data))
+		d.Set("version", customObject.Version)
+		return nil
+	}
+
+	value, err := decryptCustomObjectValue(
+		m.(*providerMeta).customObjectEncryptionKey, container, key, stored)
+	if err != nil {
+		return err
+	}
+	d.Set("value", value)
+	d.Set("version", customObject.Version)
 	return nil
 }
 
 func resourceCustomObjectUpdate(d *schema.ResourceData, m interface{}) error {
 	client := getClient(m)
-	value := _decodeCustomObjectValue(d.Get("value").(string))
+	value, err := resourceCustomObjectValue(d, m)
+	if err != nil {
+		return err
+	}
 	ctx := context.Background()
 
 	if d.HasChange("container") || d.HasChange("key") {
@@ -123,6 +235,18 @@ func resourceCustomObjectDelete(d *schema.ResourceData, m interface{}) error {
 	return nil
 }
 
+// resourceCustomObjectValue returns the value to store in commercetools, which
+// is encrypted when the encrypted attribute is set
+func resourceCustomObjectValue(d *schema.ResourceData, m interface{}) (interface{}, error) {
+	value := d.Get("value").(string)
+	if !d.Get("encrypted").(bool) {
+		return _decodeCustomObjectValue(value), nil
+	}
+	return encryptCustomObjectValue(
+		m.(*providerMeta).customObjectEncryptionKey,
+		d.Get("container").(string), d.Get("key").(string), value)
+}
+
 func _decodeCustomObjectValue(value string) interface{} {
 	data := make(map[string]interface{})
 	json.Unmarshal([]byte(value), &data)
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccCustomObjectCreate_basic(t *testing.T) {
@@ -166,3 +167,13 @@ func testAccCustomObjectNestedData() string {
 		})
 	  }`
 }
+
+func TestValidateCustomObjectValue(t *testing.T) {
+	jsonSchema := `{"type": "object", "required": ["url"], "properties": {"url": {"type": "string"}}}`
+
+	assert.NoError(t, validateCustomObjectValue(jsonSchema, `{"url": "https://example.com"}`))
+	assert.EqualError(t,
+		validateCustomObjectValue(jsonSchema, `{"url": 1}`),
+		"the value doesn't match the JSON schema:\n#/url: expected string, got integer")
+	assert.Error(t, validateCustomObjectValue(jsonSchema, `{`))
+}
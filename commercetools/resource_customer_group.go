@@ -32,6 +32,8 @@ func resourceCustomerGroup() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"custom": customFieldSchema(),
+			"labels": labelsSchema(),
 		},
 	}
 }
@@ -40,9 +42,23 @@ func resourceCustomerGroupCreate(d *schema.ResourceData, m interface{}) error {
 	client := getClient(m)
 	var customerGroup *commercetools.CustomerGroup
 
+	custom, err := expandCustomFields(d, client)
+	if err != nil {
+		return err
+	}
+	if custom != nil {
+		custom.Fields, err = expandLabelsFields(d, custom.Fields, true)
+	} else {
+		_, err = expandLabelsFields(d, nil, false)
+	}
+	if err != nil {
+		return err
+	}
+
 	draft := &commercetools.CustomerGroupDraft{
 		GroupName: d.Get("name").(string),
 		Key:       d.Get("key").(string),
+		Custom:    custom,
 	}
 
 	errorResponse := resource.Retry(1*time.Minute, func() *resource.RetryError {
@@ -75,7 +91,8 @@ func resourceCustomerGroupRead(d *schema.ResourceData, m interface{}) error {
 
 	client := getClient(m)
 
-	customerGroup, err := client.CustomerGroupGetWithID(context.Background(), d.Id())
+	customerGroup, err := client.CustomerGroupGetWithID(
+		context.Background(), d.Id(), customFieldsReadOption)
 
 	if err != nil {
 		if ctErr, ok := err.(commercetools.ErrorResponse); ok {
@@ -97,6 +114,8 @@ func resourceCustomerGroupRead(d *schema.ResourceData, m interface{}) error {
 		d.Set("version", customerGroup.Version)
 		d.Set("name", customerGroup.Name)
 		d.Set("key", customerGroup.Key)
+		d.Set("custom", flattenCustomFields(customerGroup.Custom))
+		d.Set("labels", flattenLabels(customerGroup.Custom))
 	}
 
 	return nil
@@ -129,6 +148,43 @@ func resourceCustomerGroupUpdate(d *schema.ResourceData, m interface{}) error {
 			&commercetools.CustomerGroupSetKeyAction{Key: newKey})
 	}
 
+	changes, err := resourceCustomFieldChanges(d, client)
+	if err != nil {
+		return err
+	}
+	if changes != nil {
+		if changes.TypeChanged {
+			// Setting the type replaces all fields, including the labels
+			fields, err := expandLabelsFields(d, changes.Fields, changes.Type != nil)
+			if err != nil {
+				return err
+			}
+			input.Actions = append(
+				input.Actions,
+				&commercetools.CustomerGroupSetCustomTypeAction{
+					Type:   changes.Type,
+					Fields: fields,
+				})
+		}
+		for name, value := range changes.Changed {
+			input.Actions = append(
+				input.Actions,
+				&commercetools.CustomerGroupSetCustomFieldAction{Name: name, Value: value})
+		}
+	}
+
+	if d.HasChange("labels") && (changes == nil || !changes.TypeChanged) {
+		if _, err := expandLabelsFields(d, nil, len(d.Get("custom").([]interface{})) > 0); err != nil {
+			return err
+		}
+		input.Actions = append(
+			input.Actions,
+			&commercetools.CustomerGroupSetCustomFieldAction{
+				Name:  labelsFieldName,
+				Value: labelsFieldValue(d),
+			})
+	}
+
 	log.Printf(
 		"[DEBUG] Will perform update operation with the following actions:\n%s",
 		stringFormatActions(input.Actions))
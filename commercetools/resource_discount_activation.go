@@ -0,0 +1,148 @@
+package commercetools
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/labd/commercetools-go-sdk/commercetools"
+)
+
+// resourceDiscountActivation manages only the is_active flag of an existing
+// cart discount or discount code. This allows activating discounts from a
+// separate workspace which only needs the manage_discount_codes /
+// manage_cart_discounts scopes.
+func resourceDiscountActivation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceDiscountActivationCreate,
+		Read:   resourceDiscountActivationRead,
+		Update: resourceDiscountActivationUpdate,
+		Delete: resourceDiscountActivationDelete,
+		Schema: map[string]*schema.Schema{
+			"cart_discount_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"cart_discount_id", "discount_code_id"},
+			},
+			"discount_code_id": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"cart_discount_id", "discount_code_id"},
+			},
+			"is_active": {
+				Type:     schema.TypeBool,
+				Required: true,
+			},
+		},
+	}
+}
+
+func resourceDiscountActivationCreate(d *schema.ResourceData, m interface{}) error {
+	if id, ok := d.GetOk("cart_discount_id"); ok {
+		d.SetId(id.(string))
+	} else {
+		d.SetId(d.Get("discount_code_id").(string))
+	}
+
+	err := resourceDiscountActivationSetIsActive(d, m)
+	if err != nil {
+		d.SetId("")
+		return err
+	}
+
+	return resourceDiscountActivationRead(d, m)
+}
+
+func resourceDiscountActivationRead(d *schema.ResourceData, m interface{}) error {
+	client := getClient(m)
+
+	var isActive bool
+	if _, ok := d.GetOk("cart_discount_id"); ok {
+		cartDiscount, err := client.CartDiscountGetWithID(context.Background(), d.Id())
+		if err != nil {
+			return resourceDiscountActivationHandleReadError(d, err)
+		}
+		isActive = cartDiscount.IsActive
+	} else {
+		discountCode, err := client.DiscountCodeGetWithID(context.Background(), d.Id())
+		if err != nil {
+			return resourceDiscountActivationHandleReadError(d, err)
+		}
+		isActive = discountCode.IsActive
+	}
+
+	log.Printf("[DEBUG] Discount %s has is_active set to %t", d.Id(), isActive)
+	d.Set("is_active", isActive)
+	return nil
+}
+
+func resourceDiscountActivationHandleReadError(d *schema.ResourceData, err error) error {
+	if ctErr, ok := err.(commercetools.ErrorResponse); ok {
+		if ctErr.StatusCode == 404 {
+			d.SetId("")
+			return nil
+		}
+	}
+	return err
+}
+
+func resourceDiscountActivationUpdate(d *schema.ResourceData, m interface{}) error {
+	if d.HasChange("is_active") {
+		if err := resourceDiscountActivationSetIsActive(d, m); err != nil {
+			return err
+		}
+	}
+	return resourceDiscountActivationRead(d, m)
+}
+
+// Deleting the activation only removes it from the state, the discount itself
+// keeps its current activation status.
+func resourceDiscountActivationDelete(d *schema.ResourceData, m interface{}) error {
+	d.SetId("")
+	return nil
+}
+
+func resourceDiscountActivationSetIsActive(d *schema.ResourceData, m interface{}) error {
+	client := getClient(m)
+	isActive := d.Get("is_active").(bool)
+
+	// Lock to prevent concurrent updates due to Version number conflicts
+	ctMutexKV.Lock(d.Id())
+	defer ctMutexKV.Unlock(d.Id())
+
+	if _, ok := d.GetOk("cart_discount_id"); ok {
+		cartDiscount, err := client.CartDiscountGetWithID(context.Background(), d.Id())
+		if err != nil {
+			return err
+		}
+		if cartDiscount.IsActive == isActive {
+			return nil
+		}
+		_, err = client.CartDiscountUpdateWithID(context.Background(), &commercetools.CartDiscountUpdateWithIDInput{
+			ID:      cartDiscount.ID,
+			Version: cartDiscount.Version,
+			Actions: []commercetools.CartDiscountUpdateAction{
+				&commercetools.CartDiscountChangeIsActiveAction{IsActive: isActive},
+			},
+		})
+		return err
+	}
+
+	discountCode, err := client.DiscountCodeGetWithID(context.Background(), d.Id())
+	if err != nil {
+		return err
+	}
+	if discountCode.IsActive == isActive {
+		return nil
+	}
+	_, err = client.DiscountCodeUpdateWithID(context.Background(), &commercetools.DiscountCodeUpdateWithIDInput{
+		ID:      discountCode.ID,
+		Version: discountCode.Version,
+		Actions: []commercetools.DiscountCodeUpdateAction{
+			&commercetools.DiscountCodeChangeIsActiveAction{IsActive: isActive},
+		},
+	})
+	return err
+}
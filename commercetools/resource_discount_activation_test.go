@@ -0,0 +1,70 @@
+package commercetools
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+)
+
+func TestAccDiscountActivation_cartDiscount(t *testing.T) {
+	rName := acctest.RandString(5)
+	sortOrder := acctest.RandIntRange(1000, 9999)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: nil,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDiscountActivationConfig(rName, sortOrder, true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"commercetools_discount_activation.activation", "is_active", "true",
+					),
+					resource.TestCheckResourceAttrPair(
+						"commercetools_discount_activation.activation", "id",
+						"commercetools_cart_discount.discount", "id",
+					),
+				),
+			},
+			{
+				Config: testAccDiscountActivationConfig(rName, sortOrder, false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"commercetools_discount_activation.activation", "is_active", "false",
+					),
+				),
+			},
+		},
+	})
+}
+
+func testAccDiscountActivationConfig(rName string, sortOrder int, isActive bool) string {
+	return fmt.Sprintf(`
+resource "commercetools_cart_discount" "discount" {
+	key = "activation-%[1]s"
+	name = {
+		en = "Activation test"
+	}
+	sort_order = "0.9%[2]d"
+	predicate  = "1=1"
+	is_active  = false
+
+	value {
+		type      = "relative"
+		permyriad = 1000
+	}
+
+	lifecycle {
+		ignore_changes = [is_active]
+	}
+}
+
+resource "commercetools_discount_activation" "activation" {
+	cart_discount_id = commercetools_cart_discount.discount.id
+	is_active        = %[3]t
+}
+`, rName, sortOrder, isActive)
+}
@@ -2,11 +2,15 @@ package commercetools
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/labd/commercetools-go-sdk/commercetools"
 )
 
@@ -19,6 +23,9 @@ func resourceDiscountCode() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     TypeLocalizedString,
@@ -33,12 +40,15 @@ func resourceDiscountCode() *schema.Resource {
 				Required: true,
 			},
 			"valid_from": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validateValidFrom,
+				DiffSuppressFunc: diffSuppressValidFrom,
 			},
 			"valid_until": {
-				Type:     schema.TypeString,
-				Optional: true,
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: diffSuppressEquivalentDate,
 			},
 			"is_active": {
 				Type:     schema.TypeBool,
@@ -50,12 +60,14 @@ func resourceDiscountCode() *schema.Resource {
 				Optional: true,
 			},
 			"max_applications_per_customer": {
-				Type:     schema.TypeInt,
-				Optional: true,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
 			},
 			"max_applications": {
-				Type:     schema.TypeInt,
-				Optional: true,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
 			},
 			"groups": {
 				Type:     schema.TypeList,
@@ -63,16 +75,206 @@ func resourceDiscountCode() *schema.Resource {
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
 			"cart_discounts": {
-				Type:     schema.TypeList,
-				Required: true,
-				Elem:     &schema.Schema{Type: schema.TypeString},
+				Type:         schema.TypeList,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				AtLeastOneOf: []string{"cart_discounts", "cart_discount_keys"},
+				Description:  "The ids of the cart discounts",
+			},
+			"cart_discount_keys": {
+				Type:         schema.TypeList,
+				Optional:     true,
+				Elem:         &schema.Schema{Type: schema.TypeString},
+				AtLeastOneOf: []string{"cart_discounts", "cart_discount_keys"},
+				Description:  "The keys of the cart discounts",
+			},
+			"cart_discount_refs": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The id and key of all cart discounts of the code",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"key": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
 			},
 			"version": {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
 		},
+		CustomizeDiff: resourceDiscountCodeResolveCartDiscounts,
+	}
+}
+
+// resourceDiscountCodePlanWarnings checks if the configured limits of the
+// discount code actually have an effect, and if the cart discounts referenced
+// by key exist. The warnings are only returned when these values change, so
+// they don't result in a plan on their own.
+func resourceDiscountCodePlanWarnings(d *schema.ResourceDiff, m interface{}) ([]string, error) {
+	if d.Id() != "" && !d.HasChange("max_applications") && !d.HasChange("max_applications_per_customer") &&
+		!d.HasChange("cart_discounts") && !d.HasChange("cart_discount_keys") {
+		return nil, nil
+	}
+
+	maxApplications := d.Get("max_applications").(int)
+	maxApplicationsPerCustomer := d.Get("max_applications_per_customer").(int)
+	warnings := validateDiscountCodeMaxApplications(maxApplications, maxApplicationsPerCustomer)
+	if m == nil {
+		return warnings, nil
+	}
+	client := getClient(m)
+
+	if d.NewValueKnown("cart_discount_keys") && (d.Id() == "" || d.HasChange("cart_discount_keys")) {
+		_, missingKeys, err := resolveDiscountCodeCartDiscounts(
+			client, nil, expandStringArray(d.Get("cart_discount_keys").([]interface{})))
+		if err != nil {
+			return nil, err
+		}
+		if len(missingKeys) > 0 {
+			warnings = append(warnings, fmt.Sprintf(
+				"the cart discounts with key %s don't exist yet", strings.Join(missingKeys, ", ")))
+		}
+	}
+
+	// The ids of the cart discounts are not known yet when they are created
+	// in the same plan.
+	if (maxApplications == 0 && maxApplicationsPerCustomer == 0) || !d.NewValueKnown("cart_discounts") {
+		return warnings, nil
+	}
+
+	cartDiscounts := []*commercetools.CartDiscount{}
+	for _, id := range expandStringArray(d.Get("cart_discounts").([]interface{})) {
+		cartDiscount, err := client.CartDiscountGetWithID(context.Background(), id)
+		if err != nil {
+			log.Printf("[DEBUG] Unable to fetch cart discount %s: %s", id, err)
+			continue
+		}
+		cartDiscounts = append(cartDiscounts, cartDiscount)
 	}
+	return append(warnings, validateDiscountCodeCartDiscounts(cartDiscounts)...), nil
+}
+
+// resourceDiscountCodeResolveCartDiscounts looks up the cart discounts during
+// the plan, so a reference to a cart discount which doesn't exist fails the
+// plan instead of the apply. A cart discount with a key can be created in the
+// same apply, so missing keys are resolved during the apply.
+func resourceDiscountCodeResolveCartDiscounts(d *schema.ResourceDiff, m interface{}) error {
+	if m == nil {
+		return nil
+	}
+	if !d.NewValueKnown("cart_discounts") || !d.NewValueKnown("cart_discount_keys") {
+		return d.SetNewComputed("cart_discount_refs")
+	}
+	if d.Id() != "" && !d.HasChange("cart_discounts") && !d.HasChange("cart_discount_keys") {
+		return nil
+	}
+
+	refs, missingKeys, err := resolveDiscountCodeCartDiscounts(
+		getClient(m),
+		expandStringArray(d.Get("cart_discounts").([]interface{})),
+		expandStringArray(d.Get("cart_discount_keys").([]interface{})))
+	if err != nil {
+		return err
+	}
+	if len(missingKeys) > 0 {
+		return d.SetNewComputed("cart_discount_refs")
+	}
+	return d.SetNew("cart_discount_refs", refs)
+}
+
+// resolveDiscountCodeCartDiscounts returns the id and key of the cart
+// discounts, together with the keys which don't exist. Ids which don't exist
+// are an error.
+func resolveDiscountCodeCartDiscounts(client *commercetools.Client, ids []string, keys []string) ([]map[string]interface{}, []string, error) {
+	refs := []map[string]interface{}{}
+	for _, id := range ids {
+		cartDiscount, err := client.CartDiscountGetWithID(context.Background(), id)
+		if err != nil {
+			if ctErr, ok := err.(commercetools.ErrorResponse); ok && ctErr.StatusCode == 404 {
+				return nil, nil, fmt.Errorf("cart discount %s does not exist", id)
+			}
+			return nil, nil, err
+		}
+		refs = append(refs, map[string]interface{}{"id": cartDiscount.ID, "key": cartDiscount.Key})
+	}
+
+	missingKeys := []string{}
+	for _, key := range keys {
+		cartDiscount, err := client.CartDiscountGetWithKey(context.Background(), key)
+		if err != nil {
+			if ctErr, ok := err.(commercetools.ErrorResponse); ok && ctErr.StatusCode == 404 {
+				missingKeys = append(missingKeys, key)
+				continue
+			}
+			return nil, nil, err
+		}
+		refs = append(refs, map[string]interface{}{"id": cartDiscount.ID, "key": cartDiscount.Key})
+	}
+	return refs, missingKeys, nil
+}
+
+func validateDiscountCodeMaxApplications(maxApplications int, maxApplicationsPerCustomer int) []string {
+	warnings := []string{}
+	if maxApplications > 0 && maxApplicationsPerCustomer > maxApplications {
+		warnings = append(warnings, fmt.Sprintf(
+			"max_applications_per_customer (%d) is higher than max_applications (%d) and will never be reached",
+			maxApplicationsPerCustomer, maxApplications))
+	}
+	return warnings
+}
+
+// validateDiscountCodeCartDiscounts returns warnings for the cart discounts of
+// a code with application limits. A cart discount with the stacking mode
+// StopAfterThisDiscount prevents the cart discounts of the code with a lower
+// sort order from being applied, while the application of the code is still
+// counted, so the limits don't limit these discounts. The same applies to a
+// cart discount which is also applied without a discount code.
+func validateDiscountCodeCartDiscounts(cartDiscounts []*commercetools.CartDiscount) []string {
+	warnings := []string{}
+	for _, cartDiscount := range cartDiscounts {
+		if !cartDiscount.RequiresDiscountCode {
+			warnings = append(warnings, fmt.Sprintf(
+				"cart discount %s does not require a discount code, so the application limits of this code do not limit the discount",
+				cartDiscount.ID))
+		}
+		if cartDiscount.StackingMode != commercetools.StackingModeStopAfterThisDiscount {
+			continue
+		}
+		for _, other := range cartDiscounts {
+			if other.ID != cartDiscount.ID && compareSortOrder(other.SortOrder, cartDiscount.SortOrder) < 0 {
+				warnings = append(warnings, fmt.Sprintf(
+					"cart discount %s has stacking mode %s and a higher sort order than cart discount %s, "+
+						"which is therefore never applied while the application limits of this code are still used",
+					cartDiscount.ID, cartDiscount.StackingMode, other.ID))
+			}
+		}
+	}
+	return warnings
+}
+
+// compareSortOrder compares two sort orders of cart discounts, which are
+// decimals between 0 and 1 encoded as strings
+func compareSortOrder(a string, b string) int {
+	x, errA := strconv.ParseFloat(a, 64)
+	y, errB := strconv.ParseFloat(b, 64)
+	if errA != nil || errB != nil {
+		return strings.Compare(a, b)
+	}
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	}
+	return 0
 }
 
 func resourceDiscountCodeCreate(d *schema.ResourceData, m interface{}) error {
@@ -97,7 +299,7 @@ func resourceDiscountCodeCreate(d *schema.ResourceData, m interface{}) error {
 	}
 
 	if val := d.Get("valid_from").(string); len(val) > 0 {
-		validFrom, err := expandDate(val)
+		validFrom, err := expandValidFrom(val)
 		if err != nil {
 			return err
 		}
@@ -141,7 +343,8 @@ func resourceDiscountCodeRead(d *schema.ResourceData, m interface{}) error {
 
 	client := getClient(m)
 
-	discountCode, err := client.DiscountCodeGetWithID(context.Background(), d.Id())
+	discountCode, err := client.DiscountCodeGetWithID(
+		context.Background(), d.Id(), commercetools.WithReferenceExpansion("cartDiscounts[*]"))
 
 	if err != nil {
 		if ctErr, ok := err.(commercetools.ErrorResponse); ok {
@@ -165,11 +368,16 @@ func resourceDiscountCodeRead(d *schema.ResourceData, m interface{}) error {
 		d.Set("name", discountCode.Name)
 		d.Set("description", discountCode.Description)
 		d.Set("predicate", discountCode.CartPredicate)
-		d.Set("cart_discounts", discountCode.CartDiscounts)
+		ids, keys, refs := flattenDiscountCodeCartDiscounts(
+			discountCode.CartDiscounts,
+			expandStringArray(d.Get("cart_discount_keys").([]interface{})))
+		d.Set("cart_discounts", ids)
+		d.Set("cart_discount_keys", keys)
+		d.Set("cart_discount_refs", refs)
 		d.Set("groups", discountCode.Groups)
 		d.Set("is_active", discountCode.IsActive)
-		d.Set("valid_from", discountCode.ValidFrom)
-		d.Set("valid_until", discountCode.ValidUntil)
+		d.Set("valid_from", flattenDate(discountCode.ValidFrom))
+		d.Set("valid_until", flattenDate(discountCode.ValidUntil))
 		d.Set("max_applications_per_customer", discountCode.MaxApplicationsPerCustomer)
 		d.Set("max_applications", discountCode.MaxApplications)
 	}
@@ -184,88 +392,113 @@ func resourceDiscountCodeUpdate(d *schema.ResourceData, m interface{}) error {
 		return err
 	}
 
+	actions, err := resourceDiscountCodeUpdateActions(d)
+	if err != nil {
+		return err
+	}
+
 	input := &commercetools.DiscountCodeUpdateWithIDInput{
 		ID:      d.Id(),
 		Version: discountCode.Version,
-		Actions: []commercetools.DiscountCodeUpdateAction{},
+		Actions: actions,
 	}
 
+	log.Printf(
+		"[DEBUG] Will perform update operation with the following actions:\n%s",
+		stringFormatActions(input.Actions))
+
+	_, err = client.DiscountCodeUpdateWithID(context.Background(), input)
+	if err != nil {
+		if ctErr, ok := err.(commercetools.ErrorResponse); ok {
+			log.Printf("[DEBUG] %v: %v", ctErr, stringFormatErrorExtras(ctErr))
+		}
+		return err
+	}
+
+	return resourceDiscountCodeRead(d, m)
+}
+
+// resourceDiscountCodeUpdateActions returns the update actions for the changes,
+// both when applying the changes and for the planned_actions during the plan
+func resourceDiscountCodeUpdateActions(d resourceChange) ([]commercetools.DiscountCodeUpdateAction, error) {
+	actions := []commercetools.DiscountCodeUpdateAction{}
+
 	if d.HasChange("name") {
 		newName := commercetools.LocalizedString(
 			expandStringMap(d.Get("name").(map[string]interface{})))
-		input.Actions = append(
-			input.Actions,
+		actions = append(
+			actions,
 			&commercetools.DiscountCodeSetNameAction{Name: &newName})
 	}
 
 	if d.HasChange("description") {
 		newDescription := commercetools.LocalizedString(
 			expandStringMap(d.Get("description").(map[string]interface{})))
-		input.Actions = append(
-			input.Actions,
+		actions = append(
+			actions,
 			&commercetools.DiscountCodeSetDescriptionAction{Description: &newDescription})
 	}
 
 	if d.HasChange("predicate") {
 		newPredicate := d.Get("predicate").(string)
-		input.Actions = append(
-			input.Actions,
+		actions = append(
+			actions,
 			&commercetools.DiscountCodeSetCartPredicateAction{CartPredicate: newPredicate})
 	}
 
 	if d.HasChange("max_applications") {
 		newMaxApplications := d.Get("max_applications").(int)
-		input.Actions = append(
-			input.Actions,
+		actions = append(
+			actions,
 			&commercetools.DiscountCodeSetMaxApplicationsAction{MaxApplications: newMaxApplications})
 	}
 
 	if d.HasChange("max_applications_per_customer") {
 		newMaxApplications := d.Get("max_applications_per_customer").(int)
-		input.Actions = append(
-			input.Actions,
+		actions = append(
+			actions,
 			&commercetools.DiscountCodeSetMaxApplicationsPerCustomerAction{MaxApplicationsPerCustomer: newMaxApplications})
 	}
 
-	if d.HasChange("cart_discounts") {
+	if d.HasChange("cart_discounts") || d.HasChange("cart_discount_keys") {
 		newCartDiscounts := resourceDiscountCodeGetCartDiscounts(d)
-		input.Actions = append(
-			input.Actions,
+		actions = append(
+			actions,
 			&commercetools.DiscountCodeChangeCartDiscountsAction{CartDiscounts: newCartDiscounts})
 	}
 
 	if d.HasChange("groups") {
 		newGroups := resourceDiscountCodeGetGroups(d)
 		if len(newGroups) > 0 {
-			input.Actions = append(
-				input.Actions,
+			actions = append(
+				actions,
 				&commercetools.DiscountCodeChangeGroupsAction{Groups: newGroups})
 		} else {
-			input.Actions = append(
-				input.Actions,
+			actions = append(
+				actions,
 				&commercetools.DiscountCodeChangeGroupsAction{Groups: []string{}})
 		}
 	}
 
 	if d.HasChange("is_active") {
 		newIsActive := d.Get("is_active").(bool)
-		input.Actions = append(
-			input.Actions,
+		actions = append(
+			actions,
 			&commercetools.DiscountCodeChangeIsActiveAction{IsActive: newIsActive})
 	}
 
 	if d.HasChange("valid_from") {
 		if val := d.Get("valid_from").(string); len(val) > 0 {
-			newValidFrom, err := expandDate(d.Get("valid_from").(string))
+			newValidFrom, err := expandValidFrom(val)
 			if err != nil {
-				return err
+				return nil, err
 			}
-			input.Actions = append(
-				input.Actions,
+			actions = append(
+				actions,
 				&commercetools.DiscountCodeSetValidFromAction{ValidFrom: &newValidFrom})
 		} else {
-			input.Actions = append(
-				input.Actions,
+			actions = append(
+				actions,
 				&commercetools.DiscountCodeSetValidFromAction{})
 		}
 	}
@@ -274,45 +507,27 @@ func resourceDiscountCodeUpdate(d *schema.ResourceData, m interface{}) error {
 		if val := d.Get("valid_until").(string); len(val) > 0 {
 			newValidUntil, err := expandDate(d.Get("valid_until").(string))
 			if err != nil {
-				return err
+				return nil, err
 			}
-			input.Actions = append(
-				input.Actions,
+			actions = append(
+				actions,
 				&commercetools.DiscountCodeSetValidUntilAction{ValidUntil: &newValidUntil})
 		} else {
-			input.Actions = append(
-				input.Actions,
+			actions = append(
+				actions,
 				&commercetools.DiscountCodeSetValidUntilAction{})
 		}
 	}
 
-	log.Printf(
-		"[DEBUG] Will perform update operation with the following actions:\n%s",
-		stringFormatActions(input.Actions))
-
-	_, err = client.DiscountCodeUpdateWithID(context.Background(), input)
-	if err != nil {
-		if ctErr, ok := err.(commercetools.ErrorResponse); ok {
-			log.Printf("[DEBUG] %v: %v", ctErr, stringFormatErrorExtras(ctErr))
-		}
-		return err
-	}
-
-	return resourceDiscountCodeRead(d, m)
+	return actions, nil
 }
 
 func resourceDiscountCodeDelete(d *schema.ResourceData, m interface{}) error {
-	client := getClient(m)
-	version := d.Get("version").(int)
-	_, err := client.DiscountCodeDeleteWithID(context.Background(), d.Id(), version, false)
-	if err != nil {
-		log.Printf("[ERROR] Error during deleting discount code resource %s", err)
-		return nil
-	}
-	return nil
+	deleter := m.(*providerMeta).discountCodeDeleter
+	return deleter.delete(getClient(m), d.Id(), d.Get("version").(int), d.Timeout(schema.TimeoutDelete))
 }
 
-func resourceDiscountCodeGetGroups(d *schema.ResourceData) []string {
+func resourceDiscountCodeGetGroups(d resourceChange) []string {
 	var groups []string
 	for _, group := range expandStringArray(d.Get("groups").([]interface{})) {
 		groups = append(groups, group)
@@ -320,10 +535,36 @@ func resourceDiscountCodeGetGroups(d *schema.ResourceData) []string {
 	return groups
 }
 
-func resourceDiscountCodeGetCartDiscounts(d *schema.ResourceData) []commercetools.CartDiscountResourceIdentifier {
+func resourceDiscountCodeGetCartDiscounts(d resourceChange) []commercetools.CartDiscountResourceIdentifier {
 	var cartDiscounts []commercetools.CartDiscountResourceIdentifier
 	for _, cartDiscount := range expandStringArray(d.Get("cart_discounts").([]interface{})) {
 		cartDiscounts = append(cartDiscounts, commercetools.CartDiscountResourceIdentifier{ID: cartDiscount})
 	}
+	for _, key := range expandStringArray(d.Get("cart_discount_keys").([]interface{})) {
+		cartDiscounts = append(cartDiscounts, commercetools.CartDiscountResourceIdentifier{Key: key})
+	}
 	return cartDiscounts
 }
+
+// flattenDiscountCodeCartDiscounts splits the (expanded) cart discounts of the
+// code into the ids and the keys, cart discounts which are configured by key
+// are kept in cart_discount_keys.
+func flattenDiscountCodeCartDiscounts(references []commercetools.CartDiscountReference, configuredKeys []string) ([]string, []string, []map[string]interface{}) {
+	ids := []string{}
+	keys := []string{}
+	refs := make([]map[string]interface{}, len(references))
+	for i, reference := range references {
+		key := ""
+		if reference.Obj != nil {
+			key = reference.Obj.Key
+		}
+		refs[i] = map[string]interface{}{"id": reference.ID, "key": key}
+
+		if key != "" && stringInSlice(key, configuredKeys) {
+			keys = append(keys, key)
+		} else {
+			ids = append(ids, reference.ID)
+		}
+	}
+	return ids, keys, refs
+}
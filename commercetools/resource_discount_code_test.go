@@ -1,9 +1,13 @@
 package commercetools
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/labd/commercetools-go-sdk/commercetools"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccDiscountCodeCreate_basic(t *testing.T) {
@@ -315,3 +319,89 @@ func testAccDiscountCodeRemoveProperties() string {
 		cart_discounts = [commercetools_cart_discount.standard.id]
 	  }  `
 }
+
+func TestValidateDiscountCodeMaxApplications(t *testing.T) {
+	assert.Empty(t, validateDiscountCodeMaxApplications(0, 0))
+	assert.Empty(t, validateDiscountCodeMaxApplications(0, 5))
+	assert.Empty(t, validateDiscountCodeMaxApplications(10, 5))
+	assert.Len(t, validateDiscountCodeMaxApplications(10, 50), 1)
+}
+
+func TestValidateDiscountCodeCartDiscounts(t *testing.T) {
+	assert.Empty(t, validateDiscountCodeCartDiscounts([]*commercetools.CartDiscount{
+		{ID: "discount", RequiresDiscountCode: true, StackingMode: commercetools.StackingModeStacking},
+	}))
+	assert.Len(t, validateDiscountCodeCartDiscounts([]*commercetools.CartDiscount{
+		{ID: "discount", RequiresDiscountCode: false, StackingMode: commercetools.StackingModeStacking},
+	}), 1)
+
+	// The cart discount with the lower sort order is never applied
+	warnings := validateDiscountCodeCartDiscounts([]*commercetools.CartDiscount{
+		{ID: "first", SortOrder: "0.9", RequiresDiscountCode: true, StackingMode: commercetools.StackingModeStopAfterThisDiscount},
+		{ID: "second", SortOrder: "0.85", RequiresDiscountCode: true, StackingMode: commercetools.StackingModeStacking},
+	})
+	assert.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "StopAfterThisDiscount")
+
+	// Cart discounts with a higher sort order are applied before it
+	assert.Empty(t, validateDiscountCodeCartDiscounts([]*commercetools.CartDiscount{
+		{ID: "first", SortOrder: "0.1", RequiresDiscountCode: true, StackingMode: commercetools.StackingModeStopAfterThisDiscount},
+		{ID: "second", SortOrder: "0.85", RequiresDiscountCode: true, StackingMode: commercetools.StackingModeStacking},
+	}))
+}
+
+func TestCompareSortOrder(t *testing.T) {
+	assert.Equal(t, -1, compareSortOrder("0.85", "0.9"))
+	assert.Equal(t, 1, compareSortOrder("0.9", "0.1"))
+	assert.Equal(t, 0, compareSortOrder("0.5", "0.50"))
+}
+
+func TestResolveDiscountCodeCartDiscounts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/my-project/cart-discounts/1234":
+			w.Write([]byte(`{"id": "1234", "key": "summer"}`))
+		case "/my-project/cart-discounts/key=winter":
+			w.Write([]byte(`{"id": "5678", "key": "winter"}`))
+		default:
+			w.WriteHeader(404)
+			w.Write([]byte(`{"statusCode": 404, "message": "not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := commercetools.New(&commercetools.Config{
+		ProjectKey: "my-project",
+		URL:        server.URL,
+		HTTPClient: server.Client(),
+	})
+
+	refs, missingKeys, err := resolveDiscountCodeCartDiscounts(
+		client, []string{"1234"}, []string{"winter", "spring"})
+	assert.NoError(t, err)
+	assert.Equal(t, []map[string]interface{}{
+		{"id": "1234", "key": "summer"},
+		{"id": "5678", "key": "winter"},
+	}, refs)
+	assert.Equal(t, []string{"spring"}, missingKeys)
+
+	_, _, err = resolveDiscountCodeCartDiscounts(client, []string{"unknown"}, nil)
+	assert.EqualError(t, err, "cart discount unknown does not exist")
+}
+
+func TestFlattenDiscountCodeCartDiscounts(t *testing.T) {
+	ids, keys, refs := flattenDiscountCodeCartDiscounts([]commercetools.CartDiscountReference{
+		{ID: "1234", Obj: &commercetools.CartDiscount{Key: "summer"}},
+		{ID: "5678", Obj: &commercetools.CartDiscount{Key: "winter"}},
+		{ID: "9012"},
+	}, []string{"winter"})
+
+	assert.Equal(t, []string{"1234", "9012"}, ids)
+	assert.Equal(t, []string{"winter"}, keys)
+	assert.Equal(t, []map[string]interface{}{
+		{"id": "1234", "key": "summer"},
+		{"id": "5678", "key": "winter"},
+		{"id": "9012", "key": ""},
+	}, refs)
+}
@@ -0,0 +1,501 @@
+package commercetools
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/labd/commercetools-go-sdk/commercetools"
+)
+
+func resourceProductDiscount() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceProductDiscountCreate,
+		Read:   resourceProductDiscountRead,
+		Update: resourceProductDiscountUpdate,
+		Delete: resourceProductDiscountDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Delete: schema.DefaultTimeout(5 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"name": {
+				Type:     TypeLocalizedString,
+				Required: true,
+			},
+			"description": {
+				Type:     TypeLocalizedString,
+				Optional: true,
+			},
+			"value": {
+				Type:     schema.TypeList,
+				MaxItems: 1,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"relative", "absolute", "external"}, false),
+						},
+						// Relative discount specific fields
+						"permyriad": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						// Absolute discount specific fields
+						"money": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"currency_code": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: ValidateCurrencyCode,
+									},
+									"cent_amount": {
+										Type:     schema.TypeInt,
+										Required: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			"predicate": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"sort_order": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"is_active": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"valid_from": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validateValidFrom,
+				DiffSuppressFunc: diffSuppressValidFrom,
+			},
+			"valid_until": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				DiffSuppressFunc: diffSuppressEquivalentDate,
+			},
+			"clear_discounted_prices_on_delete": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Clear the discounted prices of products which reference the discount before deleting it",
+			},
+			"version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceProductDiscountCreate(d *schema.ResourceData, m interface{}) error {
+	client := getClient(m)
+
+	name := commercetools.LocalizedString(
+		expandStringMap(d.Get("name").(map[string]interface{})))
+	description := commercetools.LocalizedString(
+		expandStringMap(d.Get("description").(map[string]interface{})))
+
+	value, err := resourceProductDiscountGetValue(d)
+	if err != nil {
+		return err
+	}
+
+	draft := &commercetools.ProductDiscountDraft{
+		Key:         d.Get("key").(string),
+		Name:        &name,
+		Description: &description,
+		Value:       value,
+		Predicate:   d.Get("predicate").(string),
+		SortOrder:   d.Get("sort_order").(string),
+		IsActive:    d.Get("is_active").(bool),
+	}
+
+	if val := d.Get("valid_from").(string); len(val) > 0 {
+		validFrom, err := expandValidFrom(val)
+		if err != nil {
+			return err
+		}
+		draft.ValidFrom = &validFrom
+	}
+	if val := d.Get("valid_until").(string); len(val) > 0 {
+		validUntil, err := expandDate(val)
+		if err != nil {
+			return err
+		}
+		draft.ValidUntil = &validUntil
+	}
+
+	var productDiscount *commercetools.ProductDiscount
+	err = resource.Retry(1*time.Minute, func() *resource.RetryError {
+		var err error
+		productDiscount, err = client.ProductDiscountCreate(context.Background(), draft)
+		if err != nil {
+			return handleCommercetoolsError(err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	d.SetId(productDiscount.ID)
+	d.Set("version", productDiscount.Version)
+	return resourceProductDiscountRead(d, m)
+}
+
+func resourceProductDiscountRead(d *schema.ResourceData, m interface{}) error {
+	log.Printf("[DEBUG] Reading product discount from commercetools, with id: %s", d.Id())
+	client := getClient(m)
+
+	productDiscount, err := client.ProductDiscountGetWithID(context.Background(), d.Id())
+	if err != nil {
+		if ctErr, ok := err.(commercetools.ErrorResponse); ok && ctErr.StatusCode == 404 {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	d.Set("version", productDiscount.Version)
+	d.Set("key", productDiscount.Key)
+	d.Set("name", productDiscount.Name)
+	d.Set("description", productDiscount.Description)
+	d.Set("value", flattenProductDiscountValue(productDiscount.Value))
+	d.Set("predicate", productDiscount.Predicate)
+	d.Set("sort_order", productDiscount.SortOrder)
+	d.Set("is_active", productDiscount.IsActive)
+	d.Set("valid_from", flattenDate(productDiscount.ValidFrom))
+	d.Set("valid_until", flattenDate(productDiscount.ValidUntil))
+	return nil
+}
+
+func resourceProductDiscountUpdate(d *schema.ResourceData, m interface{}) error {
+	client := getClient(m)
+
+	actions, err := resourceProductDiscountUpdateActions(d)
+	if err != nil {
+		return err
+	}
+	if len(actions) == 0 {
+		return resourceProductDiscountRead(d, m)
+	}
+
+	input := &commercetools.ProductDiscountUpdateWithIDInput{
+		ID:      d.Id(),
+		Version: d.Get("version").(int),
+		Actions: actions,
+	}
+
+	log.Printf(
+		"[DEBUG] Will perform update operation with the following actions:\n%s",
+		stringFormatActions(input.Actions))
+
+	_, err = client.ProductDiscountUpdateWithID(context.Background(), input)
+	if err != nil {
+		if ctErr, ok := err.(commercetools.ErrorResponse); ok {
+			log.Printf("[DEBUG] %v: %v", ctErr, stringFormatErrorExtras(ctErr))
+		}
+		return err
+	}
+
+	return resourceProductDiscountRead(d, m)
+}
+
+// resourceProductDiscountUpdateActions returns the update actions for the
+// changes, both when applying the changes and for the planned_actions during
+// the plan
+func resourceProductDiscountUpdateActions(d resourceChange) ([]commercetools.ProductDiscountUpdateAction, error) {
+	actions := []commercetools.ProductDiscountUpdateAction{}
+
+	if d.HasChange("key") {
+		actions = append(actions, &commercetools.ProductDiscountSetKeyAction{Key: d.Get("key").(string)})
+	}
+
+	if d.HasChange("name") {
+		newName := commercetools.LocalizedString(
+			expandStringMap(d.Get("name").(map[string]interface{})))
+		actions = append(actions, &commercetools.ProductDiscountChangeNameAction{Name: &newName})
+	}
+
+	if d.HasChange("description") {
+		newDescription := commercetools.LocalizedString(
+			expandStringMap(d.Get("description").(map[string]interface{})))
+		actions = append(actions, &commercetools.ProductDiscountSetDescriptionAction{Description: &newDescription})
+	}
+
+	if d.HasChange("value") {
+		value, err := resourceProductDiscountGetValue(d)
+		if err != nil {
+			return nil, err
+		}
+		actions = append(actions, &commercetools.ProductDiscountChangeValueAction{Value: value})
+	}
+
+	if d.HasChange("predicate") {
+		actions = append(actions, &commercetools.ProductDiscountChangePredicateAction{Predicate: d.Get("predicate").(string)})
+	}
+
+	if d.HasChange("sort_order") {
+		actions = append(actions, &commercetools.ProductDiscountChangeSortOrderAction{SortOrder: d.Get("sort_order").(string)})
+	}
+
+	if d.HasChange("is_active") {
+		actions = append(actions, &commercetools.ProductDiscountChangeIsActiveAction{IsActive: d.Get("is_active").(bool)})
+	}
+
+	if d.HasChange("valid_from") {
+		action := &commercetools.ProductDiscountSetValidFromAction{}
+		if val := d.Get("valid_from").(string); len(val) > 0 {
+			validFrom, err := expandValidFrom(val)
+			if err != nil {
+				return nil, err
+			}
+			action.ValidFrom = &validFrom
+		}
+		actions = append(actions, action)
+	}
+
+	if d.HasChange("valid_until") {
+		action := &commercetools.ProductDiscountSetValidUntilAction{}
+		if val := d.Get("valid_until").(string); len(val) > 0 {
+			validUntil, err := expandDate(val)
+			if err != nil {
+				return nil, err
+			}
+			action.ValidUntil = &validUntil
+		}
+		actions = append(actions, action)
+	}
+
+	return actions, nil
+}
+
+// resourceProductDiscountDelete deletes the product discount. An external
+// discount can't be deleted while discounted prices set by an external system
+// still reference it. With clear_discounted_prices_on_delete these prices are
+// cleared first, and the delete is retried in case the external system set
+// new discounted prices in the meantime.
+func resourceProductDiscountDelete(d *schema.ResourceData, m interface{}) error {
+	client := getClient(m)
+	clear := d.Get("clear_discounted_prices_on_delete").(bool)
+	version := d.Get("version").(int)
+
+	return resource.Retry(d.Timeout(schema.TimeoutDelete), func() *resource.RetryError {
+		if clear {
+			cleared, err := clearProductDiscountPrices(getRestClient(m), d.Id())
+			if err != nil {
+				return resource.NonRetryableError(err)
+			}
+			if cleared > 0 {
+				log.Printf("[INFO] Cleared %d discounted prices of product discount %s", cleared, d.Id())
+			}
+		}
+
+		_, err := client.ProductDiscountDeleteWithID(context.Background(), d.Id(), version)
+		if err == nil {
+			return nil
+		}
+		ctErr, ok := err.(commercetools.ErrorResponse)
+		if !ok {
+			return handleCommercetoolsError(err)
+		}
+
+		switch {
+		case ctErr.StatusCode == 404:
+			return nil
+		case ctErr.StatusCode == 409:
+			current, getErr := client.ProductDiscountGetWithID(context.Background(), d.Id())
+			if getErr != nil {
+				return resource.NonRetryableError(getErr)
+			}
+			version = current.Version
+			return resource.RetryableError(err)
+		case productDiscountReferenced(ctErr):
+			if clear {
+				log.Printf("[DEBUG] Product discount %s is still referenced by discounted prices, retrying", d.Id())
+				return resource.RetryableError(err)
+			}
+			return resource.NonRetryableError(fmt.Errorf(
+				"%s, set clear_discounted_prices_on_delete to clear the discounted prices before deleting the discount", err))
+		}
+		return handleCommercetoolsError(err)
+	})
+}
+
+// productDiscountReferenced returns whether the delete failed because the
+// discount is still referenced
+func productDiscountReferenced(err commercetools.ErrorResponse) bool {
+	for _, item := range err.Errors {
+		if _, ok := item.(commercetools.ReferenceExistsError); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// productDiscountPricesBatchSize is the number of products fetched at once
+// when clearing the discounted prices of a product discount
+const productDiscountPricesBatchSize = 100
+
+// productDiscountPrice is a price of a product variant, only the fields
+// needed to find the discounted prices of a product discount
+type productDiscountPrice struct {
+	ID         string `json:"id"`
+	Discounted *struct {
+		Discount struct {
+			ID string `json:"id"`
+		} `json:"discount"`
+	} `json:"discounted"`
+}
+
+type productDiscountVariant struct {
+	Prices []productDiscountPrice `json:"prices"`
+}
+
+type productDiscountPricesPage struct {
+	Results []struct {
+		ID            string                   `json:"id"`
+		Version       int                      `json:"version"`
+		MasterVariant productDiscountVariant   `json:"masterVariant"`
+		Variants      []productDiscountVariant `json:"variants"`
+	} `json:"results"`
+}
+
+// clearProductDiscountPrices clears the discounted prices which reference the
+// product discount, first of the current and then of the staged products.
+// Cleared prices no longer match the query, so the first page is fetched
+// until it is empty. When a page doesn't contain any product which could be
+// updated the clearing is aborted.
+func clearProductDiscountPrices(client *restClient, discountID string) (int, error) {
+	where := fmt.Sprintf(
+		"masterVariant(prices(discounted(discount(id = %q)))) or variants(prices(discounted(discount(id = %q))))",
+		discountID, discountID)
+
+	cleared := 0
+	for _, staged := range []bool{false, true} {
+		query := url.Values{}
+		query.Set("where", where)
+		query.Set("staged", strconv.FormatBool(staged))
+		query.Set("sort", "id asc")
+		query.Set("limit", strconv.Itoa(productDiscountPricesBatchSize))
+
+		for {
+			page := &productDiscountPricesPage{}
+			if err := client.get(context.Background(), "product-projections", query, page); err != nil {
+				return cleared, err
+			}
+			if len(page.Results) == 0 {
+				break
+			}
+
+			var lastErr error
+			pageCleared := 0
+			for _, product := range page.Results {
+				actions := []interface{}{}
+				for _, variant := range append([]productDiscountVariant{product.MasterVariant}, product.Variants...) {
+					for _, price := range variant.Prices {
+						if price.Discounted != nil && price.Discounted.Discount.ID == discountID {
+							actions = append(actions, map[string]interface{}{
+								"action":  "setDiscountedPrice",
+								"priceId": price.ID,
+								"staged":  staged,
+							})
+						}
+					}
+				}
+				if len(actions) == 0 {
+					continue
+				}
+
+				err := client.update(
+					context.Background(), fmt.Sprintf("products/%s", product.ID), nil,
+					product.Version, actions, nil)
+				if err != nil {
+					log.Printf("[DEBUG] Failed to clear the discounted prices of product %s: %s", product.ID, err)
+					lastErr = err
+					continue
+				}
+				pageCleared++
+				cleared += len(actions)
+			}
+
+			if pageCleared == 0 {
+				if lastErr == nil {
+					lastErr = fmt.Errorf("unable to clear the discounted prices of product discount %s", discountID)
+				}
+				return cleared, lastErr
+			}
+		}
+	}
+	return cleared, nil
+}
+
+func resourceProductDiscountGetValue(d resourceChange) (commercetools.ProductDiscountValueDraft, error) {
+	value := d.Get("value").([]interface{})[0].(map[string]interface{})
+	switch value["type"].(string) {
+	case "relative":
+		return commercetools.ProductDiscountValueRelativeDraft{
+			Permyriad: value["permyriad"].(int),
+		}, nil
+	case "absolute":
+		return commercetools.ProductDiscountValueAbsoluteDraft{
+			Money: resourceCartDiscountGetMoney(value),
+		}, nil
+	case "external":
+		return commercetools.ProductDiscountValueExternalDraft{}, nil
+	default:
+		return nil, fmt.Errorf("Value type %s not implemented", value["type"])
+	}
+}
+
+func flattenProductDiscountValue(value commercetools.ProductDiscountValue) []map[string]interface{} {
+	switch v := value.(type) {
+	case commercetools.ProductDiscountValueRelative:
+		return []map[string]interface{}{{
+			"type":      "relative",
+			"permyriad": v.Permyriad,
+		}}
+	case commercetools.ProductDiscountValueAbsolute:
+		money := make([]map[string]interface{}, len(v.Money))
+		for i, item := range v.Money {
+			money[i] = flattenCartDiscountMoney(item)
+		}
+		return []map[string]interface{}{{
+			"type":  "absolute",
+			"money": money,
+		}}
+	case commercetools.ProductDiscountValueExternal:
+		return []map[string]interface{}{{
+			"type": "external",
+		}}
+	}
+	log.Printf("[WARN] Product discount value %T is not supported", value)
+	return nil
+}
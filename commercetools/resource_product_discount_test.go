@@ -0,0 +1,144 @@
+package commercetools
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/labd/commercetools-go-sdk/commercetools"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlattenProductDiscountValue(t *testing.T) {
+	assert.Equal(t,
+		[]map[string]interface{}{{"type": "relative", "permyriad": 1000}},
+		flattenProductDiscountValue(commercetools.ProductDiscountValueRelative{Permyriad: 1000}))
+
+	assert.Equal(t,
+		[]map[string]interface{}{{
+			"type": "absolute",
+			"money": []map[string]interface{}{
+				{"currency_code": "EUR", "cent_amount": 1500},
+				{"currency_code": "USD", "cent_amount": 1500},
+			},
+		}},
+		flattenProductDiscountValue(commercetools.ProductDiscountValueAbsolute{
+			Money: []commercetools.TypedMoney{
+				commercetools.CentPrecisionMoney{CurrencyCode: "EUR", CentAmount: 1500},
+				map[string]interface{}{"currencyCode": "USD", "centAmount": 1500.0},
+			},
+		}))
+
+	assert.Equal(t,
+		[]map[string]interface{}{{"type": "external"}},
+		flattenProductDiscountValue(commercetools.ProductDiscountValueExternal{}))
+}
+
+// productDiscountServer fakes a product with a discounted price referencing
+// the discount in the current and the staged data
+func productDiscountServer(t *testing.T, deletes *int) *httptest.Server {
+	discounted := map[string]bool{"false": true, "true": true}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/my-project/product-projections":
+			staged := r.URL.Query().Get("staged")
+			results := []interface{}{}
+			if discounted[staged] {
+				results = append(results, map[string]interface{}{
+					"id":      "product",
+					"version": 3,
+					"masterVariant": map[string]interface{}{
+						"prices": []interface{}{
+							map[string]interface{}{"id": "price-1"},
+							map[string]interface{}{
+								"id": "price-2",
+								"discounted": map[string]interface{}{
+									"discount": map[string]interface{}{"typeId": "product-discount", "id": "discount"},
+								},
+							},
+						},
+					},
+				})
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"results": results})
+		case r.URL.Path == "/my-project/products/product":
+			body, _ := ioutil.ReadAll(r.Body)
+			update := map[string]interface{}{}
+			assert.NoError(t, json.Unmarshal(body, &update))
+			action := update["actions"].([]interface{})[0].(map[string]interface{})
+			assert.Equal(t, "setDiscountedPrice", action["action"])
+			assert.Equal(t, "price-2", action["priceId"])
+			assert.NotContains(t, action, "discounted")
+
+			// Clearing the current price clears the staged price as well
+			discounted[fmt.Sprint(action["staged"])] = false
+			if action["staged"] == false {
+				discounted["true"] = false
+			}
+			w.Write([]byte(`{"id": "product", "version": 4}`))
+		case r.URL.Path == "/my-project/product-discounts/discount" && r.Method == "DELETE":
+			*deletes++
+			if *deletes == 1 {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{
+					"statusCode": 400,
+					"message": "Can not delete a product-discount while it is referenced by at least one product.",
+					"errors": [{"code": "ReferenceExists", "message": "Can not delete a product-discount while it is referenced by at least one product.", "referencedBy": "product"}]
+				}`))
+				return
+			}
+			w.Write([]byte(`{"id": "discount", "version": 1}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+}
+
+func TestClearProductDiscountPrices(t *testing.T) {
+	deletes := 0
+	server := productDiscountServer(t, &deletes)
+	defer server.Close()
+
+	cleared, err := clearProductDiscountPrices(newRestClient(server.Client(), server.URL, "my-project"), "discount")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, cleared)
+}
+
+func TestResourceProductDiscountDelete(t *testing.T) {
+	deletes := 0
+	server := productDiscountServer(t, &deletes)
+	defer server.Close()
+
+	meta := &providerMeta{
+		client: commercetools.New(&commercetools.Config{
+			ProjectKey: "my-project",
+			URL:        server.URL,
+			HTTPClient: server.Client(),
+		}),
+		rest: newRestClient(server.Client(), server.URL, "my-project"),
+	}
+	raw := map[string]interface{}{"clear_discounted_prices_on_delete": false}
+
+	// Without clearing the discounted prices the delete fails right away
+	d := schema.TestResourceDataRaw(t, resourceProductDiscount().Schema, raw)
+	d.SetId("discount")
+	d.Set("version", 1)
+	err := resourceProductDiscountDelete(d, meta)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "clear_discounted_prices_on_delete")
+	assert.Equal(t, 1, deletes)
+
+	// With clearing the prices are cleared, and the delete is retried when the
+	// discount is still referenced
+	deletes = 0
+	raw["clear_discounted_prices_on_delete"] = true
+	d = schema.TestResourceDataRaw(t, resourceProductDiscount().Schema, raw)
+	d.SetId("discount")
+	d.Set("version", 1)
+	assert.NoError(t, resourceProductDiscountDelete(d, meta))
+	assert.Equal(t, 2, deletes)
+}
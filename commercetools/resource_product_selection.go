@@ -0,0 +1,325 @@
+package commercetools
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/labd/commercetools-go-sdk/commercetools"
+)
+
+// Product selections are not supported by the commercetools-go-sdk yet, so
+// the resource uses the restClient together with the types defined below.
+
+const (
+	productSelectionModeIndividual          = "Individual"
+	productSelectionModeIndividualExclusion = "IndividualExclusion"
+)
+
+type productSelectionDraft struct {
+	Key  string                         `json:"key,omitempty"`
+	Name *commercetools.LocalizedString `json:"name"`
+	Mode string                         `json:"mode"`
+}
+
+type productSelection struct {
+	ID      string                         `json:"id"`
+	Version int                            `json:"version"`
+	Key     string                         `json:"key,omitempty"`
+	Name    *commercetools.LocalizedString `json:"name"`
+	Mode    string                         `json:"mode"`
+}
+
+type productSelectionAssignmentPage struct {
+	Results []struct {
+		Product *commercetools.ProductReference `json:"product"`
+	} `json:"results"`
+}
+
+// productSelectionAction is a generic update action, the fields of the
+// action are passed as is next to the action name.
+type productSelectionAction map[string]interface{}
+
+// productSelectionPageSize is the number of assigned products fetched at once
+const productSelectionPageSize = 500
+
+func resourceProductSelection() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceProductSelectionCreate,
+		Read:   resourceProductSelectionRead,
+		Update: resourceProductSelectionUpdate,
+		Delete: resourceProductSelectionDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"name": {
+				Type:     TypeLocalizedString,
+				Required: true,
+			},
+			"mode": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Default:  productSelectionModeIndividual,
+				ValidateFunc: validation.StringInSlice([]string{
+					productSelectionModeIndividual,
+					productSelectionModeIndividualExclusion,
+				}, false),
+				Description: "Individual to include the assigned products, IndividualExclusion to include all products except the assigned ones",
+			},
+			"product_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The products included in the selection, only for mode Individual",
+			},
+			"excluded_product_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The products excluded from the selection, only for mode IndividualExclusion",
+			},
+			"version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+		CustomizeDiff: resourceProductSelectionValidateAssignments,
+	}
+}
+
+// resourceProductSelectionValidateAssignments checks that the products are
+// assigned with the operation of the mode: products can only be added to a
+// selection with mode Individual and only be excluded from a selection with
+// mode IndividualExclusion
+func resourceProductSelectionValidateAssignments(d *schema.ResourceDiff, m interface{}) error {
+	return validateProductSelectionAssignments(
+		d.Get("mode").(string),
+		d.Get("product_ids").(*schema.Set).Len(),
+		d.Get("excluded_product_ids").(*schema.Set).Len())
+}
+
+func validateProductSelectionAssignments(mode string, included int, excluded int) error {
+	if mode == productSelectionModeIndividual && excluded > 0 {
+		return fmt.Errorf(
+			"excluded_product_ids can only be used with mode %s, use product_ids for mode %s",
+			productSelectionModeIndividualExclusion, productSelectionModeIndividual)
+	}
+	if mode == productSelectionModeIndividualExclusion && included > 0 {
+		return fmt.Errorf(
+			"product_ids can only be used with mode %s, use excluded_product_ids for mode %s",
+			productSelectionModeIndividual, productSelectionModeIndividualExclusion)
+	}
+	return nil
+}
+
+func resourceProductSelectionCreate(d *schema.ResourceData, m interface{}) error {
+	client := getRestClient(m)
+	var selection productSelection
+
+	name := commercetools.LocalizedString(
+		expandStringMap(d.Get("name").(map[string]interface{})))
+
+	draft := &productSelectionDraft{
+		Key:  d.Get("key").(string),
+		Name: &name,
+		Mode: d.Get("mode").(string),
+	}
+
+	err := resource.Retry(1*time.Minute, func() *resource.RetryError {
+		err := client.create(context.Background(), "product-selections", nil, draft, &selection)
+		if err != nil {
+			return handleCommercetoolsError(err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	d.SetId(selection.ID)
+	d.Set("version", selection.Version)
+
+	// The products can't be part of the draft, so they are assigned with a
+	// separate update
+	actions := resourceProductSelectionAssignmentActions(
+		selection.Mode, &schema.Set{F: schema.HashString}, productSelectionAssignments(d))
+	if len(actions) > 0 {
+		err := client.update(
+			context.Background(), fmt.Sprintf("product-selections/%s", selection.ID), nil,
+			selection.Version, actions, &selection)
+		if err != nil {
+			return err
+		}
+	}
+
+	return resourceProductSelectionRead(d, m)
+}
+
+func resourceProductSelectionRead(d *schema.ResourceData, m interface{}) error {
+	log.Printf("[DEBUG] Reading product selection from commercetools, with id: %s", d.Id())
+	client := getRestClient(m)
+
+	var selection productSelection
+	err := client.get(context.Background(), fmt.Sprintf("product-selections/%s", d.Id()), nil, &selection)
+	if err != nil {
+		if ctErr, ok := err.(commercetools.ErrorResponse); ok {
+			if ctErr.StatusCode == 404 {
+				d.SetId("")
+				return nil
+			}
+		}
+		return err
+	}
+
+	log.Print("[DEBUG] Found following product selection:")
+	log.Print(stringFormatObject(selection))
+
+	productIDs, err := productSelectionAssignedProducts(client, selection.ID)
+	if err != nil {
+		return err
+	}
+
+	d.Set("version", selection.Version)
+	d.Set("key", selection.Key)
+	d.Set("name", selection.Name)
+	d.Set("mode", selection.Mode)
+	if selection.Mode == productSelectionModeIndividualExclusion {
+		d.Set("product_ids", []string{})
+		d.Set("excluded_product_ids", productIDs)
+	} else {
+		d.Set("product_ids", productIDs)
+		d.Set("excluded_product_ids", []string{})
+	}
+	return nil
+}
+
+// productSelectionAssignedProducts returns the ids of all products assigned
+// to the selection, which are included or excluded depending on the mode
+func productSelectionAssignedProducts(client *restClient, id string) ([]string, error) {
+	productIDs := []string{}
+	for offset := 0; ; offset += productSelectionPageSize {
+		query := url.Values{}
+		query.Set("limit", strconv.Itoa(productSelectionPageSize))
+		query.Set("offset", strconv.Itoa(offset))
+
+		page := &productSelectionAssignmentPage{}
+		err := client.get(context.Background(), fmt.Sprintf("product-selections/%s/products", id), query, page)
+		if err != nil {
+			return nil, err
+		}
+		for _, assignment := range page.Results {
+			if assignment.Product != nil {
+				productIDs = append(productIDs, assignment.Product.ID)
+			}
+		}
+		if len(page.Results) < productSelectionPageSize {
+			return productIDs, nil
+		}
+	}
+}
+
+func resourceProductSelectionUpdate(d *schema.ResourceData, m interface{}) error {
+	client := getRestClient(m)
+
+	ctMutexKV.Lock(d.Id())
+	defer ctMutexKV.Unlock(d.Id())
+
+	actions := []productSelectionAction{}
+	if d.HasChange("key") {
+		action := productSelectionAction{"action": "setKey"}
+		if key := d.Get("key").(string); key != "" {
+			action["key"] = key
+		}
+		actions = append(actions, action)
+	}
+	if d.HasChange("name") {
+		name := commercetools.LocalizedString(
+			expandStringMap(d.Get("name").(map[string]interface{})))
+		actions = append(actions, productSelectionAction{"action": "changeName", "name": &name})
+	}
+
+	mode := d.Get("mode").(string)
+	attribute := "product_ids"
+	if mode == productSelectionModeIndividualExclusion {
+		attribute = "excluded_product_ids"
+	}
+	if d.HasChange(attribute) {
+		old, new := d.GetChange(attribute)
+		actions = append(actions, resourceProductSelectionAssignmentActions(
+			mode, old.(*schema.Set), new.(*schema.Set))...)
+	}
+
+	log.Printf(
+		"[DEBUG] Will perform update operation with the following actions:\n%s",
+		stringFormatObject(actions))
+
+	if len(actions) > 0 {
+		err := client.update(
+			context.Background(), fmt.Sprintf("product-selections/%s", d.Id()), nil,
+			d.Get("version").(int), actions, nil)
+		if err != nil {
+			if ctErr, ok := err.(commercetools.ErrorResponse); ok {
+				log.Printf("[DEBUG] %v: %v", ctErr, stringFormatErrorExtras(ctErr))
+			}
+			return err
+		}
+	}
+
+	return resourceProductSelectionRead(d, m)
+}
+
+// productSelectionAssignments returns the configured products of the mode
+func productSelectionAssignments(d *schema.ResourceData) *schema.Set {
+	if d.Get("mode").(string) == productSelectionModeIndividualExclusion {
+		return d.Get("excluded_product_ids").(*schema.Set)
+	}
+	return d.Get("product_ids").(*schema.Set)
+}
+
+// resourceProductSelectionAssignmentActions returns the actions to change the
+// assigned products. Products are added with addProduct in mode Individual
+// and with excludeProduct in mode IndividualExclusion, both are unassigned
+// with removeProduct.
+func resourceProductSelectionAssignmentActions(mode string, old *schema.Set, new *schema.Set) []productSelectionAction {
+	addAction := "addProduct"
+	if mode == productSelectionModeIndividualExclusion {
+		addAction = "excludeProduct"
+	}
+
+	actions := []productSelectionAction{}
+	for _, id := range expandStringArray(old.Difference(new).List()) {
+		actions = append(actions, productSelectionAction{
+			"action":  "removeProduct",
+			"product": commercetools.ProductResourceIdentifier{ID: id},
+		})
+	}
+	for _, id := range expandStringArray(new.Difference(old).List()) {
+		actions = append(actions, productSelectionAction{
+			"action":  addAction,
+			"product": commercetools.ProductResourceIdentifier{ID: id},
+		})
+	}
+	return actions
+}
+
+func resourceProductSelectionDelete(d *schema.ResourceData, m interface{}) error {
+	client := getRestClient(m)
+	version := d.Get("version").(int)
+
+	params := url.Values{}
+	params.Set("version", strconv.Itoa(version))
+	return client.delete(context.Background(), fmt.Sprintf("product-selections/%s", d.Id()), params, nil)
+}
@@ -0,0 +1,35 @@
+package commercetools
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/labd/commercetools-go-sdk/commercetools"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateProductSelectionAssignments(t *testing.T) {
+	assert.NoError(t, validateProductSelectionAssignments(productSelectionModeIndividual, 2, 0))
+	assert.NoError(t, validateProductSelectionAssignments(productSelectionModeIndividualExclusion, 0, 2))
+	assert.EqualError(t,
+		validateProductSelectionAssignments(productSelectionModeIndividual, 0, 1),
+		"excluded_product_ids can only be used with mode IndividualExclusion, use product_ids for mode Individual")
+	assert.EqualError(t,
+		validateProductSelectionAssignments(productSelectionModeIndividualExclusion, 1, 0),
+		"product_ids can only be used with mode Individual, use excluded_product_ids for mode IndividualExclusion")
+}
+
+func TestResourceProductSelectionAssignmentActions(t *testing.T) {
+	old := schema.NewSet(schema.HashString, []interface{}{"1", "2"})
+	new := schema.NewSet(schema.HashString, []interface{}{"2", "3"})
+
+	assert.Equal(t, []productSelectionAction{
+		{"action": "removeProduct", "product": commercetools.ProductResourceIdentifier{ID: "1"}},
+		{"action": "addProduct", "product": commercetools.ProductResourceIdentifier{ID: "3"}},
+	}, resourceProductSelectionAssignmentActions(productSelectionModeIndividual, old, new))
+
+	assert.Equal(t, []productSelectionAction{
+		{"action": "removeProduct", "product": commercetools.ProductResourceIdentifier{ID: "1"}},
+		{"action": "excludeProduct", "product": commercetools.ProductResourceIdentifier{ID: "3"}},
+	}, resourceProductSelectionAssignmentActions(productSelectionModeIndividualExclusion, old, new))
+}
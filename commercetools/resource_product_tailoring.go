@@ -0,0 +1,291 @@
+package commercetools
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/labd/commercetools-go-sdk/commercetools"
+)
+
+// Product tailoring is not supported by the commercetools-go-sdk yet, so the
+// resource uses the restClient together with the types defined below.
+
+type productTailoringDraft struct {
+	Key             string                                   `json:"key,omitempty"`
+	Store           *commercetools.StoreResourceIdentifier   `json:"store"`
+	Product         *commercetools.ProductResourceIdentifier `json:"product"`
+	Name            *commercetools.LocalizedString           `json:"name,omitempty"`
+	Description     *commercetools.LocalizedString           `json:"description,omitempty"`
+	MetaTitle       *commercetools.LocalizedString           `json:"metaTitle,omitempty"`
+	MetaDescription *commercetools.LocalizedString           `json:"metaDescription,omitempty"`
+	MetaKeywords    *commercetools.LocalizedString           `json:"metaKeywords,omitempty"`
+	Slug            *commercetools.LocalizedString           `json:"slug,omitempty"`
+	Publish         bool                                     `json:"publish"`
+}
+
+type productTailoringData struct {
+	Name            *commercetools.LocalizedString `json:"name,omitempty"`
+	Description     *commercetools.LocalizedString `json:"description,omitempty"`
+	MetaTitle       *commercetools.LocalizedString `json:"metaTitle,omitempty"`
+	MetaDescription *commercetools.LocalizedString `json:"metaDescription,omitempty"`
+	MetaKeywords    *commercetools.LocalizedString `json:"metaKeywords,omitempty"`
+	Slug            *commercetools.LocalizedString `json:"slug,omitempty"`
+}
+
+type productTailoring struct {
+	ID               string                           `json:"id"`
+	Version          int                              `json:"version"`
+	Key              string                           `json:"key,omitempty"`
+	Store            *commercetools.StoreKeyReference `json:"store"`
+	Product          *commercetools.ProductReference  `json:"product"`
+	Published        bool                             `json:"published"`
+	HasStagedChanges bool                             `json:"hasStagedChanges"`
+	Current          *productTailoringData            `json:"current"`
+	Staged           *productTailoringData            `json:"staged"`
+}
+
+// productTailoringAction is a generic update action, the fields of the
+// action are passed as is next to the action name.
+type productTailoringAction map[string]interface{}
+
+// productTailoringFields maps the localized fields of the resource to the
+// update action which changes them
+var productTailoringFields = []struct {
+	Attribute string
+	Field     string
+	Action    string
+}{
+	{"name", "name", "setName"},
+	{"description", "description", "setDescription"},
+	{"slug", "slug", "setSlug"},
+	{"meta_title", "metaTitle", "setMetaTitle"},
+	{"meta_description", "metaDescription", "setMetaDescription"},
+	{"meta_keywords", "metaKeywords", "setMetaKeywords"},
+}
+
+func resourceProductTailoring() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceProductTailoringCreate,
+		Read:   resourceProductTailoringRead,
+		Update: resourceProductTailoringUpdate,
+		Delete: resourceProductTailoringDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Schema: map[string]*schema.Schema{
+			"key": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"store_key": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"product_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"name": {
+				Type:     TypeLocalizedString,
+				Optional: true,
+			},
+			"description": {
+				Type:     TypeLocalizedString,
+				Optional: true,
+			},
+			"slug": {
+				Type:     TypeLocalizedString,
+				Optional: true,
+			},
+			"meta_title": {
+				Type:     TypeLocalizedString,
+				Optional: true,
+			},
+			"meta_description": {
+				Type:     TypeLocalizedString,
+				Optional: true,
+			},
+			"meta_keywords": {
+				Type:     TypeLocalizedString,
+				Optional: true,
+			},
+			"published": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"version": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceProductTailoringCreate(d *schema.ResourceData, m interface{}) error {
+	client := getRestClient(m)
+	var tailoring productTailoring
+
+	draft := &productTailoringDraft{
+		Key:             d.Get("key").(string),
+		Store:           &commercetools.StoreResourceIdentifier{Key: d.Get("store_key").(string)},
+		Product:         &commercetools.ProductResourceIdentifier{ID: d.Get("product_id").(string)},
+		Name:            expandProductTailoringField(d, "name"),
+		Description:     expandProductTailoringField(d, "description"),
+		MetaTitle:       expandProductTailoringField(d, "meta_title"),
+		MetaDescription: expandProductTailoringField(d, "meta_description"),
+		MetaKeywords:    expandProductTailoringField(d, "meta_keywords"),
+		Slug:            expandProductTailoringField(d, "slug"),
+		Publish:         d.Get("published").(bool),
+	}
+
+	err := resource.Retry(1*time.Minute, func() *resource.RetryError {
+		err := client.create(context.Background(), "product-tailoring", nil, draft, &tailoring)
+		if err != nil {
+			return handleCommercetoolsError(err)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	d.SetId(tailoring.ID)
+	d.Set("version", tailoring.Version)
+
+	return resourceProductTailoringRead(d, m)
+}
+
+func resourceProductTailoringRead(d *schema.ResourceData, m interface{}) error {
+	log.Printf("[DEBUG] Reading product tailoring from commercetools, with id: %s", d.Id())
+	client := getRestClient(m)
+
+	var tailoring productTailoring
+	err := client.get(context.Background(), fmt.Sprintf("product-tailoring/%s", d.Id()), nil, &tailoring)
+	if err != nil {
+		if ctErr, ok := err.(commercetools.ErrorResponse); ok {
+			if ctErr.StatusCode == 404 {
+				d.SetId("")
+				return nil
+			}
+		}
+		return err
+	}
+
+	log.Print("[DEBUG] Found following product tailoring:")
+	log.Print(stringFormatObject(tailoring))
+
+	d.Set("version", tailoring.Version)
+	d.Set("key", tailoring.Key)
+	if tailoring.Store != nil {
+		d.Set("store_key", tailoring.Store.Key)
+	}
+	if tailoring.Product != nil {
+		d.Set("product_id", tailoring.Product.ID)
+	}
+	d.Set("published", tailoring.Published)
+
+	// All changes are applied to the staged data, so that is what the
+	// configuration is compared against.
+	data := tailoring.Staged
+	if data == nil {
+		data = &productTailoringData{}
+	}
+	d.Set("name", flattenProductTailoringField(data.Name))
+	d.Set("description", flattenProductTailoringField(data.Description))
+	d.Set("slug", flattenProductTailoringField(data.Slug))
+	d.Set("meta_title", flattenProductTailoringField(data.MetaTitle))
+	d.Set("meta_description", flattenProductTailoringField(data.MetaDescription))
+	d.Set("meta_keywords", flattenProductTailoringField(data.MetaKeywords))
+	return nil
+}
+
+func resourceProductTailoringUpdate(d *schema.ResourceData, m interface{}) error {
+	client := getRestClient(m)
+
+	ctMutexKV.Lock(d.Id())
+	defer ctMutexKV.Unlock(d.Id())
+
+	actions := resourceProductTailoringActions(d)
+
+	log.Printf(
+		"[DEBUG] Will perform update operation with the following actions:\n%s",
+		stringFormatObject(actions))
+
+	var tailoring productTailoring
+	err := client.update(
+		context.Background(), fmt.Sprintf("product-tailoring/%s", d.Id()), nil,
+		d.Get("version").(int), actions, &tailoring)
+	if err != nil {
+		if ctErr, ok := err.(commercetools.ErrorResponse); ok {
+			log.Printf("[DEBUG] %v: %v", ctErr, stringFormatErrorExtras(ctErr))
+		}
+		return err
+	}
+
+	return resourceProductTailoringRead(d, m)
+}
+
+// resourceProductTailoringActions returns the update actions for the changed
+// fields. The fields are changed on the staged data, when the tailoring is
+// published the changes are published afterwards.
+func resourceProductTailoringActions(d *schema.ResourceData) []productTailoringAction {
+	actions := []productTailoringAction{}
+	for _, field := range productTailoringFields {
+		if !d.HasChange(field.Attribute) {
+			continue
+		}
+		action := productTailoringAction{
+			"action": field.Action,
+			"staged": true,
+		}
+		if value := expandProductTailoringField(d, field.Attribute); value != nil {
+			action[field.Field] = value
+		}
+		actions = append(actions, action)
+	}
+
+	published := d.Get("published").(bool)
+	if published && (len(actions) > 0 || d.HasChange("published")) {
+		actions = append(actions, productTailoringAction{"action": "publish"})
+	}
+	if !published && d.HasChange("published") {
+		actions = append(actions, productTailoringAction{"action": "unpublish"})
+	}
+	return actions
+}
+
+func resourceProductTailoringDelete(d *schema.ResourceData, m interface{}) error {
+	client := getRestClient(m)
+	version := d.Get("version").(int)
+
+	params := url.Values{}
+	params.Set("version", strconv.Itoa(version))
+	return client.delete(context.Background(), fmt.Sprintf("product-tailoring/%s", d.Id()), params, nil)
+}
+
+func expandProductTailoringField(d *schema.ResourceData, attribute string) *commercetools.LocalizedString {
+	value := expandStringMap(d.Get(attribute).(map[string]interface{}))
+	if len(value) == 0 {
+		return nil
+	}
+	result := commercetools.LocalizedString(value)
+	return &result
+}
+
+func flattenProductTailoringField(value *commercetools.LocalizedString) map[string]string {
+	if value == nil {
+		return nil
+	}
+	return *value
+}
@@ -0,0 +1,35 @@
+package commercetools
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/labd/commercetools-go-sdk/commercetools"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProductTailoringDraftMarshal(t *testing.T) {
+	name := commercetools.LocalizedString{"en": "Tailored name"}
+	draft := &productTailoringDraft{
+		Store:   &commercetools.StoreResourceIdentifier{Key: "my-store"},
+		Product: &commercetools.ProductResourceIdentifier{ID: "product-id"},
+		Name:    &name,
+		Publish: true,
+	}
+
+	data, err := json.Marshal(draft)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"store": {"typeId": "store", "key": "my-store"},
+		"product": {"typeId": "product", "id": "product-id"},
+		"name": {"en": "Tailored name"},
+		"publish": true
+	}`, string(data))
+}
+
+func TestFlattenProductTailoringField(t *testing.T) {
+	assert.Nil(t, flattenProductTailoringField(nil))
+
+	value := commercetools.LocalizedString{"en": "Slug"}
+	assert.Equal(t, map[string]string{"en": "Slug"}, flattenProductTailoringField(&value))
+}
@@ -5,11 +5,14 @@ import (
 	"fmt"
 	"log"
 	"reflect"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/labd/commercetools-go-sdk/commercetools"
 )
 
@@ -20,6 +23,23 @@ var constraintMap = map[string]commercetools.AttributeConstraintEnum{
 	"None":              commercetools.AttributeConstraintEnumNone,
 }
 
+// attributeReferenceTypeIDs are the resource types which can be referenced by
+// an attribute of type reference
+var attributeReferenceTypeIDs = []string{
+	"cart",
+	"category",
+	"channel",
+	"customer",
+	"key-value-document",
+	"order",
+	"product",
+	"product-type",
+	"review",
+	"shipping-method",
+	"state",
+	"zone",
+}
+
 func resourceProductType() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceProductTypeCreate,
@@ -115,6 +135,12 @@ func resourceProductType() *schema.Resource {
 				for _, field := range newV {
 					newF := field.(map[string]interface{})
 					name := newF["name"].(string)
+					if types, ok := newF["type"].([]interface{}); ok && len(types) > 0 && types[0] != nil {
+						if err := validateAttributeReferenceType(name, types[0].(map[string]interface{})); err != nil {
+							return err
+						}
+					}
+
 					oldF, ok := oldLookup[name].(map[string]interface{})
 					if !ok {
 						// It means this is a new field, that's ok.
@@ -126,13 +152,8 @@ func resourceProductType() *schema.Resource {
 					oldType := oldF["type"].([]interface{})[0].(map[string]interface{})
 					newType := newF["type"].([]interface{})[0].(map[string]interface{})
 
-					if oldType["name"] != newType["name"] {
-						if oldType["name"] != "" || newType["name"] == "" {
-							continue
-						}
-						return fmt.Errorf(
-							"Field '%s' type changed from %s to %s. Changing types is not supported; please remove the attribute first and re-define it later",
-							name, oldType["name"], newType["name"])
+					if err := validateAttributeTypeChange(name, oldType, newType); err != nil {
+						return err
 					}
 
 					if oldF["required"] != newF["required"] {
@@ -140,6 +161,10 @@ func resourceProductType() *schema.Resource {
 							"Error on the '%s' attribute: Updating the 'required' attribute is not supported. Consider removing the attribute first and then re-adding it",
 							name)
 					}
+
+					if err := validateAttributeConstraintChange(name, oldF["constraint"], newF["constraint"]); err != nil {
+						return err
+					}
 				}
 				return nil
 			}),
@@ -147,6 +172,118 @@ func resourceProductType() *schema.Resource {
 	}
 }
 
+// validateAttributeReferenceType checks that reference types (and sets of
+// reference types) define the referenced resource type
+func validateAttributeReferenceType(name string, attrType map[string]interface{}) error {
+	switch attrType["name"] {
+	case "reference":
+		if attrType["reference_type_id"] == "" {
+			return fmt.Errorf(
+				"Error on the '%s' attribute: reference_type_id is required for the reference type, supported values are %s",
+				name, strings.Join(attributeReferenceTypeIDs, ", "))
+		}
+	case "set":
+		if elementTypes, ok := attrType["element_type"].([]interface{}); ok && len(elementTypes) > 0 && elementTypes[0] != nil {
+			return validateAttributeReferenceType(name, elementTypes[0].(map[string]interface{}))
+		}
+	}
+	return nil
+}
+
+// validateAttributeTypeChange checks that the type of an existing attribute
+// is unchanged, commercetools has no update action to change it. Only the
+// values of enum types can be changed. The product type can't be recreated
+// either when products use it, so the attribute needs to be migrated.
+func validateAttributeTypeChange(name string, oldType map[string]interface{}, newType map[string]interface{}) error {
+	describe := func(attrType map[string]interface{}) string {
+		description := fmt.Sprint(attrType["name"])
+		switch attrType["name"] {
+		case "set":
+			if elementTypes, ok := attrType["element_type"].([]interface{}); ok && len(elementTypes) > 0 && elementTypes[0] != nil {
+				elementType := elementTypes[0].(map[string]interface{})
+				description = fmt.Sprintf("set of %s", elementType["name"])
+			}
+		case "reference":
+			description = fmt.Sprintf("reference to %s", attrType["reference_type_id"])
+		}
+		return description
+	}
+
+	changed := false
+	switch {
+	case oldType["name"] == "" || newType["name"] == "":
+		// The type is not known yet
+		return nil
+	case oldType["name"] != newType["name"]:
+		changed = true
+	case newType["name"] == "reference":
+		changed = oldType["reference_type_id"] != newType["reference_type_id"]
+	case newType["name"] == "nested":
+		// The referenced product type is only compared when it is
+		// configured by id, a key is resolved during the apply
+		changed = oldType["type_reference"] != "" && newType["type_reference"] != "" &&
+			newType["type_reference_key"] == "" && oldType["type_reference"] != newType["type_reference"]
+	case newType["name"] == "set":
+		oldElementTypes, _ := oldType["element_type"].([]interface{})
+		newElementTypes, _ := newType["element_type"].([]interface{})
+		if len(oldElementTypes) > 0 && len(newElementTypes) > 0 && oldElementTypes[0] != nil && newElementTypes[0] != nil {
+			return validateAttributeTypeChange(
+				name,
+				oldElementTypes[0].(map[string]interface{}),
+				newElementTypes[0].(map[string]interface{}))
+		}
+	}
+	if !changed {
+		return nil
+	}
+	return fmt.Errorf(
+		"Error on the '%s' attribute: The type can't be changed from %s to %s, commercetools doesn't support changing the type of an attribute. "+
+			"To migrate, add an attribute with a new name and the new type, copy the values of the products to it and remove the '%s' attribute afterwards",
+		name, describe(oldType), describe(newType), name)
+}
+
+// validateAttributeConstraintChange checks that the constraint of an existing
+// attribute is only relaxed. commercetools only allows changing the
+// constraint to None with the changeAttributeConstraint update action, since
+// the existing products may not comply with a stricter constraint.
+func validateAttributeConstraintChange(name string, old interface{}, new interface{}) error {
+	if old == new || old == "" || new == string(commercetools.AttributeConstraintEnumNone) {
+		return nil
+	}
+	return fmt.Errorf(
+		"Error on the '%s' attribute: The constraint can only be changed to None, changing it from %s to %s is not supported. Consider removing the attribute first and then re-adding it",
+		name, old, new)
+}
+
+// resourceProductTypePlanWarnings warns when changing the attributes removes
+// values from products or results in a large number of update actions
+func resourceProductTypePlanWarnings(d *schema.ResourceDiff, m interface{}) ([]string, error) {
+	if d.Id() == "" || !d.HasChange("attribute") || !d.NewValueKnown("attribute") {
+		return nil, nil
+	}
+
+	old, new := d.GetChange("attribute")
+	actions, err := resourceProductTypeAttributeChangeActions(old.([]interface{}), new.([]interface{}))
+	if err != nil {
+		// Invalid changes are reported when applying the change
+		return nil, nil
+	}
+	warnings := []string{}
+	for _, action := range actions {
+		if remove, ok := action.(commercetools.ProductTypeRemoveAttributeDefinitionAction); ok {
+			warnings = append(warnings, fmt.Sprintf(
+				"removing attribute %s removes the values of the attribute from all products",
+				remove.Name))
+		}
+		if remove, ok := action.(commercetools.ProductTypeRemoveEnumValuesAction); ok {
+			warnings = append(warnings, fmt.Sprintf(
+				"removing the values %s of attribute %s removes them from all products using them",
+				strings.Join(remove.Keys, ", "), remove.AttributeName))
+		}
+	}
+	return append(warnings, updateActionCountWarning(len(actions), m)...), nil
+}
+
 func attributeTypeElement(setsAllowed bool) *schema.Resource {
 	result := map[string]*schema.Schema{
 		"name": {
@@ -170,12 +307,20 @@ func attributeTypeElement(setsAllowed bool) *schema.Resource {
 			Elem:     localizedValueElement(),
 		},
 		"reference_type_id": {
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice(attributeReferenceTypeIDs, false),
 		},
 		"type_reference": {
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:        schema.TypeString,
+			Optional:    true,
+			Computed:    true,
+			Description: "The id of the product type referenced by a nested type",
+		},
+		"type_reference_key": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "The key of the product type referenced by a nested type",
 		},
 	}
 
@@ -195,7 +340,11 @@ func resourceProductTypeCreate(d *schema.ResourceData, m interface{}) error {
 	client := getClient(m)
 	var ctType *commercetools.ProductType
 
-	attributes, err := resourceProductTypeGetAttributeDefinitions(d)
+	input, err := resolveNestedTypeReferences(client, d.Get("attribute").([]interface{}))
+	if err != nil {
+		return err
+	}
+	attributes, err := resourceProductTypeGetAttributeDefinitions(input)
 
 	if err != nil {
 		return err
@@ -278,6 +427,8 @@ func resourceProductTypeRead(d *schema.ResourceData, m interface{}) error {
 			attributes[i] = fieldData
 		}
 
+		preserveNestedTypeKeys(attributes, d.Get("attribute").([]interface{}))
+
 		log.Printf("[DEBUG] Created attributes %#v", attributes)
 		d.Set("version", ctType.Version)
 		d.Set("key", ctType.Key)
@@ -322,7 +473,7 @@ func resourceProductTypeReadAttributeType(attrType commercetools.AttributeType,
 		typeData["name"] = "datetime"
 	} else if f, ok := attrType.(commercetools.AttributeReferenceType); ok {
 		typeData["name"] = "reference"
-		typeData["reference_type_id"] = f.ReferenceTypeID
+		typeData["reference_type_id"] = string(f.ReferenceTypeID)
 	} else if f, ok := attrType.(commercetools.AttributeNestedType); ok {
 		typeData["name"] = "nested"
 		typeData["type_reference"] = f.TypeReference.ID
@@ -345,57 +496,73 @@ func resourceProductTypeReadAttributeType(attrType commercetools.AttributeType,
 func resourceProductTypeUpdate(d *schema.ResourceData, m interface{}) error {
 	client := getClient(m)
 
+	actions, err := resourceProductTypeUpdateActions(d, client)
+	if err != nil {
+		return err
+	}
+
 	input := &commercetools.ProductTypeUpdateWithIDInput{
 		ID:      d.Id(),
 		Version: d.Get("version").(int),
-		Actions: []commercetools.ProductTypeUpdateAction{},
+		Actions: actions,
+	}
+
+	log.Printf(
+		"[DEBUG] Will perform update operation with the following actions:\n%s",
+		stringFormatActions(input.Actions))
+
+	_, err = client.ProductTypeUpdateWithID(context.Background(), input)
+	if err != nil {
+		if ctErr, ok := err.(commercetools.ErrorResponse); ok {
+			log.Printf("[DEBUG] %v: %v", ctErr, stringFormatErrorExtras(ctErr))
+		}
+		return err
 	}
 
+	return resourceProductTypeRead(d, m)
+}
+
+// resourceProductTypeUpdateActions returns the update actions for the changes,
+// both when applying the changes and for the planned_actions during the plan
+func resourceProductTypeUpdateActions(d resourceChange, client *commercetools.Client) ([]commercetools.ProductTypeUpdateAction, error) {
+	actions := []commercetools.ProductTypeUpdateAction{}
+
 	if d.HasChange("key") {
 		newKey := d.Get("key").(string)
-		input.Actions = append(
-			input.Actions,
+		actions = append(
+			actions,
 			&commercetools.ProductTypeSetKeyAction{Key: newKey})
 	}
 
 	if d.HasChange("name") {
 		newName := d.Get("name").(string)
-		input.Actions = append(
-			input.Actions,
+		actions = append(
+			actions,
 			&commercetools.ProductTypeChangeNameAction{Name: newName})
 	}
 
 	if d.HasChange("description") {
 		newDescr := d.Get("description").(string)
-		input.Actions = append(
-			input.Actions,
+		actions = append(
+			actions,
 			&commercetools.ProductTypeChangeDescriptionAction{Description: newDescr})
 	}
 
 	if d.HasChange("attribute") {
 		old, new := d.GetChange("attribute")
+		resolved, err := resolveNestedTypeReferences(client, new.([]interface{}))
+		if err != nil {
+			return nil, err
+		}
 		attributeChangeActions, err := resourceProductTypeAttributeChangeActions(
-			old.([]interface{}), new.([]interface{}))
+			old.([]interface{}), resolved)
 		if err != nil {
-			return err
+			return nil, err
 		}
 
-		input.Actions = append(input.Actions, attributeChangeActions...)
-	}
-
-	log.Printf(
-		"[DEBUG] Will perform update operation with the following actions:\n%s",
-		stringFormatActions(input.Actions))
-
-	_, err := client.ProductTypeUpdateWithID(context.Background(), input)
-	if err != nil {
-		if ctErr, ok := err.(commercetools.ErrorResponse); ok {
-			log.Printf("[DEBUG] %v: %v", ctErr, stringFormatErrorExtras(ctErr))
-		}
-		return err
+		actions = append(actions, attributeChangeActions...)
 	}
-
-	return resourceProductTypeRead(d, m)
+	return actions, nil
 }
 
 func resourceProductTypeDelete(d *schema.ResourceData, m interface{}) error {
@@ -412,15 +579,16 @@ func resourceProductTypeDelete(d *schema.ResourceData, m interface{}) error {
 func resourceProductTypeAttributeChangeActions(oldValues []interface{}, newValues []interface{}) ([]commercetools.ProductTypeUpdateAction, error) {
 	oldLookup := createLookup(oldValues, "name")
 	newLookup := createLookup(newValues, "name")
-	newAttrDefinitions := []commercetools.AttributeDefinition{}
 	actions := []commercetools.ProductTypeUpdateAction{}
-	checkAttributeOrder := true
 
-	for name := range oldLookup {
+	// Removed attributes are removed one by one, in the order of the current
+	// attributes, the product type itself is never recreated since that's not
+	// possible while products use it
+	for _, value := range oldValues {
+		name := value.(map[string]interface{})["name"].(string)
 		if _, ok := newLookup[name]; !ok {
 			log.Printf("[DEBUG] Attribute deleted: %s", name)
 			actions = append(actions, commercetools.ProductTypeRemoveAttributeDefinitionAction{Name: name})
-			checkAttributeOrder = false
 		}
 	}
 
@@ -443,14 +611,11 @@ func resourceProductTypeAttributeChangeActions(oldValues []interface{}, newValue
 			return nil, err
 		}
 
-		newAttrDefinitions = append(newAttrDefinitions, attrDef)
-
 		if !existingField {
 			log.Printf("[DEBUG] Attribute added: %s", name)
 			actions = append(
 				actions,
 				commercetools.ProductTypeAddAttributeDefinitionAction{Attribute: &attrDefDraft})
-			checkAttributeOrder = false
 			continue
 		}
 
@@ -520,6 +685,7 @@ func resourceProductTypeAttributeChangeActions(oldValues []interface{}, newValue
 		}
 
 		if len(removeEnumKeys) > 0 {
+			sort.Strings(removeEnumKeys)
 			actions = append(
 				actions,
 				commercetools.ProductTypeRemoveEnumValuesAction{
@@ -530,23 +696,29 @@ func resourceProductTypeAttributeChangeActions(oldValues []interface{}, newValue
 
 	}
 
-	oldNames := make([]string, len(oldValues))
-	newNames := make([]string, len(newValues))
-
-	for i, value := range oldValues {
-		v := value.(map[string]interface{})
-		oldNames[i] = v["name"].(string)
+	// commercetools appends added attributes, so the order only needs to be
+	// changed when the remaining and added attributes aren't in the order of
+	// the configuration
+	expectedNames := []string{}
+	for _, value := range oldValues {
+		name := value.(map[string]interface{})["name"].(string)
+		if _, ok := newLookup[name]; ok {
+			expectedNames = append(expectedNames, name)
+		}
 	}
+	newNames := make([]string, len(newValues))
 	for i, value := range newValues {
-		v := value.(map[string]interface{})
-		newNames[i] = v["name"].(string)
+		newNames[i] = value.(map[string]interface{})["name"].(string)
+		if _, ok := oldLookup[newNames[i]]; !ok {
+			expectedNames = append(expectedNames, newNames[i])
+		}
 	}
 
-	if checkAttributeOrder && !reflect.DeepEqual(oldNames, newNames) {
+	if !reflect.DeepEqual(expectedNames, newNames) {
 		actions = append(
 			actions,
-			commercetools.ProductTypeChangeAttributeOrderAction{
-				Attributes: newAttrDefinitions,
+			commercetools.ProductTypeChangeAttributeOrderByNameAction{
+				AttributeNames: newNames,
 			})
 	}
 
@@ -559,9 +731,13 @@ func handleEnumTypeChanges(newFieldType commercetools.AttributeType, oldFieldTyp
 	if enumType, ok := newFieldType.(commercetools.AttributeEnumType); ok {
 		oldEnumV := oldFieldType["values"].(map[string]interface{})
 
+		for key, label := range oldEnumV {
+			oldEnumKeys[key] = label
+		}
+
 		for i, enumValue := range enumType.Values {
 			newEnumKeys[enumValue.Key] = enumValue
-			if _, ok := oldEnumV[enumValue.Key]; !ok {
+			if oldLabel, ok := oldEnumV[enumValue.Key]; !ok {
 				// Key does not appear in old enum values, so we'll add it
 				actions = append(
 					actions,
@@ -569,6 +745,13 @@ func handleEnumTypeChanges(newFieldType commercetools.AttributeType, oldFieldTyp
 						AttributeName: name,
 						Value:         &enumType.Values[i],
 					})
+			} else if oldLabel != enumValue.Label {
+				actions = append(
+					actions,
+					commercetools.ProductTypeChangePlainEnumValueLabelAction{
+						AttributeName: name,
+						NewValue:      &enumType.Values[i],
+					})
 			}
 		}
 
@@ -618,8 +801,7 @@ func handleEnumTypeChanges(newFieldType commercetools.AttributeType, oldFieldTyp
 	return actions
 }
 
-func resourceProductTypeGetAttributeDefinitions(d *schema.ResourceData) ([]commercetools.AttributeDefinitionDraft, error) {
-	input := d.Get("attribute").([]interface{})
+func resourceProductTypeGetAttributeDefinitions(input []interface{}) ([]commercetools.AttributeDefinitionDraft, error) {
 	var result []commercetools.AttributeDefinitionDraft
 
 	for _, raw := range input {
@@ -739,7 +921,7 @@ func getAttributeType(input interface{}) (commercetools.AttributeType, error) {
 		return commercetools.AttributeDateTimeType{}, nil
 	case "reference":
 		refTypeID, refTypeIDOk := config["reference_type_id"].(string)
-		if !refTypeIDOk {
+		if !refTypeIDOk || refTypeID == "" {
 			return nil, fmt.Errorf("No reference_type_id specified for Reference type")
 		}
 		return commercetools.AttributeReferenceType{
@@ -747,8 +929,8 @@ func getAttributeType(input interface{}) (commercetools.AttributeType, error) {
 		}, nil
 	case "nested":
 		typeReference, typeReferenceOk := config["type_reference"].(string)
-		if !typeReferenceOk {
-			return nil, fmt.Errorf("No type_reference specified for Nested type")
+		if !typeReferenceOk || typeReference == "" {
+			return nil, fmt.Errorf("No type_reference or type_reference_key specified for Nested type")
 		}
 		return commercetools.AttributeNestedType{
 			TypeReference: &commercetools.ProductTypeReference{ID: typeReference},
@@ -787,3 +969,99 @@ func readAttributeLocalizedEnum(values []commercetools.AttributeLocalizedEnumVal
 	log.Printf("[DEBUG] readLocalizedEnum values: %#v", enumValues)
 	return enumValues
 }
+
+// resolveNestedTypeReferences returns a copy of the attributes in which the
+// nested types referencing a product type by key reference it by id, since
+// commercetools only accepts ids. It fails when a referenced product type
+// doesn't exist.
+func resolveNestedTypeReferences(client *commercetools.Client, attributes []interface{}) ([]interface{}, error) {
+	ids := map[string]string{}
+	result := make([]interface{}, len(attributes))
+	for i, raw := range attributes {
+		attribute := copyStringInterfaceMap(raw.(map[string]interface{}))
+		types := attribute["type"].([]interface{})
+		if len(types) > 0 {
+			attrType, err := resolveNestedTypeReference(client, attribute["name"].(string), types[0].(map[string]interface{}), ids)
+			if err != nil {
+				return nil, err
+			}
+			attribute["type"] = []interface{}{attrType}
+		}
+		result[i] = attribute
+	}
+	return result, nil
+}
+
+func resolveNestedTypeReference(client *commercetools.Client, name string, input map[string]interface{}, ids map[string]string) (map[string]interface{}, error) {
+	attrType := copyStringInterfaceMap(input)
+
+	switch attrType["name"] {
+	case "nested":
+		key, _ := attrType["type_reference_key"].(string)
+		id, _ := attrType["type_reference"].(string)
+		if key != "" {
+			if _, ok := ids[key]; !ok {
+				productType, err := client.ProductTypeGetWithKey(context.Background(), key)
+				if err != nil {
+					return nil, nestedTypeReferenceError(name, "key", key, err)
+				}
+				ids[key] = productType.ID
+			}
+			attrType["type_reference"] = ids[key]
+		} else if id != "" {
+			if _, err := client.ProductTypeGetWithID(context.Background(), id); err != nil {
+				return nil, nestedTypeReferenceError(name, "id", id, err)
+			}
+		}
+	case "set":
+		elementTypes, _ := attrType["element_type"].([]interface{})
+		if len(elementTypes) > 0 {
+			elementType, err := resolveNestedTypeReference(client, name, elementTypes[0].(map[string]interface{}), ids)
+			if err != nil {
+				return nil, err
+			}
+			attrType["element_type"] = []interface{}{elementType}
+		}
+	}
+	return attrType, nil
+}
+
+func nestedTypeReferenceError(name string, field string, value string, err error) error {
+	if ctErr, ok := err.(commercetools.ErrorResponse); ok && ctErr.StatusCode == 404 {
+		return fmt.Errorf("attribute %s references the product type with %s %s, which does not exist", name, field, value)
+	}
+	return err
+}
+
+// preserveNestedTypeKeys sets the type_reference_key of nested types to the
+// key in the current state, commercetools only returns the id of the
+// referenced product type
+func preserveNestedTypeKeys(attributes []map[string]interface{}, current []interface{}) {
+	currentLookup := createLookup(current, "name")
+	for _, attribute := range attributes {
+		currentAttribute, ok := currentLookup[attribute["name"].(string)].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		types := attribute["type"].([]interface{})
+		currentTypes, _ := currentAttribute["type"].([]interface{})
+		if len(types) > 0 && len(currentTypes) > 0 {
+			preserveNestedTypeKey(types[0].(map[string]interface{}), currentTypes[0].(map[string]interface{}))
+		}
+	}
+}
+
+func preserveNestedTypeKey(attrType map[string]interface{}, current map[string]interface{}) {
+	switch attrType["name"] {
+	case "nested":
+		if attrType["type_reference"] == current["type_reference"] {
+			attrType["type_reference_key"] = current["type_reference_key"]
+		}
+	case "set":
+		elementTypes, _ := attrType["element_type"].([]interface{})
+		currentElementTypes, _ := current["element_type"].([]interface{})
+		if len(elementTypes) > 0 && len(currentElementTypes) > 0 {
+			preserveNestedTypeKey(elementTypes[0].(map[string]interface{}), currentElementTypes[0].(map[string]interface{}))
+		}
+	}
+}
@@ -2,6 +2,8 @@ package commercetools
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -105,6 +107,254 @@ func TestGetAttributeType(t *testing.T) {
 	}
 }
 
+func testProductTypeAttribute(name string) map[string]interface{} {
+	return map[string]interface{}{
+		"name":       name,
+		"label":      map[string]interface{}{"en": name},
+		"required":   false,
+		"constraint": "None",
+		"input_tip":  map[string]interface{}{},
+		"input_hint": "SingleLine",
+		"searchable": false,
+		"type":       []interface{}{map[string]interface{}{"name": "text"}},
+	}
+}
+
+func TestResourceProductTypeAttributeChangeActionsOrder(t *testing.T) {
+	a, b, c := testProductTypeAttribute("a"), testProductTypeAttribute("b"), testProductTypeAttribute("c")
+
+	// Only the order is changed
+	actions, err := resourceProductTypeAttributeChangeActions(
+		[]interface{}{a, b, c}, []interface{}{c, a, b})
+	assert.NoError(t, err)
+	assert.Equal(t, []commercetools.ProductTypeUpdateAction{
+		commercetools.ProductTypeChangeAttributeOrderByNameAction{AttributeNames: []string{"c", "a", "b"}},
+	}, actions)
+
+	// Added attributes are appended, so no reorder is needed
+	actions, err = resourceProductTypeAttributeChangeActions(
+		[]interface{}{a, b}, []interface{}{a, b, c})
+	assert.NoError(t, err)
+	assert.Len(t, actions, 1)
+	assert.IsType(t, commercetools.ProductTypeAddAttributeDefinitionAction{}, actions[0])
+
+	// An attribute added in between is moved after adding it
+	actions, err = resourceProductTypeAttributeChangeActions(
+		[]interface{}{a, c}, []interface{}{a, b, c})
+	assert.NoError(t, err)
+	assert.Len(t, actions, 2)
+	assert.Equal(t,
+		commercetools.ProductTypeChangeAttributeOrderByNameAction{AttributeNames: []string{"a", "b", "c"}},
+		actions[1])
+
+	// Removing an attribute keeps the order of the others
+	actions, err = resourceProductTypeAttributeChangeActions(
+		[]interface{}{a, b, c}, []interface{}{a, c})
+	assert.NoError(t, err)
+	assert.Equal(t, []commercetools.ProductTypeUpdateAction{
+		commercetools.ProductTypeRemoveAttributeDefinitionAction{Name: "b"},
+	}, actions)
+}
+
+func TestResourceProductTypeAttributeChangeActionsEnumValues(t *testing.T) {
+	enumAttribute := func(values map[string]interface{}) map[string]interface{} {
+		attribute := testProductTypeAttribute("color")
+		attribute["type"] = []interface{}{map[string]interface{}{"name": "enum", "values": values}}
+		return attribute
+	}
+
+	// A changed label is updated in place, removed values are removed in a
+	// single action
+	actions, err := resourceProductTypeAttributeChangeActions(
+		[]interface{}{enumAttribute(map[string]interface{}{"red": "Red", "green": "Green", "blue": "Blue"})},
+		[]interface{}{enumAttribute(map[string]interface{}{"red": "Bright red"})})
+	assert.NoError(t, err)
+	assert.Equal(t, []commercetools.ProductTypeUpdateAction{
+		commercetools.ProductTypeChangePlainEnumValueLabelAction{
+			AttributeName: "color",
+			NewValue:      &commercetools.AttributePlainEnumValue{Key: "red", Label: "Bright red"},
+		},
+		commercetools.ProductTypeRemoveEnumValuesAction{AttributeName: "color", Keys: []string{"blue", "green"}},
+	}, actions)
+
+	lenumAttribute := func(label string) map[string]interface{} {
+		attribute := testProductTypeAttribute("size")
+		attribute["type"] = []interface{}{map[string]interface{}{
+			"name": "lenum",
+			"localized_value": []interface{}{
+				map[string]interface{}{"key": "small", "label": map[string]interface{}{"en": label}},
+			},
+		}}
+		return attribute
+	}
+
+	actions, err = resourceProductTypeAttributeChangeActions(
+		[]interface{}{lenumAttribute("Small")}, []interface{}{lenumAttribute("S")})
+	assert.NoError(t, err)
+	assert.Len(t, actions, 1)
+	assert.IsType(t, commercetools.ProductTypeChangeLocalizedEnumValueLabelAction{}, actions[0])
+}
+
+func TestResourceProductTypeAttributeChangeActionsRemove(t *testing.T) {
+	a, b, c, d := testProductTypeAttribute("a"), testProductTypeAttribute("b"),
+		testProductTypeAttribute("c"), testProductTypeAttribute("d")
+
+	actions, err := resourceProductTypeAttributeChangeActions(
+		[]interface{}{a, b, c, d}, []interface{}{a, c})
+	assert.NoError(t, err)
+	assert.Equal(t, []commercetools.ProductTypeUpdateAction{
+		commercetools.ProductTypeRemoveAttributeDefinitionAction{Name: "b"},
+		commercetools.ProductTypeRemoveAttributeDefinitionAction{Name: "d"},
+	}, actions)
+
+	// Removing attributes never recreates the product type
+	for name, attribute := range resourceProductType().Schema {
+		assert.False(t, attribute.ForceNew, name)
+	}
+}
+
+func TestResolveNestedTypeReferences(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/my-project/product-types/key=dimensions", "/my-project/product-types/existing-id":
+			w.Write([]byte(`{"id": "dimensions-id", "version": 1}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"statusCode": 404, "message": "not found", "errors": []}`))
+		}
+	}))
+	defer server.Close()
+
+	client := commercetools.New(&commercetools.Config{
+		ProjectKey: "my-project",
+		URL:        server.URL,
+		HTTPClient: server.Client(),
+	})
+
+	attributes := []interface{}{
+		map[string]interface{}{
+			"name": "size",
+			"type": []interface{}{map[string]interface{}{
+				"name":               "nested",
+				"type_reference":     "",
+				"type_reference_key": "dimensions",
+			}},
+		},
+		map[string]interface{}{
+			"name": "sizes",
+			"type": []interface{}{map[string]interface{}{
+				"name": "set",
+				"element_type": []interface{}{map[string]interface{}{
+					"name":           "nested",
+					"type_reference": "existing-id",
+				}},
+			}},
+		},
+	}
+	result, err := resolveNestedTypeReferences(client, attributes)
+	assert.NoError(t, err)
+
+	attrType := result[0].(map[string]interface{})["type"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "dimensions-id", attrType["type_reference"])
+	// The attributes read from the resource data are not modified
+	original := attributes[0].(map[string]interface{})["type"].([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "", original["type_reference"])
+
+	attributes[0].(map[string]interface{})["type"] = []interface{}{map[string]interface{}{
+		"name":               "nested",
+		"type_reference_key": "unknown",
+	}}
+	_, err = resolveNestedTypeReferences(client, attributes)
+	assert.EqualError(t, err, "attribute size references the product type with key unknown, which does not exist")
+}
+
+func TestPreserveNestedTypeKeys(t *testing.T) {
+	attributes := []map[string]interface{}{
+		{
+			"name": "size",
+			"type": []interface{}{map[string]interface{}{"name": "nested", "type_reference": "dimensions-id"}},
+		},
+		{
+			"name": "other",
+			"type": []interface{}{map[string]interface{}{"name": "nested", "type_reference": "new-id"}},
+		},
+	}
+	current := []interface{}{
+		map[string]interface{}{
+			"name": "size",
+			"type": []interface{}{map[string]interface{}{
+				"name": "nested", "type_reference": "dimensions-id", "type_reference_key": "dimensions"}},
+		},
+		map[string]interface{}{
+			"name": "other",
+			"type": []interface{}{map[string]interface{}{
+				"name": "nested", "type_reference": "old-id", "type_reference_key": "other"}},
+		},
+	}
+	preserveNestedTypeKeys(attributes, current)
+
+	assert.Equal(t, "dimensions", attributes[0]["type"].([]interface{})[0].(map[string]interface{})["type_reference_key"])
+	// The referenced product type was changed outside of terraform
+	assert.Nil(t, attributes[1]["type"].([]interface{})[0].(map[string]interface{})["type_reference_key"])
+}
+
+func TestValidateAttributeReferenceType(t *testing.T) {
+	assert.NoError(t, validateAttributeReferenceType("related", map[string]interface{}{
+		"name": "reference", "reference_type_id": "product"}))
+	assert.EqualError(t,
+		validateAttributeReferenceType("related", map[string]interface{}{
+			"name": "set",
+			"element_type": []interface{}{map[string]interface{}{
+				"name": "reference", "reference_type_id": ""}},
+		}),
+		"Error on the 'related' attribute: reference_type_id is required for the reference type, supported values are cart, category, channel, customer, key-value-document, order, product, product-type, review, shipping-method, state, zone")
+
+	_, errs := attributeTypeElement(true).Schema["reference_type_id"].ValidateFunc("products", "reference_type_id")
+	assert.Len(t, errs, 1)
+
+	// The reference type id is read as a plain string
+	attrType, err := resourceProductTypeReadAttributeType(
+		commercetools.AttributeReferenceType{ReferenceTypeID: commercetools.ReferenceTypeIDProduct}, true)
+	assert.NoError(t, err)
+	assert.Equal(t, "product", attrType[0].(map[string]interface{})["reference_type_id"])
+}
+
+func TestValidateAttributeTypeChange(t *testing.T) {
+	text := map[string]interface{}{"name": "text"}
+	ltext := map[string]interface{}{"name": "ltext"}
+	setOf := func(elementType map[string]interface{}) map[string]interface{} {
+		return map[string]interface{}{"name": "set", "element_type": []interface{}{elementType}}
+	}
+	reference := func(referenceTypeID string) map[string]interface{} {
+		return map[string]interface{}{"name": "reference", "reference_type_id": referenceTypeID}
+	}
+
+	assert.NoError(t, validateAttributeTypeChange("color", text, text))
+	assert.NoError(t, validateAttributeTypeChange("color", setOf(reference("product")), setOf(reference("product"))))
+	assert.NoError(t, validateAttributeTypeChange("color",
+		map[string]interface{}{"name": "enum", "values": map[string]interface{}{"red": "Red"}},
+		map[string]interface{}{"name": "enum", "values": map[string]interface{}{"blue": "Blue"}}))
+
+	assert.EqualError(t,
+		validateAttributeTypeChange("color", text, ltext),
+		"Error on the 'color' attribute: The type can't be changed from text to ltext, commercetools doesn't support changing the type of an attribute. "+
+			"To migrate, add an attribute with a new name and the new type, copy the values of the products to it and remove the 'color' attribute afterwards")
+	assert.Error(t, validateAttributeTypeChange("color", setOf(text), setOf(ltext)))
+	assert.Error(t, validateAttributeTypeChange("related", reference("product"), reference("category")))
+	assert.Error(t, validateAttributeTypeChange("size",
+		map[string]interface{}{"name": "nested", "type_reference": "a", "type_reference_key": ""},
+		map[string]interface{}{"name": "nested", "type_reference": "b", "type_reference_key": ""}))
+}
+
+func TestValidateAttributeConstraintChange(t *testing.T) {
+	assert.NoError(t, validateAttributeConstraintChange("size", "SameForAll", "None"))
+	assert.NoError(t, validateAttributeConstraintChange("size", "Unique", "Unique"))
+	assert.EqualError(t,
+		validateAttributeConstraintChange("size", "None", "SameForAll"),
+		"Error on the 'size' attribute: The constraint can only be changed to None, changing it from None to SameForAll is not supported. Consider removing the attribute first and then re-adding it")
+	assert.Error(t, validateAttributeConstraintChange("size", "Unique", "CombinationUnique"))
+}
+
 func TestAccProductTypes_basic(t *testing.T) {
 	name := "acctest_producttype"
 	resource.Test(t, resource.TestCase{
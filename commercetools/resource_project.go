@@ -1,10 +1,16 @@
 package commercetools
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"log"
+	"strconv"
+	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/labd/commercetools-go-sdk/commercetools"
 )
 
@@ -46,20 +52,29 @@ func resourceProjectSettings() *schema.Resource {
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
 			"messages": {
-				Type:     schema.TypeMap,
-				Optional: true,
+				Type:         schema.TypeMap,
+				Optional:     true,
+				ValidateFunc: validateProjectMessages,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"enabled": {
 							Type:     schema.TypeBool,
 							Required: true,
 						},
+						"delete_days_after_creation": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
 					},
 				},
 			},
 			"external_oauth": {
 				Type:     schema.TypeMap,
 				Optional: true,
+				// The authorization header is a secret, since it is stored in
+				// a map the complete configuration is marked as sensitive.
+				Sensitive:    true,
+				ValidateFunc: validateProjectExternalOAuth,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"url": {
@@ -74,22 +89,607 @@ func resourceProjectSettings() *schema.Resource {
 				},
 			},
 			"carts": {
-				Type:     schema.TypeMap,
-				Optional: true,
+				Type:         schema.TypeMap,
+				Optional:     true,
+				ValidateFunc: validateProjectCarts,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"country_tax_rate_fallback_enabled": {
 							Type:     schema.TypeBool,
-							Required: true,
+							Optional: true,
+						},
+						"delete_days_after_last_modification": {
+							Type:     schema.TypeInt,
+							Optional: true,
+						},
+						"price_rounding_mode": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+						"tax_rounding_mode": {
+							Type:     schema.TypeString,
+							Optional: true,
 						},
 					}},
 			},
+			"shipping_rate_input_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					shippingRateInputTypeCartValue,
+					shippingRateInputTypeCartScore,
+					shippingRateInputTypeCartClassification,
+				}, false),
+			},
+			"shipping_rate_cart_classification_value": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"key": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"label": {
+							Type:     TypeLocalizedString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"business_units": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"my_business_unit_status_on_creation": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								businessUnitStatusActive,
+								businessUnitStatusInactive,
+							}, false),
+						},
+						"my_business_unit_associate_role_key_on_creation": {
+							Type:     schema.TypeString,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"search_indexing": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						searchIndexProducts: {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						searchIndexOrders: {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						searchIndexCustomers: {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+						searchIndexBusinessUnits: {
+							Type:     schema.TypeBool,
+							Optional: true,
+						},
+					},
+				},
+			},
+			"search_indexing_status": {
+				Type:     schema.TypeMap,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+			"allow_removals": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Allow removing languages, currencies or countries from the project",
+			},
+			"force": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Allow removing languages, currencies or countries which are still in use",
+			},
 			"version": {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
 		},
+		CustomizeDiff: customdiff.All(
+			resourceProjectValidateRemovals,
+			resourceProjectValidateShippingRateInputType,
+		),
+	}
+}
+
+const (
+	shippingRateInputTypeCartValue          = "CartValue"
+	shippingRateInputTypeCartScore          = "CartScore"
+	shippingRateInputTypeCartClassification = "CartClassification"
+)
+
+// roundingModes are the rounding modes supported for prices and taxes
+var roundingModes = []string{"HalfEven", "HalfUp", "HalfDown"}
+
+// projectRestSettings are the settings of the project which are not (fully)
+// supported by the commercetools-go-sdk, these are read with the restClient
+type projectRestSettings struct {
+	searchIndexingProject
+	Carts         *projectCartsConfiguration         `json:"carts,omitempty"`
+	BusinessUnits *projectBusinessUnitsConfiguration `json:"businessUnits,omitempty"`
+}
+
+// projectCartsConfiguration is the carts configuration of the project. The
+// commercetools-go-sdk only supports the country tax rate fallback.
+type projectCartsConfiguration struct {
+	CountryTaxRateFallbackEnabled   bool   `json:"countryTaxRateFallbackEnabled"`
+	DeleteDaysAfterLastModification int    `json:"deleteDaysAfterLastModification,omitempty"`
+	PriceRoundingMode               string `json:"priceRoundingMode,omitempty"`
+	TaxRoundingMode                 string `json:"taxRoundingMode,omitempty"`
+}
+
+type projectChangeCartsConfigurationAction struct {
+	CartsConfiguration projectCartsConfiguration `json:"cartsConfiguration"`
+}
+
+// MarshalJSON override to set the discriminator value
+func (obj projectChangeCartsConfigurationAction) MarshalJSON() ([]byte, error) {
+	type Alias projectChangeCartsConfigurationAction
+	return json.Marshal(struct {
+		Action string `json:"action"`
+		*Alias
+	}{Action: "changeCartsConfiguration", Alias: (*Alias)(&obj)})
+}
+
+const (
+	businessUnitStatusActive   = "Active"
+	businessUnitStatusInactive = "Inactive"
+)
+
+type projectBusinessUnitsConfiguration struct {
+	MyBusinessUnitStatusOnCreation        string                     `json:"myBusinessUnitStatusOnCreation,omitempty"`
+	MyBusinessUnitAssociateRoleOnCreation *associateRoleKeyReference `json:"myBusinessUnitAssociateRoleOnCreation,omitempty"`
+}
+
+type associateRoleKeyReference struct {
+	TypeID string `json:"typeId"`
+	Key    string `json:"key"`
+}
+
+func flattenProjectBusinessUnits(config *projectBusinessUnitsConfiguration) []map[string]interface{} {
+	if config == nil {
+		return []map[string]interface{}{}
+	}
+	roleKey := ""
+	if config.MyBusinessUnitAssociateRoleOnCreation != nil {
+		roleKey = config.MyBusinessUnitAssociateRoleOnCreation.Key
+	}
+	return []map[string]interface{}{
+		{
+			"my_business_unit_status_on_creation":             config.MyBusinessUnitStatusOnCreation,
+			"my_business_unit_associate_role_key_on_creation": roleKey,
+		},
+	}
+}
+
+// projectBusinessUnitsActions returns the update actions for the changes of
+// the business units configuration
+func projectBusinessUnitsActions(d *schema.ResourceData) []commercetools.ProjectUpdateAction {
+	actions := []commercetools.ProjectUpdateAction{}
+	status := d.Get("business_units.0.my_business_unit_status_on_creation").(string)
+	roleKey := d.Get("business_units.0.my_business_unit_associate_role_key_on_creation").(string)
+
+	if d.HasChange("business_units.0.my_business_unit_status_on_creation") && status != "" {
+		actions = append(actions, map[string]interface{}{
+			"action": "changeMyBusinessUnitStatusOnCreation",
+			"status": status,
+		})
+	}
+	if d.HasChange("business_units.0.my_business_unit_associate_role_key_on_creation") {
+		action := map[string]interface{}{
+			"action": "setMyBusinessUnitAssociateRoleOnCreation",
+		}
+		if roleKey != "" {
+			action["associateRole"] = associateRoleKeyReference{TypeID: "associate-role", Key: roleKey}
+		}
+		actions = append(actions, action)
+	}
+	return actions
+}
+
+// validateProjectMessages validates the values of the messages map
+func validateProjectMessages(val interface{}, key string) (warns []string, errs []error) {
+	messages := val.(map[string]interface{})
+	for name, value := range messages {
+		raw := fmt.Sprint(value)
+		switch name {
+		case "enabled":
+			if _, err := strconv.ParseBool(raw); err != nil {
+				errs = append(errs, fmt.Errorf("%s.%s must be true or false, got %q", key, name, raw))
+			}
+		case "delete_days_after_creation":
+			if days, err := strconv.Atoi(raw); err != nil || days < 1 || days > 90 {
+				errs = append(errs, fmt.Errorf("%s.%s must be between 1 and 90, got %q", key, name, raw))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("%s contains unsupported field %s", key, name))
+		}
+	}
+	if _, ok := messages["enabled"]; len(messages) > 0 && !ok {
+		errs = append(errs, fmt.Errorf("%s.enabled is required", key))
+	}
+	return
+}
+
+// flattenProjectMessages returns the messages configuration for the state.
+// Only the fields in the current state are returned, since commercetools
+// returns the defaults when the messages are not configured.
+func flattenProjectMessages(config *commercetools.MessageConfiguration, current map[string]interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	if config == nil || len(current) == 0 {
+		return result
+	}
+	result["enabled"] = strconv.FormatBool(config.Enabled)
+	if _, ok := current["delete_days_after_creation"]; ok {
+		result["delete_days_after_creation"] = strconv.Itoa(int(config.DeleteDaysAfterCreation))
+	}
+	return result
+}
+
+// validateProjectCarts validates the values of the carts map, since the
+// values of a map are always strings
+func validateProjectCarts(val interface{}, key string) (warns []string, errs []error) {
+	for name, value := range val.(map[string]interface{}) {
+		raw := fmt.Sprint(value)
+		switch name {
+		case "country_tax_rate_fallback_enabled":
+			if _, err := strconv.ParseBool(raw); err != nil {
+				errs = append(errs, fmt.Errorf("%s.%s must be true or false, got %q", key, name, raw))
+			}
+		case "delete_days_after_last_modification":
+			if days, err := strconv.Atoi(raw); err != nil || days < 1 {
+				errs = append(errs, fmt.Errorf("%s.%s must be a positive number, got %q", key, name, raw))
+			}
+		case "price_rounding_mode", "tax_rounding_mode":
+			if !stringInSlice(raw, roundingModes) {
+				errs = append(errs, fmt.Errorf("%s.%s must be one of %q, got %q", key, name, roundingModes, raw))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("%s contains unsupported field %s", key, name))
+		}
+	}
+	return
+}
+
+// expandProjectCarts returns the carts configuration. Fields which are not
+// set are reset to the defaults of commercetools.
+func expandProjectCarts(carts map[string]interface{}) projectCartsConfiguration {
+	config := projectCartsConfiguration{}
+	if value, ok := carts["country_tax_rate_fallback_enabled"]; ok {
+		config.CountryTaxRateFallbackEnabled, _ = strconv.ParseBool(fmt.Sprint(value))
+	}
+	if value, ok := carts["delete_days_after_last_modification"]; ok {
+		config.DeleteDaysAfterLastModification, _ = strconv.Atoi(fmt.Sprint(value))
+	}
+	if value, ok := carts["price_rounding_mode"]; ok {
+		config.PriceRoundingMode = fmt.Sprint(value)
+	}
+	if value, ok := carts["tax_rounding_mode"]; ok {
+		config.TaxRoundingMode = fmt.Sprint(value)
+	}
+	return config
+}
+
+// flattenProjectCarts returns the carts configuration for the state. Only the
+// fields which are in the current state are returned, since commercetools
+// returns the default values for the fields which are not configured.
+func flattenProjectCarts(config *projectCartsConfiguration, current map[string]interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	if config == nil {
+		return result
+	}
+	values := map[string]string{
+		"country_tax_rate_fallback_enabled":   strconv.FormatBool(config.CountryTaxRateFallbackEnabled),
+		"delete_days_after_last_modification": strconv.Itoa(config.DeleteDaysAfterLastModification),
+		"price_rounding_mode":                 config.PriceRoundingMode,
+		"tax_rounding_mode":                   config.TaxRoundingMode,
+	}
+	for name := range current {
+		if value, ok := values[name]; ok {
+			result[name] = value
+		}
+	}
+	return result
+}
+
+// resourceProjectValidateShippingRateInputType makes sure the cart
+// classification values are only set for the CartClassification type, which
+// requires at least one value
+func resourceProjectValidateShippingRateInputType(d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("shipping_rate_input_type") || !d.NewValueKnown("shipping_rate_cart_classification_value") {
+		return nil
+	}
+	inputType := d.Get("shipping_rate_input_type").(string)
+	values := d.Get("shipping_rate_cart_classification_value").([]interface{})
+	if inputType == shippingRateInputTypeCartClassification && len(values) == 0 {
+		return fmt.Errorf("shipping_rate_cart_classification_value is required for the %s shipping rate input type", inputType)
 	}
+	if inputType != shippingRateInputTypeCartClassification && len(values) > 0 {
+		return fmt.Errorf("shipping_rate_cart_classification_value can only be set for the %s shipping rate input type",
+			shippingRateInputTypeCartClassification)
+	}
+	return nil
+}
+
+func expandProjectShippingRateInputType(d *schema.ResourceData) commercetools.ShippingRateInputType {
+	switch d.Get("shipping_rate_input_type").(string) {
+	case shippingRateInputTypeCartValue:
+		return commercetools.CartValueType{}
+	case shippingRateInputTypeCartScore:
+		return commercetools.CartScoreType{}
+	case shippingRateInputTypeCartClassification:
+		values := []commercetools.CustomFieldLocalizedEnumValue{}
+		for _, raw := range d.Get("shipping_rate_cart_classification_value").([]interface{}) {
+			value := raw.(map[string]interface{})
+			label := commercetools.LocalizedString(
+				expandStringMap(value["label"].(map[string]interface{})))
+			values = append(values, commercetools.CustomFieldLocalizedEnumValue{
+				Key:   value["key"].(string),
+				Label: &label,
+			})
+		}
+		return commercetools.CartClassificationType{Values: values}
+	}
+	return nil
+}
+
+// flattenProjectShippingRateInputType returns the name of the shipping rate
+// input type and the values of the cart classification
+func flattenProjectShippingRateInputType(inputType commercetools.ShippingRateInputType) (string, []map[string]interface{}) {
+	values := []map[string]interface{}{}
+	switch t := inputType.(type) {
+	case commercetools.CartValueType:
+		return shippingRateInputTypeCartValue, values
+	case commercetools.CartScoreType:
+		return shippingRateInputTypeCartScore, values
+	case commercetools.CartClassificationType:
+		for _, value := range t.Values {
+			label := map[string]string{}
+			if value.Label != nil {
+				label = *value.Label
+			}
+			values = append(values, map[string]interface{}{
+				"key":   value.Key,
+				"label": label,
+			})
+		}
+		return shippingRateInputTypeCartClassification, values
+	}
+	return "", values
+}
+
+// validateProjectExternalOAuth makes sure both the url and authorization
+// header are set, since the values of a map are not validated by the schema
+func validateProjectExternalOAuth(val interface{}, key string) (warns []string, errs []error) {
+	config := val.(map[string]interface{})
+	if len(config) == 0 {
+		return
+	}
+	for name := range config {
+		if name != "url" && name != "authorization_header" {
+			errs = append(errs, fmt.Errorf("%s contains unsupported field %s", key, name))
+		}
+	}
+	for _, name := range []string{"url", "authorization_header"} {
+		if value, _ := config[name].(string); value == "" {
+			errs = append(errs, fmt.Errorf("%s.%s is required", key, name))
+		}
+	}
+	return
+}
+
+// flattenProjectExternalOAuth returns the external OAuth configuration for the
+// state. commercetools masks the authorization header, so it is kept from the
+// current state.
+func flattenProjectExternalOAuth(externalOAuth *commercetools.ExternalOAuth, current map[string]interface{}) map[string]interface{} {
+	if externalOAuth == nil {
+		return map[string]interface{}{}
+	}
+	authorizationHeader, _ := current["authorization_header"].(string)
+	return map[string]interface{}{
+		"url":                  externalOAuth.URL,
+		"authorization_header": authorizationHeader,
+	}
+}
+
+// flattenProjectSearchIndexing returns the status of the search indexes and
+// the toggles of the search_indexing block. An index which is still indexing
+// is reported as active.
+func flattenProjectSearchIndexing(project *searchIndexingProject) (map[string]string, []map[string]interface{}) {
+	status := map[string]string{}
+	toggles := map[string]interface{}{}
+	for _, index := range searchIndexes {
+		value := project.searchIndex(index).Status
+		status[index] = value
+		toggles[index] = value != searchIndexStatusDeactivated
+	}
+	return status, []map[string]interface{}{toggles}
+}
+
+// projectRemovalChecks maps the list attributes of the project to the check
+// which reports whether a removed value is still used
+var projectRemovalChecks = map[string]func(client *commercetools.Client, value string) (bool, error){
+	"languages":  projectLanguageInUse,
+	"currencies": projectCurrencyInUse,
+	"countries":  projectCountryInUse,
+}
+
+// projectRemovalAttributes are the attributes of projectRemovalChecks in the
+// order in which they are validated
+var projectRemovalAttributes = []string{"languages", "currencies", "countries"}
+
+// resourceProjectValidateRemovals guards against removing languages,
+// currencies or countries from the project. The new configuration is compared
+// with the remote project, so values added outside of Terraform are detected
+// as well. Removals are only allowed when allow_removals is set, and even then
+// values which are still in use can only be removed with force. commercetools
+// allows these removals, but the localized content or prices using them can
+// no longer be managed afterwards.
+func resourceProjectValidateRemovals(d *schema.ResourceDiff, m interface{}) error {
+	if m == nil || d.Id() == "" {
+		return nil
+	}
+
+	changed := false
+	for _, attribute := range projectRemovalAttributes {
+		if d.HasChange(attribute) && d.NewValueKnown(attribute) {
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	client := getClient(m)
+	project, err := client.ProjectGet()
+	if err != nil {
+		return err
+	}
+
+	for _, attribute := range projectRemovalAttributes {
+		if !d.NewValueKnown(attribute) {
+			continue
+		}
+		removed := projectRemovedValues(project, attribute, d.Get(attribute).([]interface{}))
+		if len(removed) == 0 {
+			continue
+		}
+		if !d.Get("allow_removals").(bool) {
+			return fmt.Errorf(
+				"the configuration removes %s %s from the project, set allow_removals = true to remove them",
+				attribute, strings.Join(removed, ", "))
+		}
+		log.Printf("[WARN] The following %s will be removed from the project: %s", attribute, strings.Join(removed, ", "))
+
+		if d.Get("force").(bool) {
+			continue
+		}
+		for _, value := range removed {
+			used, err := projectRemovalChecks[attribute](client, value)
+			if err != nil {
+				return err
+			}
+			if used {
+				return fmt.Errorf(
+					"%s %s is still in use and can't be removed from the project settings, set force = true to remove it anyway",
+					attribute, value)
+			}
+		}
+	}
+	return nil
+}
+
+// projectRemovedValues returns the values of the given list attribute of the
+// remote project which are not in the new configuration
+func projectRemovedValues(project *commercetools.Project, attribute string, new []interface{}) []string {
+	old := []interface{}{}
+	switch attribute {
+	case "languages":
+		for _, value := range project.Languages {
+			old = append(old, string(value))
+		}
+	case "currencies":
+		for _, value := range project.Currencies {
+			old = append(old, string(value))
+		}
+	case "countries":
+		for _, value := range project.Countries {
+			old = append(old, string(value))
+		}
+	}
+	return removedStrings(old, new)
+}
+
+// removedStrings returns the values in old which are not in new
+func removedStrings(old []interface{}, new []interface{}) []string {
+	lookup := make(map[string]bool, len(new))
+	for _, value := range new {
+		lookup[value.(string)] = true
+	}
+
+	removed := []string{}
+	for _, value := range old {
+		if !lookup[value.(string)] {
+			removed = append(removed, value.(string))
+		}
+	}
+	return removed
+}
+
+func projectLanguageInUse(client *commercetools.Client, language string) (bool, error) {
+	products, err := client.ProductQuery(context.Background(), &commercetools.QueryInput{
+		Where: fmt.Sprintf("masterData(staged(name(%s is defined)))", language),
+		Limit: 1,
+	})
+	if err != nil {
+		return false, err
+	}
+	if len(products.Results) > 0 {
+		return true, nil
+	}
+
+	categories, err := client.CategoryQuery(context.Background(), &commercetools.QueryInput{
+		Where: fmt.Sprintf("name(%s is defined)", language),
+		Limit: 1,
+	})
+	if err != nil {
+		return false, err
+	}
+	return len(categories.Results) > 0, nil
+}
+
+func projectCurrencyInUse(client *commercetools.Client, currency string) (bool, error) {
+	products, err := client.ProductQuery(context.Background(), &commercetools.QueryInput{
+		Where: fmt.Sprintf("masterData(staged(masterVariant(prices(value(currencyCode = %q)))))", currency),
+		Limit: 1,
+	})
+	if err != nil {
+		return false, err
+	}
+	return len(products.Results) > 0, nil
+}
+
+func projectCountryInUse(client *commercetools.Client, country string) (bool, error) {
+	products, err := client.ProductQuery(context.Background(), &commercetools.QueryInput{
+		Where: fmt.Sprintf("masterData(staged(masterVariant(prices(country = %q))))", country),
+		Limit: 1,
+	})
+	if err != nil {
+		return false, err
+	}
+	if len(products.Results) > 0 {
+		return true, nil
+	}
+
+	zones, err := client.ZoneQuery(context.Background(), &commercetools.QueryInput{
+		Where: fmt.Sprintf("locations(country = %q)", country),
+		Limit: 1,
+	})
+	if err != nil {
+		return false, err
+	}
+	return len(zones.Results) > 0, nil
 }
 
 func resourceProjectExists(d *schema.ResourceData, m interface{}) (bool, error) {
@@ -143,7 +743,7 @@ func resourceProjectRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	log.Print("[DEBUG] Found the following project:")
-	log.Print(stringFormatObject(project))
+	log.Print(stringFormatObject(redactProject(*project)))
 
 	d.SetId(project.Key)
 	d.Set("version", project.Version)
@@ -151,18 +751,42 @@ func resourceProjectRead(d *schema.ResourceData, m interface{}) error {
 	d.Set("currencies", project.Currencies)
 	d.Set("countries", project.Countries)
 	d.Set("languages", project.Languages)
-	d.Set("external_oauth", project.ExternalOAuth)
-	d.Set("carts", project.Carts)
+	d.Set("external_oauth", flattenProjectExternalOAuth(
+		project.ExternalOAuth, d.Get("external_oauth").(map[string]interface{})))
+	settings := &projectRestSettings{}
+	if err := getRestClient(m).get(context.Background(), "", nil, settings); err != nil {
+		return err
+	}
+	d.Set("carts", flattenProjectCarts(settings.Carts, d.Get("carts").(map[string]interface{})))
+	if len(d.Get("business_units").([]interface{})) > 0 {
+		d.Set("business_units", flattenProjectBusinessUnits(settings.BusinessUnits))
+	}
 	// d.Set("createdAt", project.CreatedAt)
 	// d.Set("trialUntil", project.TrialUntil)
-	log.Print("[DEBUG] Logging messages enabled")
-	log.Print(stringFormatObject(project.Messages))
-	d.Set("messages", project.Messages)
-	log.Print(stringFormatObject(d))
-	// d.Set("shippingRateInputType", project.ShippingRateInputType)
+	d.Set("messages", flattenProjectMessages(project.Messages, d.Get("messages").(map[string]interface{})))
+	status, toggles := flattenProjectSearchIndexing(&settings.searchIndexingProject)
+	d.Set("search_indexing_status", status)
+	if len(d.Get("search_indexing").([]interface{})) > 0 {
+		d.Set("search_indexing", toggles)
+	}
+
+	inputType, classificationValues := flattenProjectShippingRateInputType(project.ShippingRateInputType)
+	d.Set("shipping_rate_input_type", inputType)
+	d.Set("shipping_rate_cart_classification_value", classificationValues)
 	return nil
 }
 
+// redactProject returns a copy of the project without the authorization
+// header of the external OAuth configuration, for logging
+func redactProject(project commercetools.Project) commercetools.Project {
+	if project.ExternalOAuth != nil {
+		externalOAuth := *project.ExternalOAuth
+		externalOAuth.AuthorizationHeader = "<redacted>"
+		project.ExternalOAuth = &externalOAuth
+	}
+	return project
+}
+
 func resourceProjectUpdate(d *schema.ResourceData, m interface{}) error {
 	client := getClient(m)
 	version := d.Get("version").(int)
@@ -222,28 +846,24 @@ func projectUpdate(d *schema.ResourceData, client *commercetools.Client, version
 
 	if d.HasChange("messages") {
 		messages := d.Get("messages").(map[string]interface{})
-		if messages["enabled"] != nil {
-			// boolean value is somehow interface{} | string so....
-			var enabled bool
-			switch messages["enabled"] {
-			case "true":
-				enabled = true
-			case "false":
-				enabled = false
-			default:
-				return fmt.Errorf("invalid value for messages[\"enabled\"]: %t", messages["enabled"])
-			}
-
+		enabled, _ := strconv.ParseBool(fmt.Sprint(messages["enabled"]))
+		if days, ok := messages["delete_days_after_creation"]; ok {
+			deleteDays, _ := strconv.Atoi(fmt.Sprint(days))
 			input.Actions = append(
 				input.Actions,
-				&commercetools.ProjectChangeMessagesEnabledAction{MessagesEnabled: enabled})
+				&commercetools.ProjectChangeMessagesConfigurationAction{
+					MessagesConfiguration: &commercetools.MessageConfigurationDraft{
+						Enabled:                 enabled,
+						DeleteDaysAfterCreation: float64(deleteDays),
+					},
+				})
 		} else {
-			// To commercetools this field is not optional, so when deleting we revert to the default: false:
+			// To commercetools this field is not optional, so when deleting we
+			// revert to the default: false
 			input.Actions = append(
 				input.Actions,
-				&commercetools.ProjectChangeMessagesEnabledAction{MessagesEnabled: false})
+				&commercetools.ProjectChangeMessagesEnabledAction{MessagesEnabled: enabled})
 		}
-
 	}
 
 	if d.HasChange("external_oauth") {
@@ -263,31 +883,28 @@ func projectUpdate(d *schema.ResourceData, client *commercetools.Client, version
 
 	if d.HasChange("carts") {
 		carts := d.Get("carts").(map[string]interface{})
-		if carts["country_tax_rate_fallback_enabled"] != nil {
-			// boolean value is somehow interface{} | string so....
-			var fallbackEnabled bool
-			switch carts["country_tax_rate_fallback_enabled"] {
-			case "true":
-				fallbackEnabled = true
-			case "false":
-				fallbackEnabled = false
-			default:
-				return fmt.Errorf("invalid value for carts[\"country_tax_rate_fallback_enabled\"]: %s", carts["country_tax_rate_fallback_enabled"])
-			}
-			input.Actions = append(
-				input.Actions,
-				&commercetools.ProjectChangeCountryTaxRateFallbackEnabledAction{
-					CountryTaxRateFallbackEnabled: fallbackEnabled,
-				})
-		} else {
-			// To commercetools this field is not optional, so when deleting we revert to the default: false:
-			input.Actions = append(
-				input.Actions,
-				&commercetools.ProjectChangeCountryTaxRateFallbackEnabledAction{
-					CountryTaxRateFallbackEnabled: false,
-				})
+		input.Actions = append(
+			input.Actions,
+			&projectChangeCartsConfigurationAction{CartsConfiguration: expandProjectCarts(carts)})
+	}
+
+	input.Actions = append(input.Actions, projectBusinessUnitsActions(d)...)
+
+	for _, index := range searchIndexes {
+		key := fmt.Sprintf("search_indexing.0.%s", index)
+		if d.HasChange(key) {
+			input.Actions = append(input.Actions, searchIndexAction(index, d.Get(key).(bool)))
 		}
+	}
 
+	// The cart classification values can only be changed by setting the
+	// complete shipping rate input type
+	if d.HasChange("shipping_rate_input_type") || d.HasChange("shipping_rate_cart_classification_value") {
+		input.Actions = append(
+			input.Actions,
+			&commercetools.ProjectSetShippingRateInputTypeAction{
+				ShippingRateInputType: expandProjectShippingRateInputType(d),
+			})
 	}
 
 	_, err := client.ProjectUpdate(input)
@@ -1,12 +1,227 @@
 package commercetools
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/labd/commercetools-go-sdk/commercetools"
+	"github.com/stretchr/testify/assert"
 )
 
+func TestRemovedStrings(t *testing.T) {
+	assert.Equal(t,
+		[]string{"de", "fr"},
+		removedStrings([]interface{}{"en", "de", "nl", "fr"}, []interface{}{"nl", "en"}))
+	assert.Equal(t,
+		[]string{},
+		removedStrings([]interface{}{"EUR"}, []interface{}{"EUR", "USD"}))
+}
+
+func TestProjectRemovedValues(t *testing.T) {
+	project := &commercetools.Project{
+		Languages:  []commercetools.Locale{"en", "de"},
+		Currencies: []commercetools.CurrencyCode{"EUR", "USD"},
+		Countries:  []commercetools.CountryCode{"NL"},
+	}
+	assert.Equal(t,
+		[]string{"de"},
+		projectRemovedValues(project, "languages", []interface{}{"en"}))
+	assert.Equal(t,
+		[]string{},
+		projectRemovedValues(project, "currencies", []interface{}{"USD", "EUR", "GBP"}))
+	assert.Equal(t,
+		[]string{"NL"},
+		projectRemovedValues(project, "countries", []interface{}{}))
+}
+
+func TestValidateProjectCarts(t *testing.T) {
+	_, errs := validateProjectCarts(map[string]interface{}{
+		"country_tax_rate_fallback_enabled":   "true",
+		"delete_days_after_last_modification": "30",
+		"price_rounding_mode":                 "HalfUp",
+		"tax_rounding_mode":                   "HalfEven",
+	}, "carts")
+	assert.Empty(t, errs)
+
+	_, errs = validateProjectCarts(map[string]interface{}{
+		"country_tax_rate_fallback_enabled":   "yes",
+		"delete_days_after_last_modification": "0",
+		"price_rounding_mode":                 "Up",
+		"unknown":                             "value",
+	}, "carts")
+	assert.Len(t, errs, 4)
+}
+
+func TestProjectCartsConfiguration(t *testing.T) {
+	config := expandProjectCarts(map[string]interface{}{
+		"country_tax_rate_fallback_enabled":   "true",
+		"delete_days_after_last_modification": "30",
+	})
+	data, err := json.Marshal(&projectChangeCartsConfigurationAction{CartsConfiguration: config})
+	assert.NoError(t, err)
+	assert.JSONEq(t,
+		`{"action": "changeCartsConfiguration", "cartsConfiguration": {"countryTaxRateFallbackEnabled": true, "deleteDaysAfterLastModification": 30}}`,
+		string(data))
+
+	// Only the fields in the current state are returned
+	config.PriceRoundingMode = "HalfEven"
+	result := flattenProjectCarts(&config, map[string]interface{}{
+		"delete_days_after_last_modification": "30",
+	})
+	assert.Equal(t, map[string]interface{}{"delete_days_after_last_modification": "30"}, result)
+}
+
+func TestProjectShippingRateInputType(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceProjectSettings().Schema, map[string]interface{}{
+		"shipping_rate_input_type": "CartClassification",
+		"shipping_rate_cart_classification_value": []interface{}{
+			map[string]interface{}{
+				"key":   "Small",
+				"label": map[string]interface{}{"en": "Small", "de": "Klein"},
+			},
+		},
+	})
+
+	inputType := expandProjectShippingRateInputType(d)
+	data, err := json.Marshal(inputType)
+	assert.NoError(t, err)
+	assert.JSONEq(t,
+		`{"type": "CartClassification", "values": [{"key": "Small", "label": {"en": "Small", "de": "Klein"}}]}`,
+		string(data))
+
+	name, values := flattenProjectShippingRateInputType(inputType)
+	assert.Equal(t, "CartClassification", name)
+	assert.Equal(t, []map[string]interface{}{
+		{
+			"key":   "Small",
+			"label": map[string]string{"en": "Small", "de": "Klein"},
+		},
+	}, values)
+
+	name, values = flattenProjectShippingRateInputType(commercetools.CartScoreType{})
+	assert.Equal(t, "CartScore", name)
+	assert.Empty(t, values)
+
+	name, _ = flattenProjectShippingRateInputType(nil)
+	assert.Equal(t, "", name)
+}
+
+func TestProjectExternalOAuth(t *testing.T) {
+	_, errs := validateProjectExternalOAuth(map[string]interface{}{
+		"url":                  "https://example.com/introspect",
+		"authorization_header": "Bearer secret",
+	}, "external_oauth")
+	assert.Empty(t, errs)
+
+	_, errs = validateProjectExternalOAuth(map[string]interface{}{
+		"url": "https://example.com/introspect",
+	}, "external_oauth")
+	assert.Len(t, errs, 1)
+
+	externalOAuth := &commercetools.ExternalOAuth{
+		URL:                 "https://example.com/introspect",
+		AuthorizationHeader: "****",
+	}
+	result := flattenProjectExternalOAuth(externalOAuth, map[string]interface{}{
+		"url":                  "https://example.com/old",
+		"authorization_header": "Bearer secret",
+	})
+	assert.Equal(t, map[string]interface{}{
+		"url":                  "https://example.com/introspect",
+		"authorization_header": "Bearer secret",
+	}, result)
+
+	project := redactProject(commercetools.Project{ExternalOAuth: externalOAuth})
+	assert.Equal(t, "<redacted>", project.ExternalOAuth.AuthorizationHeader)
+	assert.Equal(t, "****", externalOAuth.AuthorizationHeader)
+}
+
+func TestFlattenProjectSearchIndexing(t *testing.T) {
+	project := &searchIndexingProject{
+		SearchIndexing: &searchIndexingConfiguration{
+			Products:  &searchIndexingConfigurationValues{Status: "Activated"},
+			Customers: &searchIndexingConfigurationValues{Status: "Indexing"},
+		},
+	}
+	status, toggles := flattenProjectSearchIndexing(project)
+	assert.Equal(t, map[string]string{
+		"products":       "Activated",
+		"orders":         "Deactivated",
+		"customers":      "Indexing",
+		"business_units": "Deactivated",
+	}, status)
+	assert.Equal(t, []map[string]interface{}{
+		{
+			"products":       true,
+			"orders":         false,
+			"customers":      true,
+			"business_units": false,
+		},
+	}, toggles)
+}
+
+func TestProjectBusinessUnits(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceProjectSettings().Schema, map[string]interface{}{
+		"business_units": []interface{}{
+			map[string]interface{}{
+				"my_business_unit_status_on_creation":             "Active",
+				"my_business_unit_associate_role_key_on_creation": "admin",
+			},
+		},
+	})
+	actions := projectBusinessUnitsActions(d)
+	data, err := json.Marshal(actions)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"action": "changeMyBusinessUnitStatusOnCreation", "status": "Active"},
+		{"action": "setMyBusinessUnitAssociateRoleOnCreation", "associateRole": {"typeId": "associate-role", "key": "admin"}}
+	]`, string(data))
+
+	settings := &projectRestSettings{}
+	err = json.Unmarshal([]byte(`{
+		"key": "my-project",
+		"businessUnits": {
+			"myBusinessUnitStatusOnCreation": "Inactive",
+			"myBusinessUnitAssociateRoleOnCreation": {"typeId": "associate-role", "key": "admin"}
+		},
+		"searchIndexing": {"products": {"status": "Activated"}}
+	}`), settings)
+	assert.NoError(t, err)
+	assert.Equal(t, "my-project", settings.Key)
+	assert.Equal(t, "Activated", settings.searchIndex(searchIndexProducts).Status)
+	assert.Equal(t, []map[string]interface{}{
+		{
+			"my_business_unit_status_on_creation":             "Inactive",
+			"my_business_unit_associate_role_key_on_creation": "admin",
+		},
+	}, flattenProjectBusinessUnits(settings.BusinessUnits))
+}
+
+func TestProjectMessages(t *testing.T) {
+	_, errs := validateProjectMessages(map[string]interface{}{
+		"enabled":                    "true",
+		"delete_days_after_creation": "30",
+	}, "messages")
+	assert.Empty(t, errs)
+
+	_, errs = validateProjectMessages(map[string]interface{}{
+		"delete_days_after_creation": "365",
+	}, "messages")
+	assert.Len(t, errs, 2)
+
+	config := &commercetools.MessageConfiguration{Enabled: true, DeleteDaysAfterCreation: 15}
+	assert.Equal(t,
+		map[string]interface{}{"enabled": "true"},
+		flattenProjectMessages(config, map[string]interface{}{"enabled": "true"}))
+	assert.Equal(t,
+		map[string]interface{}{"enabled": "true", "delete_days_after_creation": "15"},
+		flattenProjectMessages(config, map[string]interface{}{"enabled": "true", "delete_days_after_creation": "30"}))
+	assert.Equal(t, map[string]interface{}{}, flattenProjectMessages(config, map[string]interface{}{}))
+}
+
 func TestAccProjectCreate_basic(t *testing.T) {
 
 	resource.Test(t, resource.TestCase{
@@ -0,0 +1,165 @@
+package commercetools
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+// resourceSearchActivation activates the product or order search index of
+// the project and waits until indexing is finished, so resources and tooling
+// which depend on the index can rely on it being available.
+func resourceSearchActivation() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceSearchActivationCreate,
+		Read:   resourceSearchActivationRead,
+		Delete: resourceSearchActivationDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"index": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					searchIndexProducts,
+					searchIndexOrders,
+				}, false),
+			},
+			"deactivate_on_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				ForceNew: true,
+				Default:  false,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceSearchActivationCreate(d *schema.ResourceData, m interface{}) error {
+	index := d.Get("index").(string)
+
+	project, err := getSearchIndexingProject(m)
+	if err != nil {
+		return err
+	}
+
+	if project.searchIndex(index).Status == searchIndexStatusDeactivated {
+		if err := setSearchIndexActive(m, project, index, true); err != nil {
+			return err
+		}
+	}
+
+	d.SetId(index)
+
+	err = resource.Retry(d.Timeout(schema.TimeoutCreate), func() *resource.RetryError {
+		project, err := getSearchIndexingProject(m)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+
+		status := project.searchIndex(index).Status
+		if status != searchIndexStatusActivated {
+			log.Printf("[DEBUG] Search index %s has status %s, waiting", index, status)
+			return resource.RetryableError(fmt.Errorf("search index %s has status %s", index, status))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return resourceSearchActivationRead(d, m)
+}
+
+func resourceSearchActivationRead(d *schema.ResourceData, m interface{}) error {
+	project, err := getSearchIndexingProject(m)
+	if err != nil {
+		return err
+	}
+
+	status := project.searchIndex(d.Id()).Status
+	if status == searchIndexStatusDeactivated {
+		log.Printf("[DEBUG] Search index %s is deactivated", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("index", d.Id())
+	d.Set("status", status)
+	return nil
+}
+
+// Destroying the resource only deactivates the index when requested, since
+// activating the index again means waiting for the complete reindex.
+func resourceSearchActivationDelete(d *schema.ResourceData, m interface{}) error {
+	if !d.Get("deactivate_on_destroy").(bool) {
+		d.SetId("")
+		return nil
+	}
+
+	project, err := getSearchIndexingProject(m)
+	if err != nil {
+		return err
+	}
+	return setSearchIndexActive(m, project, d.Id(), false)
+}
+
+func setSearchIndexActive(m interface{}, project *searchIndexingProject, index string, active bool) error {
+	client := getRestClient(m)
+
+	ctMutexKV.Lock(project.Key)
+	defer ctMutexKV.Unlock(project.Key)
+
+	action := searchIndexAction(index, active)
+	log.Printf(
+		"[DEBUG] Will perform update operation with the following actions:\n%s",
+		stringFormatObject(action))
+
+	return client.update(
+		context.Background(), "", nil, project.Version, []interface{}{action}, nil)
+}
+
+// searchIndexAction returns the project update action which changes the
+// status of the given index
+func searchIndexAction(index string, active bool) map[string]interface{} {
+	status := searchIndexStatusDeactivated
+	if active {
+		status = searchIndexStatusActivated
+	}
+
+	switch index {
+	case searchIndexOrders:
+		return map[string]interface{}{
+			"action": "changeOrderSearchStatus",
+			"status": status,
+		}
+	case searchIndexCustomers:
+		return map[string]interface{}{
+			"action": "changeCustomerSearchStatus",
+			"status": status,
+		}
+	case searchIndexBusinessUnits:
+		return map[string]interface{}{
+			"action": "changeBusinessUnitSearchStatus",
+			"status": status,
+		}
+	}
+	return map[string]interface{}{
+		"action":  "changeProductSearchIndexingEnabled",
+		"enabled": active,
+	}
+}
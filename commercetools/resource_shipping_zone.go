@@ -34,7 +34,7 @@ func resourceShippingZone() *schema.Resource {
 				Optional: true,
 			},
 			"location": {
-				Type:     schema.TypeList,
+				Type:     schema.TypeSet,
 				Optional: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
@@ -63,8 +63,7 @@ func resourceShippingZoneCreate(d *schema.ResourceData, m interface{}) error {
 
 	var shippingZone *commercetools.Zone
 
-	input := d.Get("location").([]interface{})
-	locations := resourceShippingZoneGetLocation(input)
+	locations := resourceShippingZoneGetLocation(d.Get("location"))
 
 	draft := &commercetools.ZoneDraft{
 		Key:         d.Get("key").(string),
@@ -124,7 +123,7 @@ func resourceShippingZoneRead(d *schema.ResourceData, m interface{}) error {
 		d.Set("key", shippingZone.Key)
 		d.Set("name", shippingZone.Name)
 		d.Set("description", shippingZone.Description)
-		d.Set("locations", shippingZone.Locations)
+		d.Set("location", flattenShippingZoneLocations(shippingZone.Locations))
 	}
 	return nil
 }
@@ -167,6 +166,8 @@ func resourceShippingZoneUpdate(d *schema.ResourceData, m interface{}) error {
 		oldLocations := resourceShippingZoneGetLocation(old)
 		newLocations := resourceShippingZoneGetLocation(new)
 
+		// Remove the locations first, so a location which is moved to
+		// another zone can be added there in the same apply
 		for i, location := range oldLocations {
 			if !_locationInSlice(location, newLocations) {
 				input.Actions = append(
@@ -183,6 +184,10 @@ func resourceShippingZoneUpdate(d *schema.ResourceData, m interface{}) error {
 		}
 	}
 
+	log.Printf(
+		"[DEBUG] Will perform update operation with the following actions:\n%s",
+		stringFormatActions(input.Actions))
+
 	_, err := client.ZoneUpdateWithID(context.Background(), input)
 	if err != nil {
 		return err
@@ -208,7 +213,7 @@ func resourceShippingZoneDelete(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceShippingZoneGetLocation(input interface{}) []commercetools.Location {
-	inputSlice := input.([]interface{})
+	inputSlice := input.(*schema.Set).List()
 	var result []commercetools.Location
 
 	for _, raw := range inputSlice {
@@ -233,6 +238,17 @@ func resourceShippingZoneGetLocation(input interface{}) []commercetools.Location
 	return result
 }
 
+func flattenShippingZoneLocations(locations []commercetools.Location) []map[string]interface{} {
+	result := make([]map[string]interface{}, len(locations))
+	for i, location := range locations {
+		result[i] = map[string]interface{}{
+			"country": string(location.Country),
+			"state":   location.State,
+		}
+	}
+	return result
+}
+
 func _locationInSlice(needle commercetools.Location, haystack []commercetools.Location) bool {
 	for _, item := range haystack {
 		if item == needle {
@@ -9,6 +9,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/labd/commercetools-go-sdk/commercetools"
 )
 
@@ -72,6 +73,45 @@ func resourceShippingZoneRate() *schema.Resource {
 					},
 				},
 			},
+			"shipping_rate_price_tier": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Type:     schema.TypeString,
+							Required: true,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(commercetools.ShippingRateTierTypeCartValue),
+							}, false),
+						},
+						"minimum_cent_amount": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntAtLeast(0),
+						},
+						"price": {
+							Type:     schema.TypeList,
+							Required: true,
+							MinItems: 1,
+							MaxItems: 1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"currency_code": {
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: ValidateCurrencyCode,
+									},
+									"cent_amount": {
+										Type:     schema.TypeInt,
+										Required: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -156,6 +196,7 @@ func resourceShippingZoneRateCreate(d *schema.ResourceData, m interface{}) error
 				CentAmount:   price["cent_amount"].(int),
 			},
 			FreeAbove: freeAbove,
+			Tiers:     expandShippingRatePriceTiers(d.Get("shipping_rate_price_tier").([]interface{})),
 		},
 	})
 
@@ -244,7 +285,7 @@ func resourceShippingZoneRateUpdate(d *schema.ResourceData, m interface{}) error
 		Actions: []commercetools.ShippingMethodUpdateAction{},
 	}
 
-	if d.HasChange("price") || d.HasChange("free_above") {
+	if d.HasChange("price") || d.HasChange("free_above") || d.HasChange("shipping_rate_price_tier") {
 		zoneResourceIdentifier := commercetools.ZoneResourceIdentifier{
 			ID: shippingZoneID,
 		}
@@ -265,6 +306,7 @@ func resourceShippingZoneRateUpdate(d *schema.ResourceData, m interface{}) error
 				CentAmount:   oldTypedPrice.CentAmount,
 			},
 			FreeAbove: oldFreeAboveMoney,
+			Tiers:     shippingRate.Tiers,
 		}
 
 		price := d.Get("price").([]interface{})[0].(map[string]interface{})
@@ -283,6 +325,7 @@ func resourceShippingZoneRateUpdate(d *schema.ResourceData, m interface{}) error
 				CentAmount:   price["cent_amount"].(int),
 			},
 			FreeAbove: newFreeAboveMoney,
+			Tiers:     expandShippingRatePriceTiers(d.Get("shipping_rate_price_tier").([]interface{})),
 		}
 
 		input.Actions = append(
@@ -351,6 +394,7 @@ func resourceShippingZoneRateDelete(d *schema.ResourceData, m interface{}) error
 				CentAmount:   price["cent_amount"].(int),
 			},
 			FreeAbove: newFreeAboveMoney,
+			Tiers:     expandShippingRatePriceTiers(d.Get("shipping_rate_price_tier").([]interface{})),
 		},
 	}
 
@@ -432,7 +476,56 @@ func setShippingZoneRateState(d *schema.ResourceData, shippingMethod *commerceto
 			return err
 		}
 	}
+
+	err = d.Set("shipping_rate_price_tier", flattenShippingRatePriceTiers(shippingRate.Tiers))
+	if err != nil {
+		return err
+	}
 	log.Printf("[DEBUG] New state: %#v", d)
 
 	return nil
 }
+
+func expandShippingRatePriceTiers(input []interface{}) []commercetools.ShippingRatePriceTier {
+	var result []commercetools.ShippingRatePriceTier
+	for _, raw := range input {
+		tier := raw.(map[string]interface{})
+		price := tier["price"].([]interface{})[0].(map[string]interface{})
+
+		switch commercetools.ShippingRateTierType(tier["type"].(string)) {
+		case commercetools.ShippingRateTierTypeCartValue:
+			result = append(result, commercetools.CartValueTier{
+				MinimumCentAmount: tier["minimum_cent_amount"].(int),
+				Price: &commercetools.Money{
+					CurrencyCode: commercetools.CurrencyCode(price["currency_code"].(string)),
+					CentAmount:   price["cent_amount"].(int),
+				},
+			})
+		}
+	}
+	return result
+}
+
+func flattenShippingRatePriceTiers(tiers []commercetools.ShippingRatePriceTier) []map[string]interface{} {
+	result := []map[string]interface{}{}
+	for _, raw := range tiers {
+		switch tier := raw.(type) {
+		case commercetools.CartValueTier:
+			price := []map[string]interface{}{}
+			if tier.Price != nil {
+				price = append(price, map[string]interface{}{
+					"currency_code": string(tier.Price.CurrencyCode),
+					"cent_amount":   tier.Price.CentAmount,
+				})
+			}
+			result = append(result, map[string]interface{}{
+				"type":                string(commercetools.ShippingRateTierTypeCartValue),
+				"minimum_cent_amount": tier.MinimumCentAmount,
+				"price":               price,
+			})
+		default:
+			log.Printf("[WARN] Shipping rate price tier %T is not supported", raw)
+		}
+	}
+	return result
+}
@@ -8,6 +8,8 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/labd/commercetools-go-sdk/commercetools"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccShippingZoneRate_create(t *testing.T) {
@@ -85,3 +87,35 @@ func testAccShippingZoneRateConfig(taxCategoryName string, shippingMethodName st
 func testAccCheckShippingZoneRateDestroy(s *terraform.State) error {
 	return nil
 }
+
+func TestExpandShippingRatePriceTiers(t *testing.T) {
+	input := []interface{}{
+		map[string]interface{}{
+			"type":                "CartValue",
+			"minimum_cent_amount": 5000,
+			"price": []interface{}{
+				map[string]interface{}{
+					"currency_code": "EUR",
+					"cent_amount":   250,
+				},
+			},
+		},
+	}
+
+	tiers := expandShippingRatePriceTiers(input)
+	expected := []commercetools.ShippingRatePriceTier{
+		commercetools.CartValueTier{
+			MinimumCentAmount: 5000,
+			Price: &commercetools.Money{
+				CurrencyCode: "EUR",
+				CentAmount:   250,
+			},
+		},
+	}
+	assert.Equal(t, expected, tiers)
+
+	flattened := flattenShippingRatePriceTiers(tiers)
+	assert.Len(t, flattened, 1)
+	assert.Equal(t, "CartValue", flattened[0]["type"])
+	assert.Equal(t, 5000, flattened[0]["minimum_cent_amount"])
+}
@@ -2,10 +2,13 @@ package commercetools
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/labd/commercetools-go-sdk/commercetools"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccShippingZone_createAndUpdateWithID(t *testing.T) {
@@ -93,14 +96,11 @@ func TestAccShippingZone_createAndAddLocation(t *testing.T) {
 					resource.TestCheckResourceAttr(
 						"commercetools_shipping_zone.standard", "name", name,
 					),
-					resource.TestCheckResourceAttr(
-						"commercetools_shipping_zone.standard", "location.0.country", "DE",
-					),
-					resource.TestCheckResourceAttr(
-						"commercetools_shipping_zone.standard", "location.1.country", "US",
+					testAccCheckShippingZoneLocation(
+						"commercetools_shipping_zone.standard", "DE", "",
 					),
-					resource.TestCheckResourceAttr(
-						"commercetools_shipping_zone.standard", "location.1.state", "Nevada",
+					testAccCheckShippingZoneLocation(
+						"commercetools_shipping_zone.standard", "US", "Nevada",
 					),
 				),
 			},
@@ -116,17 +116,14 @@ func TestAccShippingZone_createAndAddLocation(t *testing.T) {
 					resource.TestCheckResourceAttr(
 						"commercetools_shipping_zone.standard", "location.#", "3",
 					),
-					resource.TestCheckResourceAttr(
-						"commercetools_shipping_zone.standard", "location.0.country", "DE",
-					),
-					resource.TestCheckResourceAttr(
-						"commercetools_shipping_zone.standard", "location.1.country", "ES",
+					testAccCheckShippingZoneLocation(
+						"commercetools_shipping_zone.standard", "DE", "",
 					),
-					resource.TestCheckResourceAttr(
-						"commercetools_shipping_zone.standard", "location.2.country", "US",
+					testAccCheckShippingZoneLocation(
+						"commercetools_shipping_zone.standard", "ES", "",
 					),
-					resource.TestCheckResourceAttr(
-						"commercetools_shipping_zone.standard", "location.2.state", "Nevada",
+					testAccCheckShippingZoneLocation(
+						"commercetools_shipping_zone.standard", "US", "Nevada",
 					),
 				),
 			},
@@ -168,14 +165,11 @@ func TestAccShippingZone_createAndRemoveLocation(t *testing.T) {
 					resource.TestCheckResourceAttr(
 						"commercetools_shipping_zone.standard", "name", name,
 					),
-					resource.TestCheckResourceAttr(
-						"commercetools_shipping_zone.standard", "location.0.country", "DE",
+					testAccCheckShippingZoneLocation(
+						"commercetools_shipping_zone.standard", "DE", "",
 					),
-					resource.TestCheckResourceAttr(
-						"commercetools_shipping_zone.standard", "location.1.country", "US",
-					),
-					resource.TestCheckResourceAttr(
-						"commercetools_shipping_zone.standard", "location.1.state", "Nevada",
+					testAccCheckShippingZoneLocation(
+						"commercetools_shipping_zone.standard", "US", "Nevada",
 					),
 				),
 			},
@@ -191,11 +185,8 @@ func TestAccShippingZone_createAndRemoveLocation(t *testing.T) {
 					resource.TestCheckResourceAttr(
 						"commercetools_shipping_zone.standard", "location.#", "1",
 					),
-					resource.TestCheckResourceAttr(
-						"commercetools_shipping_zone.standard", "location.0.country", "US",
-					),
-					resource.TestCheckResourceAttr(
-						"commercetools_shipping_zone.standard", "location.0.state", "Nevada",
+					testAccCheckShippingZoneLocation(
+						"commercetools_shipping_zone.standard", "US", "Nevada",
 					),
 				),
 			},
@@ -218,3 +209,38 @@ resource "commercetools_shipping_zone" "standard" {
 func testAccCheckShippingZoneDestroy(s *terraform.State) error {
 	return nil
 }
+
+// testAccCheckShippingZoneLocation checks if the location is part of the set
+// of locations of the shipping zone.
+func testAccCheckShippingZoneLocation(name string, country string, state string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		attributes := rs.Primary.Attributes
+		for key, value := range attributes {
+			if !strings.HasPrefix(key, "location.") || !strings.HasSuffix(key, ".country") {
+				continue
+			}
+			prefix := strings.TrimSuffix(key, "country")
+			if value == country && attributes[prefix+"state"] == state {
+				return nil
+			}
+		}
+		return fmt.Errorf("Location %s %s not found in %s", country, state, name)
+	}
+}
+
+func TestFlattenShippingZoneLocations(t *testing.T) {
+	locations := []commercetools.Location{
+		{Country: "DE"},
+		{Country: "US", State: "Nevada"},
+	}
+	expected := []map[string]interface{}{
+		{"country": "DE", "state": ""},
+		{"country": "US", "state": "Nevada"},
+	}
+	assert.Equal(t, expected, flattenShippingZoneLocations(locations))
+}
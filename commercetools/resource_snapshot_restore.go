@@ -0,0 +1,231 @@
+package commercetools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+// snapshotRestoreEndpoint is an endpoint of which the objects can be restored
+// from a snapshot. Objects are matched with existing objects by the match
+// field, so restoring into a project which already contains them doesn't
+// result in duplicates.
+type snapshotRestoreEndpoint struct {
+	Name       string
+	MatchField string
+}
+
+// snapshotRestoreEndpoints are restored in this order, so the types exist
+// before the objects with custom fields and the cart discounts exist before
+// the discount codes referencing them
+var snapshotRestoreEndpoints = []snapshotRestoreEndpoint{
+	{Name: "types", MatchField: "key"},
+	{Name: "channels", MatchField: "key"},
+	{Name: "cart-discounts", MatchField: "key"},
+	{Name: "discount-codes", MatchField: "code"},
+}
+
+// snapshotServerFields are the fields of a stored object which are set by
+// commercetools and can't be part of a draft
+var snapshotServerFields = []string{
+	"id", "version", "createdAt", "createdBy", "lastModifiedAt", "lastModifiedBy",
+	"versionModifiedAt", "lastMessageSequenceNumber", "references", "applicationVersion",
+}
+
+// resourceSnapshotRestore creates the objects in a snapshot written by the
+// provider (see snapshot_file) in the project, for example to test the
+// recovery of the configuration of a project in an empty project. Only the
+// configuration is restored, not the data like products, orders or customers.
+//
+// The restore runs once when the resource is created. Objects which already
+// exist are left unchanged, so a failed restore can be continued by applying
+// again.
+func resourceSnapshotRestore() *schema.Resource {
+	endpoints := make([]string, len(snapshotRestoreEndpoints))
+	for i, endpoint := range snapshotRestoreEndpoints {
+		endpoints[i] = endpoint.Name
+	}
+
+	return &schema.Resource{
+		Create: resourceSnapshotRestoreCreate,
+		Read:   resourceSnapshotRestoreRead,
+		Delete: resourceSnapshotRestoreDelete,
+		Schema: map[string]*schema.Schema{
+			"snapshot": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsJSON,
+				Description:  "The contents of the snapshot file",
+			},
+			"endpoints": {
+				Type:     schema.TypeList,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringInSlice(endpoints, false),
+				},
+				Description: "The endpoints of which the objects are restored, all supported endpoints when empty",
+			},
+			"restored_ids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The ids of the objects in the project by their id in the snapshot",
+			},
+			"created_count": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceSnapshotRestoreCreate(d *schema.ResourceData, m interface{}) error {
+	input := snapshot{}
+	if err := json.Unmarshal([]byte(d.Get("snapshot").(string)), &input); err != nil {
+		return fmt.Errorf("invalid snapshot: %w", err)
+	}
+
+	selected := map[string]bool{}
+	for _, name := range expandStringArray(d.Get("endpoints").([]interface{})) {
+		selected[name] = true
+	}
+
+	ids := map[string]string{}
+	created := 0
+	for _, endpoint := range snapshotRestoreEndpoints {
+		if len(selected) > 0 && !selected[endpoint.Name] {
+			continue
+		}
+		count, err := restoreSnapshotEndpoint(getRestClient(m), endpoint, input.Resources[endpoint.Name], ids)
+		created += count
+		if err != nil {
+			return fmt.Errorf("%w (%d objects created, apply again to continue)", err, created)
+		}
+	}
+	log.Printf("[DEBUG] Restored snapshot of project %s, %d objects created", input.ProjectKey, created)
+
+	d.SetId(fmt.Sprintf("%s:%s", input.ProjectKey, input.CreatedAt))
+	d.Set("restored_ids", ids)
+	d.Set("created_count", created)
+	return resourceSnapshotRestoreRead(d, m)
+}
+
+// The restore is a one-off operation, there is nothing to read back
+func resourceSnapshotRestoreRead(d *schema.ResourceData, m interface{}) error {
+	return nil
+}
+
+// Deleting the restore only removes it from the state, the restored objects
+// are kept.
+func resourceSnapshotRestoreDelete(d *schema.ResourceData, m interface{}) error {
+	d.SetId("")
+	return nil
+}
+
+// restoreSnapshotEndpoint creates the objects of the endpoint which don't
+// exist yet and adds the ids of all objects to ids. It returns the number of
+// created objects.
+func restoreSnapshotEndpoint(client *restClient, endpoint snapshotRestoreEndpoint, objects map[string]json.RawMessage, ids map[string]string) (int, error) {
+	snapshotIDs := make([]string, 0, len(objects))
+	for id := range objects {
+		snapshotIDs = append(snapshotIDs, id)
+	}
+	sort.Strings(snapshotIDs)
+
+	created := 0
+	for _, snapshotID := range snapshotIDs {
+		object := map[string]interface{}{}
+		if err := json.Unmarshal(objects[snapshotID], &object); err != nil {
+			return created, fmt.Errorf("invalid %s %s in snapshot: %w", endpoint.Name, snapshotID, err)
+		}
+
+		if match, _ := object[endpoint.MatchField].(string); match != "" {
+			existingID, err := snapshotRestoreExisting(client, endpoint, match)
+			if err != nil {
+				return created, err
+			}
+			if existingID != "" {
+				log.Printf("[DEBUG] Skipping %s %s, it already exists", endpoint.Name, match)
+				ids[snapshotID] = existingID
+				continue
+			}
+		}
+
+		result := struct {
+			ID string `json:"id"`
+		}{}
+		draft := snapshotRestoreDraft(object, ids)
+		if err := client.create(context.Background(), endpoint.Name, nil, draft, &result); err != nil {
+			return created, fmt.Errorf("failed to restore %s %s: %w", endpoint.Name, snapshotID, err)
+		}
+		ids[snapshotID] = result.ID
+		created++
+	}
+	return created, nil
+}
+
+// snapshotRestoreExisting returns the id of the object with the value of the
+// match field, or an empty string when it doesn't exist
+func snapshotRestoreExisting(client *restClient, endpoint snapshotRestoreEndpoint, value string) (string, error) {
+	query := url.Values{}
+	query.Set("where", fmt.Sprintf("%s = %q", endpoint.MatchField, value))
+	query.Set("limit", "1")
+
+	result := struct {
+		Results []struct {
+			ID string `json:"id"`
+		} `json:"results"`
+	}{}
+	if err := client.get(context.Background(), endpoint.Name, query, &result); err != nil {
+		return "", err
+	}
+	if len(result.Results) == 0 {
+		return "", nil
+	}
+	return result.Results[0].ID, nil
+}
+
+// snapshotRestoreDraft returns the draft for a stored object. The fields set
+// by commercetools are removed and references to restored objects are
+// changed to the ids of these objects in the project.
+func snapshotRestoreDraft(object map[string]interface{}, ids map[string]string) map[string]interface{} {
+	draft := snapshotRestoreReferences(object, ids).(map[string]interface{})
+	for _, field := range snapshotServerFields {
+		delete(draft, field)
+	}
+	return draft
+}
+
+func snapshotRestoreReferences(value interface{}, ids map[string]string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			result[key] = snapshotRestoreReferences(item, ids)
+		}
+		if _, ok := v["typeId"]; ok {
+			// Expanded objects can't be part of a draft
+			delete(result, "obj")
+			if id, ok := v["id"].(string); ok && ids[id] != "" {
+				result["id"] = ids[id]
+			}
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, item := range v {
+			result[i] = snapshotRestoreReferences(item, ids)
+		}
+		return result
+	}
+	return value
+}
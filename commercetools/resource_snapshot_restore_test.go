@@ -0,0 +1,72 @@
+package commercetools
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotRestoreDraft(t *testing.T) {
+	object := map[string]interface{}{
+		"id":         "old-discount",
+		"version":    3,
+		"createdAt":  "2020-11-02T10:00:00Z",
+		"key":        "summer",
+		"references": []interface{}{map[string]interface{}{"typeId": "type", "id": "old-type"}},
+		"custom": map[string]interface{}{
+			"type": map[string]interface{}{
+				"typeId": "type", "id": "old-type", "obj": map[string]interface{}{"key": "discount"}},
+			"fields": map[string]interface{}{"id": "old-type"},
+		},
+	}
+	draft := snapshotRestoreDraft(object, map[string]string{"old-type": "new-type"})
+
+	assert.Equal(t, map[string]interface{}{
+		"key": "summer",
+		"custom": map[string]interface{}{
+			"type":   map[string]interface{}{"typeId": "type", "id": "new-type"},
+			"fields": map[string]interface{}{"id": "old-type"},
+		},
+	}, draft)
+	// The object in the snapshot is not modified
+	assert.Equal(t, "old-discount", object["id"])
+}
+
+func TestRestoreSnapshotEndpoint(t *testing.T) {
+	created := []map[string]interface{}{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			if r.URL.Query().Get("where") == `key = "existing"` {
+				w.Write([]byte(`{"results": [{"id": "existing-id"}]}`))
+				return
+			}
+			w.Write([]byte(`{"results": []}`))
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		draft := map[string]interface{}{}
+		assert.NoError(t, json.Unmarshal(body, &draft))
+		created = append(created, draft)
+		w.Write([]byte(`{"id": "created-id", "version": 1}`))
+	}))
+	defer server.Close()
+
+	objects := map[string]json.RawMessage{
+		"1": json.RawMessage(`{"id": "1", "version": 2, "key": "existing"}`),
+		"2": json.RawMessage(`{"id": "2", "version": 5, "key": "new", "roles": ["InventorySupply"]}`),
+	}
+	ids := map[string]string{}
+	client := newRestClient(server.Client(), server.URL, "my-project")
+	count, err := restoreSnapshotEndpoint(client, snapshotRestoreEndpoint{Name: "channels", MatchField: "key"}, objects, ids)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, map[string]string{"1": "existing-id", "2": "created-id"}, ids)
+	assert.Equal(t, []map[string]interface{}{
+		{"key": "new", "roles": []interface{}{"InventorySupply"}},
+	}, created)
+}
@@ -2,8 +2,11 @@ package commercetools
 
 import (
 	"context"
+	"fmt"
+	"log"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
@@ -66,11 +69,71 @@ func resourceState() *schema.Resource {
 				Type:     schema.TypeSet,
 				Optional: true,
 				Elem: &schema.Schema{
-					Type: schema.TypeString,
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
 				},
 			},
 		},
+		CustomizeDiff: customdiff.All(
+			customdiff.IfValueChange("transitions",
+				func(old, new, meta interface{}) bool {
+					return new.(*schema.Set).Len() > 0
+				},
+				resourceStateValidateTransitions),
+		),
+	}
+}
+
+// resourceStateValidateTransitions verifies that the states referenced in
+// the transitions exist and are of the same type as this state, so that
+// broken state machines are detected during the plan instead of the apply.
+// States which are not found are possibly created in the same plan, so these
+// only result in a warning.
+func resourceStateValidateTransitions(d *schema.ResourceDiff, m interface{}) error {
+	if m == nil || !d.NewValueKnown("transitions") || !d.NewValueKnown("type") {
+		return nil
+	}
+
+	keys := expandStringArray(d.Get("transitions").(*schema.Set).List())
+
+	client := getClient(m)
+	result, err := client.StateQuery(context.Background(), &commercetools.QueryInput{
+		Where: fmt.Sprintf("key in (%s)", quotePredicateValues(keys)),
+		Limit: len(keys),
+	})
+	if err != nil {
+		log.Printf("[DEBUG] Unable to fetch states for transitions: %s", err)
+		return nil
+	}
+
+	return validateStateTransitions(
+		d.Get("key").(string),
+		commercetools.StateTypeEnum(d.Get("type").(string)),
+		keys,
+		result.Results)
+}
+
+func validateStateTransitions(key string, stateType commercetools.StateTypeEnum, transitions []string, states []commercetools.State) error {
+	existing := make(map[string]commercetools.State, len(states))
+	for _, state := range states {
+		existing[state.Key] = state
+	}
+
+	for _, transition := range transitions {
+		state, ok := existing[transition]
+		if !ok {
+			if transition != key {
+				log.Printf("[WARN] State %s has a transition to state %s which does not exist (yet)", key, transition)
+			}
+			continue
+		}
+		if state.Type != stateType {
+			return fmt.Errorf(
+				"state %s of type %s can not transition to state %s of type %s",
+				key, stateType, transition, state.Type)
+		}
 	}
+	return nil
 }
 
 func resourceStateCreate(d *schema.ResourceData, m interface{}) error {
@@ -129,7 +192,9 @@ func resourceStateCreate(d *schema.ResourceData, m interface{}) error {
 
 func resourceStateRead(d *schema.ResourceData, m interface{}) error {
 	client := getClient(m)
-	state, err := client.StateGetWithID(context.Background(), d.Id())
+	state, err := client.StateGetWithID(
+		context.Background(), d.Id(),
+		commercetools.WithReferenceExpansion("transitions[*]"))
 
 	if err != nil {
 		if ctErr, ok := err.(commercetools.ErrorResponse); ok {
@@ -156,11 +221,24 @@ func resourceStateRead(d *schema.ResourceData, m interface{}) error {
 		d.Set("roles", state.Roles)
 	}
 	if state.Transitions != nil {
-		d.Set("transitions", state.Transitions)
+		d.Set("transitions", flattenStateTransitions(state.Transitions))
 	}
 	return nil
 }
 
+// flattenStateTransitions returns the keys of the (expanded) state references
+func flattenStateTransitions(transitions []commercetools.StateReference) []string {
+	result := make([]string, 0, len(transitions))
+	for _, transition := range transitions {
+		if transition.Obj != nil {
+			result = append(result, transition.Obj.Key)
+		} else {
+			log.Printf("[WARN] Transition to state %s was not expanded", transition.ID)
+		}
+	}
+	return result
+}
+
 func resourceStateUpdate(d *schema.ResourceData, m interface{}) error {
 	client := getClient(m)
 
@@ -231,6 +309,10 @@ func resourceStateUpdate(d *schema.ResourceData, m interface{}) error {
 			})
 	}
 
+	log.Printf(
+		"[DEBUG] Will perform update operation with the following actions:\n%s",
+		stringFormatActions(input.Actions))
+
 	_, err := client.StateUpdateWithID(context.Background(), input)
 	if err != nil {
 		return err
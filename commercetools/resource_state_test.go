@@ -7,6 +7,8 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/labd/commercetools-go-sdk/commercetools"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccState_createAndUpdateWithID(t *testing.T) {
@@ -133,3 +135,27 @@ func testAccTransitionsConfig(t *testing.T, transitions string) string {
 func testAccCheckStateDestroy(s *terraform.State) error {
 	return nil
 }
+
+func TestFlattenStateTransitions(t *testing.T) {
+	transitions := []commercetools.StateReference{
+		{ID: "1", Obj: &commercetools.State{ID: "1", Key: "state-a"}},
+		{ID: "2", Obj: &commercetools.State{ID: "2", Key: "state-b"}},
+		{ID: "3"},
+	}
+	assert.Equal(t, []string{"state-a", "state-b"}, flattenStateTransitions(transitions))
+}
+
+func TestValidateStateTransitions(t *testing.T) {
+	states := []commercetools.State{
+		{Key: "state-a", Type: commercetools.StateTypeEnumOrderState},
+		{Key: "state-b", Type: commercetools.StateTypeEnumReviewState},
+	}
+
+	err := validateStateTransitions(
+		"state-c", commercetools.StateTypeEnumOrderState, []string{"state-a", "state-new"}, states)
+	assert.NoError(t, err)
+
+	err = validateStateTransitions(
+		"state-c", commercetools.StateTypeEnumOrderState, []string{"state-a", "state-b"}, states)
+	assert.Error(t, err)
+}
@@ -0,0 +1,141 @@
+package commercetools
+
+import (
+	"context"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+	"github.com/labd/commercetools-go-sdk/commercetools"
+)
+
+// resourceStateTransitions manages only the transitions of an existing state.
+// State machines are often cyclic, which can't be expressed when the
+// transitions are part of the state resource itself since Terraform requires
+// an acyclic graph. With this resource all states are created first after
+// which the transitions are set.
+func resourceStateTransitions() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceStateTransitionsCreate,
+		Read:   resourceStateTransitionsRead,
+		Update: resourceStateTransitionsUpdate,
+		Delete: resourceStateTransitionsDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceStateTransitionsImportState,
+		},
+		Schema: map[string]*schema.Schema{
+			"from": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			// The states are referenced by key, the same as the transitions
+			// of the state resource
+			"to": {
+				Type:     schema.TypeSet,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringIsNotWhiteSpace,
+				},
+			},
+		},
+	}
+}
+
+func resourceStateTransitionsImportState(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	d.Set("from", d.Id())
+	return []*schema.ResourceData{d}, nil
+}
+
+func resourceStateTransitionsCreate(d *schema.ResourceData, m interface{}) error {
+	d.SetId(d.Get("from").(string))
+
+	to := expandStringArray(d.Get("to").(*schema.Set).List())
+	if err := resourceStateTransitionsSet(d, m, to); err != nil {
+		d.SetId("")
+		return err
+	}
+
+	return resourceStateTransitionsRead(d, m)
+}
+
+func resourceStateTransitionsRead(d *schema.ResourceData, m interface{}) error {
+	client := getClient(m)
+	state, err := client.StateGetWithID(
+		context.Background(), d.Id(),
+		commercetools.WithReferenceExpansion("transitions[*]"))
+
+	if err != nil {
+		if ctErr, ok := err.(commercetools.ErrorResponse); ok {
+			if ctErr.StatusCode == 404 {
+				d.SetId("")
+				return nil
+			}
+		}
+		return err
+	}
+
+	d.Set("from", state.ID)
+	d.Set("to", flattenStateTransitions(state.Transitions))
+	return nil
+}
+
+func resourceStateTransitionsUpdate(d *schema.ResourceData, m interface{}) error {
+	if d.HasChange("to") {
+		to := expandStringArray(d.Get("to").(*schema.Set).List())
+		if err := resourceStateTransitionsSet(d, m, to); err != nil {
+			return err
+		}
+	}
+	return resourceStateTransitionsRead(d, m)
+}
+
+// Deleting the transitions resets them, which means the state can transition
+// to any other state again.
+func resourceStateTransitionsDelete(d *schema.ResourceData, m interface{}) error {
+	err := resourceStateTransitionsSet(d, m, nil)
+	if err != nil {
+		if ctErr, ok := err.(commercetools.ErrorResponse); ok {
+			if ctErr.StatusCode == 404 {
+				return nil
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+func resourceStateTransitionsSet(d *schema.ResourceData, m interface{}, to []string) error {
+	client := getClient(m)
+
+	// Lock to prevent concurrent updates due to Version number conflicts
+	ctMutexKV.Lock(d.Id())
+	defer ctMutexKV.Unlock(d.Id())
+
+	state, err := client.StateGetWithID(context.Background(), d.Id())
+	if err != nil {
+		return err
+	}
+
+	var transitions []commercetools.StateResourceIdentifier
+	for _, key := range to {
+		transitions = append(transitions, commercetools.StateResourceIdentifier{Key: key})
+	}
+
+	input := &commercetools.StateUpdateWithIDInput{
+		ID:      state.ID,
+		Version: state.Version,
+		Actions: []commercetools.StateUpdateAction{
+			&commercetools.StateSetTransitionsAction{Transitions: transitions},
+		},
+	}
+
+	log.Printf(
+		"[DEBUG] Will perform update operation with the following actions:\n%s",
+		stringFormatActions(input.Actions))
+
+	_, err = client.StateUpdateWithID(context.Background(), input)
+	return err
+}
@@ -0,0 +1,71 @@
+package commercetools
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+)
+
+func TestAccStateTransitions_cyclic(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckStateTransitionsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccStateTransitionsConfig(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"commercetools_state_transitions.acctest-a", "to.#", "1",
+					),
+					resource.TestCheckResourceAttr(
+						"commercetools_state_transitions.acctest-b", "to.#", "1",
+					),
+				),
+			},
+		},
+	})
+}
+
+func testAccStateTransitionsConfig() string {
+	return `
+	resource "commercetools_state" "acctest-a" {
+		key = "state-transitions-a"
+		type = "ReviewState"
+		name = {
+			en = "State A"
+		}
+
+		lifecycle {
+			ignore_changes = [transitions]
+		}
+	}
+
+	resource "commercetools_state" "acctest-b" {
+		key = "state-transitions-b"
+		type = "ReviewState"
+		name = {
+			en = "State B"
+		}
+
+		lifecycle {
+			ignore_changes = [transitions]
+		}
+	}
+
+	resource "commercetools_state_transitions" "acctest-a" {
+		from = commercetools_state.acctest-a.id
+		to   = [commercetools_state.acctest-b.key]
+	}
+
+	resource "commercetools_state_transitions" "acctest-b" {
+		from = commercetools_state.acctest-b.id
+		to   = [commercetools_state.acctest-a.key]
+	}
+	`
+}
+
+func testAccCheckStateTransitionsDestroy(s *terraform.State) error {
+	return nil
+}
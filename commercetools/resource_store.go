@@ -3,11 +3,18 @@ package commercetools
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/labd/commercetools-go-sdk/commercetools"
 )
 
@@ -39,6 +46,14 @@ func resourceStore() *schema.Resource {
 				Optional: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
+			"countries": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validation.StringMatch(regexp.MustCompile("^[A-Z]{2}$"), "must be a two letter ISO 3166-1 country code"),
+				},
+			},
 			"distribution_channels": {
 				Type:     schema.TypeList,
 				Optional: true,
@@ -49,29 +64,176 @@ func resourceStore() *schema.Resource {
 				Optional: true,
 				Elem:     &schema.Schema{Type: schema.TypeString},
 			},
+			"custom": customFieldSchema(),
 		},
+		CustomizeDiff: customdiff.All(
+			resourceStoreValidateLanguages,
+			resourceStoreValidateChannelRoles,
+		),
 	}
 }
 
+// The store of the SDK doesn't support countries and custom fields, so the
+// store is created and read with the restClient together with the types below.
+type storeDraft struct {
+	commercetools.StoreDraft
+	Countries []storeCountry                   `json:"countries,omitempty"`
+	Custom    *commercetools.CustomFieldsDraft `json:"custom,omitempty"`
+}
+
+type storeObject struct {
+	commercetools.Store
+	Countries []storeCountry              `json:"countries,omitempty"`
+	Custom    *commercetools.CustomFields `json:"custom,omitempty"`
+}
+
+type storeCountry struct {
+	Code string `json:"code"`
+}
+
+// storeReadQuery expands the channels and the type of the custom fields, so
+// their keys can be read
+var storeReadQuery = url.Values{
+	"expand": []string{"distributionChannels[*]", "supplyChannels[*]", "custom.type"},
+}
+
+// storeChannelRoles are the roles the channels of a store need
+var storeChannelRoles = map[string]commercetools.ChannelRoleEnum{
+	"distribution_channels": commercetools.ChannelRoleEnumProductDistribution,
+	"supply_channels":       commercetools.ChannelRoleEnumInventorySupply,
+}
+
+// resourceStoreValidateLanguages checks that the languages of the store are
+// languages of the project, which commercetools requires
+func resourceStoreValidateLanguages(d *schema.ResourceDiff, m interface{}) error {
+	if m == nil || !d.NewValueKnown("languages") || !d.HasChange("languages") {
+		return nil
+	}
+	languages := expandStringArray(d.Get("languages").([]interface{}))
+	if len(languages) == 0 {
+		return nil
+	}
+
+	meta := m.(*providerMeta)
+	project, err := meta.projectCache.get(meta.client)
+	if err != nil {
+		log.Printf("[DEBUG] Unable to fetch the project to validate the languages of store %s: %s", d.Get("key"), err)
+		return nil
+	}
+	projectLanguages := make([]string, len(project.Languages))
+	for i, language := range project.Languages {
+		projectLanguages[i] = string(language)
+	}
+	return validateStoreLanguages(languages, projectLanguages)
+}
+
+func validateStoreLanguages(languages []string, projectLanguages []string) error {
+	unknown := []string{}
+	for _, language := range languages {
+		if !stringInSlice(language, projectLanguages) {
+			unknown = append(unknown, language)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf(
+			"the languages %s are not languages of the project, add them to the languages of the project first (the project has %s)",
+			strings.Join(unknown, ", "), strings.Join(projectLanguages, ", "))
+	}
+	return nil
+}
+
+// resourceStoreValidateChannelRoles checks that the existing channels of the
+// store have the role needed for their use in the store, which commercetools
+// requires
+func resourceStoreValidateChannelRoles(d *schema.ResourceDiff, m interface{}) error {
+	if m == nil {
+		return nil
+	}
+	for attribute := range storeChannelRoles {
+		if !d.NewValueKnown(attribute) {
+			return nil
+		}
+	}
+
+	keys := []string{}
+	for attribute := range storeChannelRoles {
+		keys = append(keys, expandStringArray(d.Get(attribute).([]interface{}))...)
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	result, err := getClient(m).ChannelQuery(context.Background(), &commercetools.QueryInput{
+		Where: fmt.Sprintf("key in (%s)", quotePredicateValues(keys)),
+		Limit: len(keys),
+	})
+	if err != nil {
+		log.Printf("[DEBUG] Unable to fetch the channels of store %s: %s", d.Get("key"), err)
+		return nil
+	}
+
+	// Channels which don't exist yet are created in the same apply
+	if errs := validateStoreChannelRoles(d, result.Results); len(errs) > 0 {
+		return fmt.Errorf("%s", strings.Join(errs, ", "))
+	}
+	return nil
+}
+
+func validateStoreChannelRoles(d resourceChange, channels []commercetools.Channel) []string {
+	lookup := make(map[string]commercetools.Channel, len(channels))
+	for _, channel := range channels {
+		lookup[channel.Key] = channel
+	}
+
+	errs := []string{}
+	for _, attribute := range []string{"distribution_channels", "supply_channels"} {
+		role := storeChannelRoles[attribute]
+		for _, key := range expandStringArray(d.Get(attribute).([]interface{})) {
+			channel, ok := lookup[key]
+			if !ok {
+				continue
+			}
+			hasRole := false
+			for _, channelRole := range channel.Roles {
+				hasRole = hasRole || channelRole == role
+			}
+			if !hasRole {
+				errs = append(errs, fmt.Sprintf(
+					"channel %s in %s doesn't have the role %s", key, attribute, role))
+			}
+		}
+	}
+	return errs
+}
+
 func resourceStoreCreate(d *schema.ResourceData, m interface{}) error {
 	name := commercetools.LocalizedString(
 		expandStringMap(d.Get("name").(map[string]interface{})))
 	dcIdentifiers := expandStoreChannels(d.Get("distribution_channels"))
+	scIdentifiers := expandStoreChannels(d.Get("supply_channels"))
+	custom, err := expandCustomFieldsDraft(d, getClient(m))
+	if err != nil {
+		return err
+	}
 
-	draft := &commercetools.StoreDraft{
-		Key:                  d.Get("key").(string),
-		Name:                 &name,
-		Languages:            expandStringArray(d.Get("languages").([]interface{})),
-		DistributionChannels: dcIdentifiers,
+	draft := &storeDraft{
+		StoreDraft: commercetools.StoreDraft{
+			Key:                  d.Get("key").(string),
+			Name:                 &name,
+			Languages:            expandStringArray(d.Get("languages").([]interface{})),
+			DistributionChannels: dcIdentifiers,
+			SupplyChannels:       scIdentifiers,
+		},
+		Countries: expandStoreCountries(d.Get("countries").([]interface{})),
+		Custom:    custom,
 	}
 
-	client := getClient(m)
+	client := getRestClient(m)
 
-	var store *commercetools.Store
+	store := &storeObject{}
 
-	err := resource.Retry(20*time.Second, func() *resource.RetryError {
-		var err error
-		store, err = client.StoreCreate(context.Background(), draft)
+	err = resource.Retry(20*time.Second, func() *resource.RetryError {
+		err := client.create(context.Background(), "stores", nil, draft, store)
 
 		if err != nil {
 			return handleCommercetoolsError(err)
@@ -89,13 +251,10 @@ func resourceStoreCreate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceStoreRead(d *schema.ResourceData, m interface{}) error {
-	client := getClient(m)
+	client := getRestClient(m)
 
-	store, err := client.StoreGetWithID(
-		context.Background(), d.Id(),
-		commercetools.WithReferenceExpansion("distributionChannels[*]"),
-		commercetools.WithReferenceExpansion("supplyChannels[*]"),
-	)
+	store := &storeObject{}
+	err := client.get(context.Background(), "stores/"+url.PathEscape(d.Id()), storeReadQuery, store)
 
 	if err != nil {
 		if ctErr, ok := err.(commercetools.ErrorResponse); ok {
@@ -116,24 +275,22 @@ func resourceStoreRead(d *schema.ResourceData, m interface{}) error {
 	}
 
 	log.Printf("[DEBUG] Store read, distributionChannels: %+v", store.DistributionChannels)
-	if store.DistributionChannels != nil {
-		channelKeys, err := flattenStoreChannels(store.DistributionChannels)
-		if err != nil {
-			return err
-		}
-		log.Printf("[DEBUG] Setting channel keys to: %+v", channelKeys)
-		d.Set("distribution_channels", channelKeys)
+	dcKeys, err := flattenStoreChannels(store.DistributionChannels)
+	if err != nil {
+		return err
 	}
-	log.Printf("[DEBUG] Store read, supplyChannels: %+v", store.SupplyChannels)
+	log.Printf("[DEBUG] Setting channel keys to: %+v", dcKeys)
+	d.Set("distribution_channels", dcKeys)
 
-	if store.SupplyChannels != nil {
-		channelKeys, err := flattenStoreChannels(store.SupplyChannels)
-		if err != nil {
-			return err
-		}
-		log.Printf("[DEBUG] Setting channel keys to: %+v", channelKeys)
-		d.Set("supply_channels", channelKeys)
+	log.Printf("[DEBUG] Store read, supplyChannels: %+v", store.SupplyChannels)
+	scKeys, err := flattenStoreChannels(store.SupplyChannels)
+	if err != nil {
+		return err
 	}
+	log.Printf("[DEBUG] Setting channel keys to: %+v", scKeys)
+	d.Set("supply_channels", scKeys)
+	d.Set("countries", flattenStoreCountries(store.Countries))
+	d.Set("custom", flattenCustomFields(store.Custom))
 	return nil
 }
 
@@ -162,6 +319,16 @@ func resourceStoreUpdate(d *schema.ResourceData, m interface{}) error {
 			&commercetools.StoreSetLanguagesAction{Languages: languages})
 	}
 
+	// The SDK has no setCountries action for stores, it is passed as is
+	if d.HasChange("countries") {
+		input.Actions = append(
+			input.Actions,
+			map[string]interface{}{
+				"action":    "setCountries",
+				"countries": expandStoreCountries(d.Get("countries").([]interface{})),
+			})
+	}
+
 	if d.HasChange("distribution_channels") {
 		dcIdentifiers := expandStoreChannels(d.Get("distribution_channels"))
 
@@ -190,7 +357,17 @@ func resourceStoreUpdate(d *schema.ResourceData, m interface{}) error {
 		)
 	}
 
-	_, err := client.StoreUpdateWithID(context.Background(), input)
+	customActions, err := resourceStoreCustomFieldActions(d, client)
+	if err != nil {
+		return err
+	}
+	input.Actions = append(input.Actions, customActions...)
+
+	log.Printf(
+		"[DEBUG] Will perform update operation with the following actions:\n%s",
+		stringFormatActions(input.Actions))
+
+	_, err = client.StoreUpdateWithID(context.Background(), input)
 	if err != nil {
 		return err
 	}
@@ -198,6 +375,55 @@ func resourceStoreUpdate(d *schema.ResourceData, m interface{}) error {
 	return resourceStoreRead(d, m)
 }
 
+func expandStoreCountries(input []interface{}) []storeCountry {
+	countries := []storeCountry{}
+	for _, code := range expandStringArray(input) {
+		countries = append(countries, storeCountry{Code: code})
+	}
+	return countries
+}
+
+func flattenStoreCountries(input []storeCountry) []string {
+	codes := []string{}
+	for _, country := range input {
+		codes = append(codes, country.Code)
+	}
+	return codes
+}
+
+// resourceStoreCustomFieldActions returns the actions to update the custom
+// fields, the SDK has no custom field actions for stores so these are passed
+// as is
+func resourceStoreCustomFieldActions(d *schema.ResourceData, client *commercetools.Client) ([]commercetools.StoreUpdateAction, error) {
+	changes, err := resourceCustomFieldChanges(d, client)
+	if changes == nil || err != nil {
+		return nil, err
+	}
+
+	actions := []commercetools.StoreUpdateAction{}
+	if changes.TypeChanged {
+		action := map[string]interface{}{"action": "setCustomType"}
+		if changes.Type != nil {
+			action["type"] = changes.Type
+			action["fields"] = changes.Fields
+		}
+		actions = append(actions, action)
+	}
+
+	// Set the fields in a fixed order, so the planned actions are stable
+	names := make([]string, 0, len(changes.Changed))
+	for name := range changes.Changed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		actions = append(
+			actions,
+			map[string]interface{}{"action": "setCustomField", "name": name, "value": changes.Changed[name]})
+	}
+	return actions, nil
+}
+
 func resourceStoreDelete(d *schema.ResourceData, m interface{}) error {
 	client := getClient(m)
 	version := d.Get("version").(int)
@@ -2,10 +2,15 @@ package commercetools
 
 import (
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/labd/commercetools-go-sdk/commercetools"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccStore_createAndUpdateWithID(t *testing.T) {
@@ -88,6 +93,9 @@ func TestAccStore_createAndUpdateDistributionLanguages(t *testing.T) {
 					resource.TestCheckResourceAttr(
 						"commercetools_store.test", "distribution_channels.0", "TEST",
 					),
+					resource.TestCheckResourceAttr(
+						"commercetools_store.test", "supply_channels.0", "TEST-SUPPLY",
+					),
 				),
 			},
 			{
@@ -96,6 +104,9 @@ func TestAccStore_createAndUpdateDistributionLanguages(t *testing.T) {
 					resource.TestCheckResourceAttr(
 						"commercetools_store.test", "distribution_channels.#", "0",
 					),
+					resource.TestCheckResourceAttr(
+						"commercetools_store.test", "supply_channels.#", "0",
+					),
 				),
 			},
 			{
@@ -107,6 +118,9 @@ func TestAccStore_createAndUpdateDistributionLanguages(t *testing.T) {
 					resource.TestCheckResourceAttr(
 						"commercetools_store.test", "distribution_channels.0", "TEST",
 					),
+					resource.TestCheckResourceAttr(
+						"commercetools_store.test", "supply_channels.0", "TEST-SUPPLY",
+					),
 				),
 			},
 		},
@@ -155,6 +169,11 @@ func testAccNewStoreConfigWithChannels(name string, key string, languages []stri
 		roles = ["ProductDistribution"]
 	}
 
+	resource "commercetools_channel" "test_supply_channel" {
+		key = "TEST-SUPPLY"
+		roles = ["InventorySupply"]
+	}
+
 	resource "commercetools_store" "test" {
 		name = {
 			en = "%[1]s"
@@ -163,6 +182,7 @@ func testAccNewStoreConfigWithChannels(name string, key string, languages []stri
 		key = "%[2]s"
 		languages = %[3]q
 		distribution_channels = [commercetools_channel.test_channel.key]
+		supply_channels = [commercetools_channel.test_supply_channel.key]
 	}
 	`, name, key, languages)
 }
@@ -188,3 +208,179 @@ func testAccNewStoreConfigWithoutChannels(name string, key string, languages []s
 func testAccCheckStoreDestroy(s *terraform.State) error {
 	return nil
 }
+
+func TestExpandStoreChannels(t *testing.T) {
+	identifiers := expandStoreChannels([]interface{}{"NL-DIST", "BE-DIST"})
+	assert.Equal(t, []commercetools.ChannelResourceIdentifier{
+		{Key: "NL-DIST"},
+		{Key: "BE-DIST"},
+	}, identifiers)
+}
+
+func TestFlattenStoreChannels(t *testing.T) {
+	keys, err := flattenStoreChannels([]commercetools.ChannelReference{
+		{ID: "1", Obj: &commercetools.Channel{ID: "1", Key: "NL-SUP"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"NL-SUP"}, keys)
+
+	keys, err = flattenStoreChannels(nil)
+	assert.NoError(t, err)
+	assert.Empty(t, keys)
+
+	_, err = flattenStoreChannels([]commercetools.ChannelReference{{ID: "1"}})
+	assert.Error(t, err)
+}
+
+func TestValidateStoreChannelRoles(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceStore().Schema, map[string]interface{}{
+		"key":                   "store",
+		"distribution_channels": []interface{}{"web", "warehouse"},
+		"supply_channels":       []interface{}{"warehouse", "new"},
+	})
+
+	warnings := validateStoreChannelRoles(d, []commercetools.Channel{
+		{Key: "web", Roles: []commercetools.ChannelRoleEnum{commercetools.ChannelRoleEnumProductDistribution}},
+		{Key: "warehouse", Roles: []commercetools.ChannelRoleEnum{commercetools.ChannelRoleEnumInventorySupply}},
+	})
+	assert.Equal(t, []string{
+		"channel warehouse in distribution_channels doesn't have the role ProductDistribution",
+	}, warnings)
+}
+
+func TestValidateStoreLanguages(t *testing.T) {
+	assert.NoError(t, validateStoreLanguages([]string{"en", "nl"}, []string{"en", "nl", "de"}))
+	assert.EqualError(t,
+		validateStoreLanguages([]string{"en", "fr"}, []string{"en", "nl"}),
+		"the languages fr are not languages of the project, add them to the languages of the project first (the project has en, nl)")
+}
+
+func TestResourceStoreCustomFieldActions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "type-id", "version": 1, "fieldDefinitions": [
+			{"name": "banner", "type": {"name": "String"}},
+			{"name": "theme", "type": {"name": "String"}},
+			{"name": "title", "type": {"name": "LocalizedString"}}
+		]}`))
+	}))
+	defer server.Close()
+	client := commercetools.New(&commercetools.Config{
+		ProjectKey: "my-project",
+		URL:        server.URL,
+		HTTPClient: server.Client(),
+	})
+
+	resource := resourceStore()
+	state := &terraform.InstanceState{
+		ID: "1234",
+		Attributes: map[string]string{
+			"id":                     "1234",
+			"key":                    "my-store",
+			"version":                "1",
+			"custom.#":               "1",
+			"custom.0.type_id":       "type-id",
+			"custom.0.type_key":      "store-fields",
+			"custom.0.fields.%":      "3",
+			"custom.0.fields.banner": "summer",
+			"custom.0.fields.limit":  "10",
+			"custom.0.fields.title":  `{"en":"Hello"}`,
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"key": "my-store",
+		"custom": []interface{}{
+			map[string]interface{}{
+				"type_key": "store-fields",
+				"fields": map[string]interface{}{
+					"banner": "winter",
+					"theme":  "dark",
+					"title":  `{"en": "Hello"}`,
+				},
+			},
+		},
+	})
+
+	diff, err := resource.Diff(state, config, nil)
+	assert.NoError(t, err)
+	d, err := schema.InternalMap(resource.Schema).Data(state, diff)
+	assert.NoError(t, err)
+
+	// The localized title is equivalent and not updated
+	actions, err := resourceStoreCustomFieldActions(d, client)
+	assert.NoError(t, err)
+	assert.Equal(t, []commercetools.StoreUpdateAction{
+		map[string]interface{}{"action": "setCustomField", "name": "banner", "value": "winter"},
+		map[string]interface{}{"action": "setCustomField", "name": "limit", "value": nil},
+		map[string]interface{}{"action": "setCustomField", "name": "theme", "value": "dark"},
+	}, actions)
+
+	// Changing the type sets all fields at once
+	config = terraform.NewResourceConfigRaw(map[string]interface{}{
+		"key": "my-store",
+		"custom": []interface{}{
+			map[string]interface{}{
+				"type_key": "storefront-fields",
+				"fields":   map[string]interface{}{"theme": "dark"},
+			},
+		},
+	})
+	diff, err = resource.Diff(state, config, nil)
+	assert.NoError(t, err)
+	d, err = schema.InternalMap(resource.Schema).Data(state, diff)
+	assert.NoError(t, err)
+
+	actions, err = resourceStoreCustomFieldActions(d, client)
+	assert.NoError(t, err)
+	assert.Equal(t, []commercetools.StoreUpdateAction{
+		map[string]interface{}{
+			"action": "setCustomType",
+			"type":   &commercetools.TypeResourceIdentifier{Key: "storefront-fields"},
+			"fields": &commercetools.FieldContainer{"theme": "dark"},
+		},
+	}, actions)
+}
+
+func TestResourceStoreRead(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/my-project/stores/1234", r.URL.Path)
+		assert.Contains(t, r.URL.Query()["expand"], "custom.type")
+		fmt.Fprint(w, `{
+			"id": "1234",
+			"version": 3,
+			"key": "my-store",
+			"name": {"en": "My store"},
+			"distributionChannels": [],
+			"countries": [{"code": "NL"}, {"code": "BE"}],
+			"custom": {
+				"type": {"typeId": "type", "id": "type-id", "obj": {"id": "type-id", "key": "store-fields"}},
+				"fields": {"banner": "summer", "limit": 10}
+			}
+		}`)
+	}))
+	defer server.Close()
+
+	d := schema.TestResourceDataRaw(t, resourceStore().Schema, map[string]interface{}{})
+	d.SetId("1234")
+	meta := &providerMeta{rest: newRestClient(server.Client(), server.URL, "my-project")}
+
+	assert.NoError(t, resourceStoreRead(d, meta))
+	assert.Equal(t, 3, d.Get("version"))
+	assert.Equal(t, []interface{}{"NL", "BE"}, d.Get("countries"))
+	assert.Equal(t, "type-id", d.Get("custom.0.type_id"))
+	assert.Equal(t, "store-fields", d.Get("custom.0.type_key"))
+	assert.Equal(t, map[string]interface{}{"banner": "summer", "limit": "10"}, d.Get("custom.0.fields"))
+}
+
+func TestExpandStoreCountries(t *testing.T) {
+	countries := expandStoreCountries([]interface{}{"NL", "BE"})
+	assert.Equal(t, []storeCountry{{Code: "NL"}, {Code: "BE"}}, countries)
+	assert.Equal(t, []string{"NL", "BE"}, flattenStoreCountries(countries))
+	assert.Equal(t, []storeCountry{}, expandStoreCountries([]interface{}{}))
+
+	validate := resourceStore().Schema["countries"].Elem.(*schema.Schema).ValidateFunc
+	_, errs := validate("NL", "countries.0")
+	assert.Empty(t, errs)
+	_, errs = validate("nl", "countries.0")
+	assert.Len(t, errs, 1)
+}
@@ -2,12 +2,14 @@ package commercetools
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/labd/commercetools-go-sdk/commercetools"
 )
 
@@ -18,6 +20,8 @@ const (
 	subAzureEventGrid  = "azure_eventgrid"
 	subAzureServiceBus = "azure_servicebus"
 	subGooglePubSub    = "google_pubsub"
+	subEventBridge     = "event_bridge"
+	subConfluentCloud  = "confluent_cloud"
 
 	// Formats
 	cloudEvents = "cloud_events"
@@ -47,6 +51,50 @@ var destinationFields = map[string][]string{
 		"project_id",
 		"topic",
 	},
+	subEventBridge: {
+		"region",
+		"account_id",
+	},
+	subConfluentCloud: {
+		"bootstrap_server",
+		"api_key",
+		"api_secret",
+		"topic",
+	},
+}
+
+// subscriptionResourceTypeIDs are the resource types which can be used in the
+// changes and messages of a subscription
+var subscriptionResourceTypeIDs = []string{
+	"cart",
+	"cart-discount",
+	"category",
+	"channel",
+	"customer",
+	"customer-email-token",
+	"customer-group",
+	"customer-password-token",
+	"discount-code",
+	"extension",
+	"inventory-entry",
+	"key-value-document",
+	"order",
+	"order-edit",
+	"payment",
+	"product",
+	"product-discount",
+	"product-price",
+	"product-selection",
+	"product-type",
+	"review",
+	"shipping-method",
+	"shopping-list",
+	"state",
+	"store",
+	"subscription",
+	"tax-category",
+	"type",
+	"zone",
 }
 
 var formatFields = map[string][]string{
@@ -74,6 +122,10 @@ func resourceSubscription() *schema.Resource {
 				Type:         schema.TypeMap,
 				Optional:     true,
 				ValidateFunc: validateDestination,
+				// The destination contains secrets like the access key of
+				// the Azure Event Grid topic. Since the secrets are stored in
+				// a map the complete destination is marked as sensitive.
+				Sensitive: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"type": {
@@ -94,10 +146,18 @@ func resourceSubscription() *schema.Resource {
 							Optional:         true,
 							DiffSuppressFunc: suppressIfNotDestinationType(subSQS),
 						},
+						// AWS SQS / EventBridge
 						"region": {
 							Type:             schema.TypeString,
 							Optional:         false,
-							DiffSuppressFunc: suppressIfNotDestinationType(subSQS),
+							DiffSuppressFunc: suppressIfNotDestinationType(subSQS, subEventBridge),
+						},
+
+						// AWS EventBridge
+						"account_id": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							DiffSuppressFunc: suppressIfNotDestinationType(subEventBridge),
 						},
 
 						// AWS SNS / SQS / Azure Event Grid
@@ -135,10 +195,33 @@ func resourceSubscription() *schema.Resource {
 							Optional:         true,
 							DiffSuppressFunc: suppressIfNotDestinationType(subGooglePubSub),
 						},
+						// Google Pub Sub / Confluent Cloud
 						"topic": {
 							Type:             schema.TypeString,
 							Optional:         true,
-							DiffSuppressFunc: suppressIfNotDestinationType(subGooglePubSub),
+							DiffSuppressFunc: suppressIfNotDestinationType(subGooglePubSub, subConfluentCloud),
+						},
+
+						// Confluent Cloud
+						"bootstrap_server": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							DiffSuppressFunc: suppressIfNotDestinationType(subConfluentCloud),
+						},
+						"api_key": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							DiffSuppressFunc: suppressIfNotDestinationType(subConfluentCloud),
+						},
+						"api_secret": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							DiffSuppressFunc: suppressIfNotDestinationType(subConfluentCloud),
+						},
+						"key": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							DiffSuppressFunc: suppressIfNotDestinationType(subConfluentCloud),
 						},
 					},
 				},
@@ -147,6 +230,8 @@ func resourceSubscription() *schema.Resource {
 				Type:         schema.TypeMap,
 				Optional:     true,
 				ValidateFunc: validateFormat,
+				// commercetools has no update action to change the format
+				ForceNew: true,
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"type": {
@@ -171,7 +256,10 @@ func resourceSubscription() *schema.Resource {
 						"resource_type_ids": {
 							Type:     schema.TypeList,
 							Optional: true,
-							Elem:     &schema.Schema{Type: schema.TypeString},
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice(subscriptionResourceTypeIDs, false),
+							},
 						},
 					},
 				},
@@ -182,8 +270,9 @@ func resourceSubscription() *schema.Resource {
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"resource_type_id": {
-							Type:     schema.TypeString,
-							Optional: true,
+							Type:         schema.TypeString,
+							Optional:     true,
+							ValidateFunc: validation.StringInSlice(subscriptionResourceTypeIDs, false),
 						},
 						"types": {
 							Type:     schema.TypeList,
@@ -270,14 +359,17 @@ func resourceSubscriptionRead(d *schema.ResourceData, m interface{}) error {
 		d.SetId("")
 	} else {
 		log.Print("[DEBUG] Found following subscriptions:")
-		log.Print(stringFormatObject(subscription))
+		log.Print(stringFormatObject(redactSubscription(*subscription)))
 
 		d.Set("version", subscription.Version)
 		d.Set("key", subscription.Key)
-		d.Set("destination", subscription.Destination)
-		d.Set("format", subscription.Format)
-		d.Set("message", subscription.Messages)
-		d.Set("changes", subscription.Changes)
+		d.Set("destination", flattenSubscriptionDestination(
+			subscription.Destination, d.Get("destination").(map[string]interface{})))
+		d.Set("format", flattenSubscriptionFormat(
+			subscription.Format, d.Get("format").(map[string]interface{})))
+		d.Set("message", flattenSubscriptionMessages(subscription.Messages))
+		d.Set("changes", flattenSubscriptionChanges(
+			subscription.Changes, d.Get("changes").([]interface{})))
 	}
 	return nil
 }
@@ -373,11 +465,132 @@ func resourceSubscriptionGetDestination(d *schema.ResourceData) (commercetools.D
 			ProjectID: input["project_id"].(string),
 			Topic:     input["topic"].(string),
 		}, nil
+	case subEventBridge:
+		return eventBridgeDestination{
+			Region:    input["region"].(string),
+			AccountID: input["account_id"].(string),
+		}, nil
+	case subConfluentCloud:
+		key, _ := input["key"].(string)
+		return confluentCloudDestination{
+			BootstrapServer: input["bootstrap_server"].(string),
+			APIKey:          input["api_key"].(string),
+			APISecret:       input["api_secret"].(string),
+			Topic:           input["topic"].(string),
+			Key:             key,
+		}, nil
 	default:
 		return nil, fmt.Errorf("Destination type %s not implemented", input["type"])
 	}
 }
 
+// flattenSubscriptionDestination returns the destination as stored in the
+// state. Secrets are kept from the current state since commercetools doesn't
+// necessarily return them as they were set. The SDK returns no destination
+// for destination types it doesn't support (for example EventBridge), the
+// current state is returned as is in that case.
+func flattenSubscriptionDestination(destination commercetools.Destination, current map[string]interface{}) map[string]interface{} {
+	secret := func(name string) interface{} {
+		if value, ok := current[name]; ok {
+			return value
+		}
+		return ""
+	}
+
+	switch d := destination.(type) {
+	case commercetools.SnsDestination:
+		return map[string]interface{}{
+			"type":          subSNS,
+			"topic_arn":     d.TopicArn,
+			"access_key":    d.AccessKey,
+			"access_secret": secret("access_secret"),
+		}
+	case commercetools.SqsDestination:
+		return map[string]interface{}{
+			"type":          subSQS,
+			"queue_url":     d.QueueURL,
+			"access_key":    d.AccessKey,
+			"access_secret": secret("access_secret"),
+			"region":        d.Region,
+		}
+	case commercetools.AzureEventGridDestination:
+		return map[string]interface{}{
+			"type":       subAzureEventGrid,
+			"uri":        d.URI,
+			"access_key": secret("access_key"),
+		}
+	case commercetools.AzureServiceBusDestination:
+		return map[string]interface{}{
+			"type":              subAzureServiceBus,
+			"connection_string": secret("connection_string"),
+		}
+	case commercetools.GoogleCloudPubSubDestination:
+		return map[string]interface{}{
+			"type":       subGooglePubSub,
+			"project_id": d.ProjectID,
+			"topic":      d.Topic,
+		}
+	}
+	return current
+}
+
+// redactSubscription returns a copy of the subscription with the secrets of
+// the destination removed, for use in debug logs
+func redactSubscription(subscription commercetools.Subscription) commercetools.Subscription {
+	const redacted = "<redacted>"
+
+	switch d := subscription.Destination.(type) {
+	case commercetools.SnsDestination:
+		d.AccessSecret = redacted
+		subscription.Destination = d
+	case commercetools.SqsDestination:
+		d.AccessSecret = redacted
+		subscription.Destination = d
+	case commercetools.AzureEventGridDestination:
+		d.AccessKey = redacted
+		subscription.Destination = d
+	case commercetools.AzureServiceBusDestination:
+		d.ConnectionString = redacted
+		subscription.Destination = d
+	}
+	return subscription
+}
+
+// eventBridgeDestination is the AWS EventBridge destination, which is not
+// supported by the commercetools-go-sdk yet
+type eventBridgeDestination struct {
+	Region    string `json:"region"`
+	AccountID string `json:"accountId"`
+}
+
+// MarshalJSON override to set the discriminator value
+func (obj eventBridgeDestination) MarshalJSON() ([]byte, error) {
+	type Alias eventBridgeDestination
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		*Alias
+	}{Type: "EventBridge", Alias: (*Alias)(&obj)})
+}
+
+// confluentCloudDestination is the Confluent Cloud (Kafka) destination, which
+// is not supported by the commercetools-go-sdk yet
+type confluentCloudDestination struct {
+	BootstrapServer string `json:"bootstrapServer"`
+	APIKey          string `json:"apiKey"`
+	APISecret       string `json:"apiSecret"`
+	Topic           string `json:"topic"`
+	Key             string `json:"key,omitempty"`
+}
+
+// MarshalJSON override to set the discriminator value
+func (obj confluentCloudDestination) MarshalJSON() ([]byte, error) {
+	type Alias confluentCloudDestination
+	return json.Marshal(struct {
+		Type string `json:"type"`
+		*Alias
+	}{Type: "ConfluentCloud", Alias: (*Alias)(&obj)})
+}
+
 func resourceSubscriptionGetFormat(d *schema.ResourceData) (commercetools.DeliveryFormat, error) {
 	input := d.Get("format").(map[string]interface{})
 
@@ -393,6 +606,27 @@ func resourceSubscriptionGetFormat(d *schema.ResourceData) (commercetools.Delive
 	return nil, nil
 }
 
+// flattenSubscriptionFormat returns the format as stored in the state.
+// commercetools returns the platform format when no format is set, which is
+// left out of the state when no format is configured.
+func flattenSubscriptionFormat(format commercetools.DeliveryFormat, current map[string]interface{}) map[string]interface{} {
+	switch f := format.(type) {
+	case commercetools.DeliveryCloudEventsFormat:
+		return map[string]interface{}{
+			"type":                 cloudEvents,
+			"cloud_events_version": f.CloudEventsVersion,
+		}
+	case commercetools.DeliveryPlatformFormat:
+		if len(current) == 0 {
+			return current
+		}
+		return map[string]interface{}{
+			"type": platform,
+		}
+	}
+	return current
+}
+
 func resourceSubscriptionGetChanges(d *schema.ResourceData) []commercetools.ChangeSubscription {
 	var result []commercetools.ChangeSubscription
 	input := d.Get("changes").([]interface{})
@@ -425,6 +659,55 @@ func resourceSubscriptionGetMessages(d *schema.ResourceData) []commercetools.Mes
 	return messageObjects
 }
 
+func flattenSubscriptionMessages(messages []commercetools.MessageSubscription) []map[string]interface{} {
+	result := make([]map[string]interface{}, len(messages))
+	for i, message := range messages {
+		result[i] = map[string]interface{}{
+			"resource_type_id": message.ResourceTypeID,
+			"types":            message.Types,
+		}
+	}
+	return result
+}
+
+// flattenSubscriptionChanges returns the changes as stored in the state. The
+// resource type ids can be spread over multiple changes blocks, when the ids
+// in the state match the ids in commercetools the current blocks are kept.
+func flattenSubscriptionChanges(changes []commercetools.ChangeSubscription, current []interface{}) []interface{} {
+	ids := make([]interface{}, len(changes))
+	lookup := make(map[string]bool, len(changes))
+	for i, change := range changes {
+		ids[i] = change.ResourceTypeID
+		lookup[change.ResourceTypeID] = true
+	}
+
+	currentIDs := []string{}
+	for _, raw := range current {
+		if raw == nil {
+			continue
+		}
+		currentIDs = append(currentIDs, expandStringArray(
+			raw.(map[string]interface{})["resource_type_ids"].([]interface{}))...)
+	}
+
+	matches := len(currentIDs) == len(changes)
+	for _, id := range currentIDs {
+		if !lookup[id] {
+			matches = false
+		}
+	}
+	if matches {
+		return current
+	}
+
+	if len(ids) == 0 {
+		return []interface{}{}
+	}
+	return []interface{}{
+		map[string]interface{}{"resource_type_ids": ids},
+	}
+}
+
 func validateTypeAttribute(val interface{}, key string, attributeFields map[string][]string) (warns []string, errs []error) {
 	valueAsMap := val.(map[string]interface{})
 
@@ -1,6 +1,7 @@
 package commercetools
 
 import (
+	"encoding/json"
 	"fmt"
 	"regexp"
 	"testing"
@@ -8,6 +9,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/labd/commercetools-go-sdk/commercetools"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestValidateDestination(t *testing.T) {
@@ -33,6 +36,18 @@ func TestValidateDestination(t *testing.T) {
 			"project_id": "<project_id>",
 			"topic":      "<topic>",
 		},
+		{
+			"type":       "event_bridge",
+			"region":     "<region>",
+			"account_id": "<account_id>",
+		},
+		{
+			"type":             "confluent_cloud",
+			"bootstrap_server": "<bootstrap_server>",
+			"api_key":          "<api_key>",
+			"api_secret":       "<api_secret>",
+			"topic":            "<topic>",
+		},
 	}
 	for _, validDestination := range validDestinations {
 		_, errs := validateDestination(validDestination, "destination")
@@ -57,6 +72,16 @@ func TestValidateDestination(t *testing.T) {
 			"type":  "google_pubsub",
 			"topic": "<topic>",
 		},
+		{
+			"type":   "event_bridge",
+			"region": "<region>",
+		},
+		{
+			"type":             "confluent_cloud",
+			"bootstrap_server": "<bootstrap_server>",
+			"api_key":          "<api_key>",
+			"topic":            "<topic>",
+		},
 	}
 	for _, validDestination := range invalidDestinations {
 		_, errs := validateDestination(validDestination, "destination")
@@ -66,6 +91,140 @@ func TestValidateDestination(t *testing.T) {
 	}
 }
 
+func TestEventBridgeDestinationMarshal(t *testing.T) {
+	data, err := json.Marshal(eventBridgeDestination{
+		Region:    "eu-west-1",
+		AccountID: "123456789012",
+	})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "EventBridge",
+		"region": "eu-west-1",
+		"accountId": "123456789012"
+	}`, string(data))
+}
+
+func TestConfluentCloudDestinationMarshal(t *testing.T) {
+	data, err := json.Marshal(confluentCloudDestination{
+		BootstrapServer: "pkc-1234.europe-west1.gcp.confluent.cloud:9092",
+		APIKey:          "<api_key>",
+		APISecret:       "<api_secret>",
+		Topic:           "commercetools",
+	})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "ConfluentCloud",
+		"bootstrapServer": "pkc-1234.europe-west1.gcp.confluent.cloud:9092",
+		"apiKey": "<api_key>",
+		"apiSecret": "<api_secret>",
+		"topic": "commercetools"
+	}`, string(data))
+}
+
+func TestFlattenSubscriptionDestination(t *testing.T) {
+	current := map[string]interface{}{
+		"type":       "google_pubsub",
+		"project_id": "old-project",
+		"topic":      "old-topic",
+	}
+
+	result := flattenSubscriptionDestination(commercetools.GoogleCloudPubSubDestination{
+		ProjectID: "my-project",
+		Topic:     "my-topic",
+	}, current)
+	assert.Equal(t, map[string]interface{}{
+		"type":       "google_pubsub",
+		"project_id": "my-project",
+		"topic":      "my-topic",
+	}, result)
+
+	current = map[string]interface{}{
+		"type":              "azure_servicebus",
+		"connection_string": "<connection_string>",
+	}
+	result = flattenSubscriptionDestination(commercetools.AzureServiceBusDestination{
+		ConnectionString: "<masked>",
+	}, current)
+	assert.Equal(t, "<connection_string>", result["connection_string"])
+
+	// Destinations unknown to the SDK are kept as is
+	current = map[string]interface{}{
+		"type":       "event_bridge",
+		"region":     "eu-west-1",
+		"account_id": "123456789012",
+	}
+	assert.Equal(t, current, flattenSubscriptionDestination(nil, current))
+}
+
+func TestRedactSubscription(t *testing.T) {
+	subscription := commercetools.Subscription{
+		ID: "1234",
+		Destination: commercetools.AzureEventGridDestination{
+			URI:       "https://example.westeurope-1.eventgrid.azure.net/api/events",
+			AccessKey: "secret",
+		},
+	}
+
+	result := redactSubscription(subscription)
+	assert.Equal(t, "<redacted>", result.Destination.(commercetools.AzureEventGridDestination).AccessKey)
+	assert.Equal(t, "secret", subscription.Destination.(commercetools.AzureEventGridDestination).AccessKey)
+}
+
+func TestFlattenSubscriptionFormat(t *testing.T) {
+	result := flattenSubscriptionFormat(commercetools.DeliveryCloudEventsFormat{
+		CloudEventsVersion: "1.0",
+	}, map[string]interface{}{})
+	assert.Equal(t, map[string]interface{}{
+		"type":                 "cloud_events",
+		"cloud_events_version": "1.0",
+	}, result)
+
+	// The platform format is the default, which is not stored when no format
+	// is configured
+	result = flattenSubscriptionFormat(commercetools.DeliveryPlatformFormat{}, map[string]interface{}{})
+	assert.Empty(t, result)
+
+	result = flattenSubscriptionFormat(commercetools.DeliveryPlatformFormat{}, map[string]interface{}{
+		"type": "platform",
+	})
+	assert.Equal(t, map[string]interface{}{"type": "platform"}, result)
+}
+
+func TestFlattenSubscriptionMessages(t *testing.T) {
+	result := flattenSubscriptionMessages([]commercetools.MessageSubscription{
+		{ResourceTypeID: "product", Types: []string{"ProductPublished"}},
+		{ResourceTypeID: "order"},
+	})
+	assert.Equal(t, []map[string]interface{}{
+		{"resource_type_id": "product", "types": []string{"ProductPublished"}},
+		{"resource_type_id": "order", "types": []string(nil)},
+	}, result)
+}
+
+func TestFlattenSubscriptionChanges(t *testing.T) {
+	changes := []commercetools.ChangeSubscription{
+		{ResourceTypeID: "product"},
+		{ResourceTypeID: "category"},
+	}
+
+	// The same ids spread over multiple blocks are kept as is
+	current := []interface{}{
+		map[string]interface{}{"resource_type_ids": []interface{}{"category"}},
+		map[string]interface{}{"resource_type_ids": []interface{}{"product"}},
+	}
+	assert.Equal(t, current, flattenSubscriptionChanges(changes, current))
+
+	// Changes made outside of terraform are stored in a single block
+	current = []interface{}{
+		map[string]interface{}{"resource_type_ids": []interface{}{"product"}},
+	}
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"resource_type_ids": []interface{}{"product", "category"}},
+	}, flattenSubscriptionChanges(changes, current))
+
+	assert.Equal(t, []interface{}{}, flattenSubscriptionChanges(nil, []interface{}{}))
+}
+
 func TestAccSubscription_basic(t *testing.T) {
 	rName := acctest.RandString(5)
 
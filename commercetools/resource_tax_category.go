@@ -33,12 +33,31 @@ func resourceTaxCategory() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"replaces": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The id or key of a tax category which is replaced by this one, the shipping methods and products using it are moved to this tax category before it is deleted",
+			},
 			"version": {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
 		},
+		CustomizeDiff: resourceTaxCategoryValidateReplaces,
+	}
+}
+
+// resourceTaxCategoryValidateReplaces checks that a new tax category which
+// replaces another one has a key, the key is used to find the tax category
+// again when the replacement failed halfway
+func resourceTaxCategoryValidateReplaces(d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() != "" || !d.NewValueKnown("replaces") || !d.NewValueKnown("key") {
+		return nil
 	}
+	if d.Get("replaces").(string) != "" && d.Get("key").(string) == "" {
+		return fmt.Errorf("a key is required when replaces is set, it is used to continue a replacement which failed halfway")
+	}
+	return nil
 }
 
 func resourceTaxCategoryValidateAmount(val interface{}, key string) (warns []string, errs []error) {
@@ -50,6 +69,44 @@ func resourceTaxCategoryValidateAmount(val interface{}, key string) (warns []str
 }
 
 func resourceTaxCategoryCreate(d *schema.ResourceData, m interface{}) error {
+	client := getClient(m)
+	replaces := d.Get("replaces").(string)
+
+	var taxCategory *commercetools.TaxCategory
+	if replaces != "" {
+		// A replacement which failed halfway left the new tax category
+		// behind, continue the replacement with it
+		existing, err := client.TaxCategoryGetWithKey(context.Background(), d.Get("key").(string))
+		if err == nil {
+			log.Printf("[INFO] Continuing the replacement of tax category %s with tax category %s", replaces, existing.ID)
+			taxCategory = existing
+		} else if ctErr, ok := err.(commercetools.ErrorResponse); !ok || ctErr.StatusCode != 404 {
+			return err
+		}
+	}
+	if taxCategory == nil {
+		created, err := resourceTaxCategoryCreateDraft(d, m)
+		if err != nil {
+			return err
+		}
+		taxCategory = created
+	}
+
+	// The id is only set once the replacement succeeded. A failed create
+	// taints the resource, and destroying the new tax category fails while
+	// the moved objects reference it.
+	if replaces != "" {
+		if err := migrateTaxCategory(client, getRestClient(m), replaces, taxCategory.ID); err != nil {
+			return err
+		}
+	}
+
+	d.SetId(taxCategory.ID)
+	d.Set("version", taxCategory.Version)
+	return resourceTaxCategoryRead(d, m)
+}
+
+func resourceTaxCategoryCreateDraft(d *schema.ResourceData, m interface{}) (*commercetools.TaxCategory, error) {
 	client := getClient(m)
 	var taxCategory *commercetools.TaxCategory
 	emptyTaxRates := []commercetools.TaxRateDraft{}
@@ -72,17 +129,13 @@ func resourceTaxCategoryCreate(d *schema.ResourceData, m interface{}) error {
 	})
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if taxCategory == nil {
 		log.Fatal("No tax category created?")
 	}
-
-	d.SetId(taxCategory.ID)
-	d.Set("version", taxCategory.Version)
-
-	return resourceTaxCategoryRead(d, m)
+	return taxCategory, nil
 }
 
 func resourceTaxCategoryRead(d *schema.ResourceData, m interface{}) error {
@@ -158,12 +211,20 @@ func resourceTaxCategoryUpdate(d *schema.ResourceData, m interface{}) error {
 		"[DEBUG] Will perform update operation with the following actions:\n%s",
 		stringFormatActions(input.Actions))
 
-	_, err = client.TaxCategoryUpdateWithID(context.Background(), input)
-	if err != nil {
-		if ctErr, ok := err.(commercetools.ErrorResponse); ok {
-			log.Printf("[DEBUG] %v: %v", ctErr, stringFormatErrorExtras(ctErr))
+	if len(input.Actions) > 0 {
+		_, err = client.TaxCategoryUpdateWithID(context.Background(), input)
+		if err != nil {
+			if ctErr, ok := err.(commercetools.ErrorResponse); ok {
+				log.Printf("[DEBUG] %v: %v", ctErr, stringFormatErrorExtras(ctErr))
+			}
+			return err
+		}
+	}
+
+	if replaces := d.Get("replaces").(string); d.HasChange("replaces") && replaces != "" {
+		if err := migrateTaxCategory(client, getRestClient(m), replaces, d.Id()); err != nil {
+			return err
 		}
-		return err
 	}
 
 	return resourceTaxCategoryRead(d, m)
@@ -178,6 +239,10 @@ func resourceTaxCategoryDelete(d *schema.ResourceData, m interface{}) error {
 
 	taxCategory, err := client.TaxCategoryGetWithID(context.Background(), d.Id())
 	if err != nil {
+		// The tax category was deleted when another tax category replaced it
+		if ctErr, ok := err.(commercetools.ErrorResponse); ok && ctErr.StatusCode == 404 {
+			return nil
+		}
 		return err
 	}
 	_, err = client.TaxCategoryDeleteWithID(context.Background(), d.Id(), taxCategory.Version)
@@ -0,0 +1,64 @@
+package commercetools
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/labd/commercetools-go-sdk/commercetools"
+)
+
+// taxCategoryMigrationEndpoints are the endpoints of the objects referencing
+// a tax category, in the order in which they are moved to the new category
+var taxCategoryMigrationEndpoints = []string{"shipping-methods", "products"}
+
+// migrateTaxCategory replaces the old tax category (by id or key) with the
+// new one: all shipping methods and products referencing it are moved to
+// the new category, after which the old category is deleted. A tax category
+// which doesn't exist has already been replaced, so applying again continues
+// a replacement which failed halfway.
+func migrateTaxCategory(client *commercetools.Client, rest *restClient, replaces string, newID string) error {
+	ctx := context.Background()
+
+	old, err := client.TaxCategoryGetWithID(ctx, replaces)
+	if ctErr, ok := err.(commercetools.ErrorResponse); ok && ctErr.StatusCode == 404 {
+		old, err = client.TaxCategoryGetWithKey(ctx, replaces)
+	}
+	if err != nil {
+		if ctErr, ok := err.(commercetools.ErrorResponse); ok && ctErr.StatusCode == 404 {
+			log.Printf("[DEBUG] Tax category %s doesn't exist, it was already replaced", replaces)
+			return nil
+		}
+		return err
+	}
+	if old.ID == newID {
+		return fmt.Errorf("the tax category can't replace itself")
+	}
+
+	migrated := 0
+	for _, endpoint := range taxCategoryMigrationEndpoints {
+		count, err := migrateTaxCategoryReferences(rest, endpoint, old.ID, newID)
+		migrated += count
+		if err != nil {
+			return fmt.Errorf(
+				"failed to move the %s to tax category %s (%d objects moved), apply again to continue: %w",
+				endpoint, newID, migrated, err)
+		}
+	}
+	log.Printf("[INFO] Moved %d objects from tax category %s to tax category %s", migrated, old.ID, newID)
+
+	_, err = client.TaxCategoryDeleteWithID(ctx, old.ID, old.Version)
+	return err
+}
+
+// migrateTaxCategoryReferences moves all objects of the endpoint from the old
+// tax category to the new one
+func migrateTaxCategoryReferences(client *restClient, endpoint string, oldID string, newID string) (int, error) {
+	where := fmt.Sprintf("taxCategory(id = %q)", oldID)
+	return migrateReferences(client, endpoint, where, func(object referenceMigrationObject) map[string]interface{} {
+		return map[string]interface{}{
+			"action":      "setTaxCategory",
+			"taxCategory": map[string]string{"typeId": "tax-category", "id": newID},
+		}
+	})
+}
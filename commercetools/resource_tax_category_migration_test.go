@@ -0,0 +1,157 @@
+package commercetools
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/labd/commercetools-go-sdk/commercetools"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrateTaxCategory(t *testing.T) {
+	requests := []string{}
+	moved := false
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.Method + " " + r.URL.Path {
+		case "GET /my-project/tax-categories/standard":
+			w.WriteHeader(404)
+			w.Write([]byte(`{"statusCode": 404, "message": "not found"}`))
+		case "GET /my-project/tax-categories/key=standard":
+			w.Write([]byte(`{"id": "old-id", "version": 4, "key": "standard"}`))
+		case "GET /my-project/shipping-methods":
+			assert.Equal(t, `taxCategory(id = "old-id")`, r.URL.Query().Get("where"))
+			if moved {
+				w.Write([]byte(`{"results": []}`))
+			} else {
+				w.Write([]byte(`{"results": [{"id": "dhl", "version": 2}]}`))
+			}
+		case "POST /my-project/shipping-methods/dhl":
+			moved = true
+			w.Write([]byte(`{"id": "dhl", "version": 3}`))
+		case "GET /my-project/products":
+			w.Write([]byte(`{"results": []}`))
+		case "DELETE /my-project/tax-categories/old-id":
+			assert.Equal(t, "4", r.URL.Query().Get("version"))
+			w.Write([]byte(`{"id": "old-id"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := commercetools.New(&commercetools.Config{
+		ProjectKey: "my-project",
+		URL:        server.URL,
+		HTTPClient: server.Client(),
+	})
+	rest := newRestClient(server.Client(), server.URL, "my-project")
+
+	assert.NoError(t, migrateTaxCategory(client, rest, "standard", "new-id"))
+	assert.Equal(t, []string{
+		"GET /my-project/tax-categories/standard",
+		"GET /my-project/tax-categories/key=standard",
+		"GET /my-project/shipping-methods",
+		"POST /my-project/shipping-methods/dhl",
+		"GET /my-project/shipping-methods",
+		"GET /my-project/products",
+		"DELETE /my-project/tax-categories/old-id",
+	}, requests)
+
+	assert.EqualError(t,
+		migrateTaxCategory(client, rest, "standard", "old-id"),
+		"the tax category can't replace itself")
+}
+
+func TestResourceTaxCategoryCreateContinuesReplacement(t *testing.T) {
+	requests := []string{}
+	created := false
+	failMove := true
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.Method + " " + r.URL.Path {
+		case "GET /my-project/tax-categories/key=external":
+			if !created {
+				w.WriteHeader(404)
+				w.Write([]byte(`{"statusCode": 404, "message": "not found"}`))
+				return
+			}
+			w.Write([]byte(`{"id": "new-id", "version": 1, "key": "external"}`))
+		case "POST /my-project/tax-categories":
+			created = true
+			w.WriteHeader(201)
+			w.Write([]byte(`{"id": "new-id", "version": 1, "key": "external"}`))
+		case "GET /my-project/tax-categories/old-id":
+			w.Write([]byte(`{"id": "old-id", "version": 4, "key": "standard"}`))
+		case "GET /my-project/shipping-methods":
+			if failMove {
+				w.Write([]byte(`{"results": [{"id": "dhl", "version": 2}]}`))
+			} else {
+				w.Write([]byte(`{"results": []}`))
+			}
+		case "POST /my-project/shipping-methods/dhl":
+			w.WriteHeader(400)
+			w.Write([]byte(`{"statusCode": 400, "message": "invalid"}`))
+		case "GET /my-project/products":
+			w.Write([]byte(`{"results": []}`))
+		case "DELETE /my-project/tax-categories/old-id":
+			w.Write([]byte(`{"id": "old-id"}`))
+		case "GET /my-project/tax-categories/new-id":
+			w.Write([]byte(`{"id": "new-id", "version": 1, "key": "external", "name": "External"}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	meta := &providerMeta{
+		client: commercetools.New(&commercetools.Config{
+			ProjectKey: "my-project",
+			URL:        server.URL,
+			HTTPClient: server.Client(),
+		}),
+		rest: newRestClient(server.Client(), server.URL, "my-project"),
+	}
+	d := schema.TestResourceDataRaw(t, resourceTaxCategory().Schema, map[string]interface{}{
+		"key":      "external",
+		"name":     "External",
+		"replaces": "old-id",
+	})
+
+	// The failed replacement doesn't store the new tax category, so it isn't
+	// tainted
+	assert.Error(t, resourceTaxCategoryCreate(d, meta))
+	assert.Equal(t, "", d.Id())
+
+	failMove = false
+	requests = []string{}
+	assert.NoError(t, resourceTaxCategoryCreate(d, meta))
+	assert.Equal(t, "new-id", d.Id())
+	assert.Equal(t, []string{
+		"GET /my-project/tax-categories/key=external",
+		"GET /my-project/tax-categories/old-id",
+		"GET /my-project/shipping-methods",
+		"GET /my-project/products",
+		"DELETE /my-project/tax-categories/old-id",
+		"GET /my-project/tax-categories/new-id",
+	}, requests)
+}
+
+func TestResourceTaxCategoryValidateReplaces(t *testing.T) {
+	resource := resourceTaxCategory()
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":     "External",
+		"replaces": "standard",
+	})
+	_, err := resource.Diff(nil, config, &providerMeta{})
+	assert.EqualError(t, err, "a key is required when replaces is set, it is used to continue a replacement which failed halfway")
+}
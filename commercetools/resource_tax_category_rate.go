@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"math"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
@@ -64,9 +65,45 @@ func resourceTaxCategoryRate() *schema.Resource {
 				},
 			},
 		},
+		CustomizeDiff: resourceTaxCategoryRateValidateSubRates,
 	}
 }
 
+// resourceTaxCategoryRateValidateSubRates validates that the amounts of the
+// sub rates add up to the amount of the tax rate, which is required by
+// commercetools when sub rates are used.
+func resourceTaxCategoryRateValidateSubRates(d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("amount") || !d.NewValueKnown("sub_rate") {
+		return nil
+	}
+
+	subRates := d.Get("sub_rate").([]interface{})
+	if len(subRates) == 0 {
+		return nil
+	}
+
+	amounts := make([]float64, len(subRates))
+	for i, raw := range subRates {
+		amounts[i] = raw.(map[string]interface{})["amount"].(float64)
+	}
+	return validateTaxRateSubRateAmounts(d.Get("amount").(float64), amounts)
+}
+
+func validateTaxRateSubRateAmounts(amount float64, subRateAmounts []float64) error {
+	total := 0.0
+	for _, subRateAmount := range subRateAmounts {
+		total += subRateAmount
+	}
+
+	// Allow for rounding errors of the float values
+	if math.Abs(total-amount) > 1e-9 {
+		return fmt.Errorf(
+			"the amounts of the sub rates (%g) must add up to the amount of the tax rate (%g)",
+			total, amount)
+	}
+	return nil
+}
+
 func resourceTaxCategoryRateImportState(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	client := getClient(meta)
 	taxRateID := d.Id()
@@ -196,8 +233,10 @@ func setTaxRateState(d *schema.ResourceData, taxRate *commercetools.TaxRate) {
 	subRateData := make([]map[string]interface{}, len(taxRate.SubRates))
 	for srIndex, subrate := range taxRate.SubRates {
 		subRateData[srIndex] = map[string]interface{}{
-			"name":   subrate.Name,
-			"amount": subrate.Amount,
+			"name": subrate.Name,
+		}
+		if subrate.Amount != nil {
+			subRateData[srIndex]["amount"] = *subrate.Amount
 		}
 	}
 	d.Set("sub_rate", subRateData)
@@ -8,6 +8,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/terraform"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestAccTaxCategoryRate_createAndUpdateWithID(t *testing.T) {
@@ -276,3 +277,9 @@ resource "commercetools_tax_category_rate" "test_rate" {
 func testAccCheckTaxCategoryRateDestroy(s *terraform.State) error {
 	return nil
 }
+
+func TestValidateTaxRateSubRateAmounts(t *testing.T) {
+	assert.NoError(t, validateTaxRateSubRateAmounts(0.3, []float64{0.2, 0.1}))
+	assert.NoError(t, validateTaxRateSubRateAmounts(0.19, []float64{0.19}))
+	assert.Error(t, validateTaxRateSubRateAmounts(0.2, []float64{0.2, 0.1}))
+}
@@ -5,14 +5,100 @@ import (
 	"fmt"
 	"log"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/helper/validation"
 	"github.com/labd/commercetools-go-sdk/commercetools"
 )
 
+// typeResourceTypeIDs are the resource types which can be customized with a
+// type. The resource types of the commercetools-go-sdk are extended with the
+// ones added to commercetools after the release of the SDK.
+var typeResourceTypeIDs = []string{
+	string(commercetools.ResourceTypeIDAsset),
+	string(commercetools.ResourceTypeIDCategory),
+	string(commercetools.ResourceTypeIDChannel),
+	string(commercetools.ResourceTypeIDCustomer),
+	string(commercetools.ResourceTypeIDOrder),
+	string(commercetools.ResourceTypeIDOrderEdit),
+	string(commercetools.ResourceTypeIDInventoryEntry),
+	string(commercetools.ResourceTypeIDLineItem),
+	string(commercetools.ResourceTypeIDCustomLineItem),
+	string(commercetools.ResourceTypeIDProductPrice),
+	string(commercetools.ResourceTypeIDPayment),
+	string(commercetools.ResourceTypeIDPaymentInterfaceInteraction),
+	string(commercetools.ResourceTypeIDReview),
+	string(commercetools.ResourceTypeIDShoppingList),
+	string(commercetools.ResourceTypeIDShoppingListTextLineItem),
+	string(commercetools.ResourceTypeIDDiscountCode),
+	string(commercetools.ResourceTypeIDCartDiscount),
+	string(commercetools.ResourceTypeIDCustomerGroup),
+	"address",
+	"associate-role",
+	"business-unit",
+	"order-delivery",
+	"order-parcel",
+	"order-return-item",
+	"product-selection",
+	"quote",
+	"shipping",
+	"shipping-method",
+	"standalone-price",
+	"store",
+	"transaction",
+}
+
+// fieldReferenceTypeIDs are the resource types which can be referenced by a
+// field of type Reference
+var fieldReferenceTypeIDs = []string{
+	string(commercetools.ReferenceTypeIDCart),
+	string(commercetools.ReferenceTypeIDCartDiscount),
+	string(commercetools.ReferenceTypeIDCategory),
+	string(commercetools.ReferenceTypeIDChannel),
+	string(commercetools.ReferenceTypeIDCustomer),
+	string(commercetools.ReferenceTypeIDCustomerGroup),
+	string(commercetools.ReferenceTypeIDDiscountCode),
+	string(commercetools.ReferenceTypeIDKeyValueDocument),
+	string(commercetools.ReferenceTypeIDPayment),
+	string(commercetools.ReferenceTypeIDProduct),
+	string(commercetools.ReferenceTypeIDProductType),
+	string(commercetools.ReferenceTypeIDProductDiscount),
+	string(commercetools.ReferenceTypeIDOrder),
+	string(commercetools.ReferenceTypeIDReview),
+	string(commercetools.ReferenceTypeIDShoppingList),
+	string(commercetools.ReferenceTypeIDShippingMethod),
+	string(commercetools.ReferenceTypeIDState),
+	string(commercetools.ReferenceTypeIDStore),
+	string(commercetools.ReferenceTypeIDTaxCategory),
+	string(commercetools.ReferenceTypeIDType),
+	string(commercetools.ReferenceTypeIDZone),
+	string(commercetools.ReferenceTypeIDInventoryEntry),
+	string(commercetools.ReferenceTypeIDOrderEdit),
+	"associate-role",
+	"business-unit",
+	"product-selection",
+}
+
+// validateTypeResourceTypeID checks the resource type id during the plan, and
+// suggests the resource type id when it is written with underscores
+func validateTypeResourceTypeID(val interface{}, key string) (warns []string, errs []error) {
+	v := val.(string)
+	if stringInSlice(v, typeResourceTypeIDs) {
+		return
+	}
+	if suggestion := strings.ReplaceAll(strings.ToLower(v), "_", "-"); stringInSlice(suggestion, typeResourceTypeIDs) {
+		errs = append(errs, fmt.Errorf("%q is not a valid resource type id, did you mean %q?", v, suggestion))
+		return
+	}
+	errs = append(errs, fmt.Errorf(
+		"%q is not a valid resource type id, expected one of %s", v, strings.Join(typeResourceTypeIDs, ", ")))
+	return
+}
+
 func resourceType() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceTypeCreate,
@@ -38,8 +124,18 @@ func resourceType() *schema.Resource {
 			"resource_type_ids": {
 				Type:     schema.TypeList,
 				Required: true,
-				ForceNew: true,
-				Elem:     &schema.Schema{Type: schema.TypeString},
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateTypeResourceTypeID,
+				},
+			},
+			"replace_strategy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      typeReplaceRecreate,
+				ValidateFunc: validation.StringInSlice([]string{typeReplaceRecreate, typeReplaceMigrate}, false),
+				Description: "How the type is replaced when the resource_type_ids change, recreate deletes the type first, " +
+					"migrate moves the objects using the type to the new type before deleting it",
 			},
 			"field": {
 				Type:     schema.TypeList,
@@ -69,6 +165,10 @@ func resourceType() *schema.Resource {
 							Type:     schema.TypeString,
 							Optional: true,
 							Default:  commercetools.TextInputHintSingleLine,
+							ValidateFunc: validation.StringInSlice([]string{
+								string(commercetools.TypeTextInputHintSingleLine),
+								string(commercetools.TypeTextInputHintMultiLine),
+							}, false),
 						},
 					},
 				},
@@ -87,6 +187,9 @@ func resourceType() *schema.Resource {
 				for _, field := range newV {
 					newF := field.(map[string]interface{})
 					name := newF["name"].(string)
+					if err := resourceTypeValidateSetElementType(name, newF); err != nil {
+						return err
+					}
 					oldF, ok := oldLookup[name].(map[string]interface{})
 					if !ok {
 						// It means this is a new field, that's ok.
@@ -107,6 +210,18 @@ func resourceType() *schema.Resource {
 							name, oldType["name"], newType["name"])
 					}
 
+					if oldType["name"] == "Reference" && oldType["reference_type_id"] != newType["reference_type_id"] {
+						return fmt.Errorf(
+							"Field '%s' reference_type_id changed from %s to %s. Changing types is not supported; please remove the field first and re-define it later",
+							name, oldType["reference_type_id"], newType["reference_type_id"])
+					}
+
+					if oldType["name"] == "Set" {
+						if err := resourceTypeValidateElementTypeChange(name, oldType, newType); err != nil {
+							return err
+						}
+					}
+
 					if oldF["required"] != newF["required"] {
 						return fmt.Errorf(
 							"Error on the '%s' attribute: Updating the 'required' attribute is not supported. Consider removing the attribute first and then re-adding it",
@@ -115,10 +230,84 @@ func resourceType() *schema.Resource {
 				}
 				return nil
 			}),
+			// The resource type ids can't be changed, with the migrate
+			// strategy the type is replaced during the update
+			customdiff.ForceNewIf("resource_type_ids", func(d *schema.ResourceDiff, meta interface{}) bool {
+				return d.Get("replace_strategy").(string) != typeReplaceMigrate
+			}),
+			resourceTypeValidateReplacement,
 		),
 	}
 }
 
+// resourceTypeValidateSetElementType checks that a Set field defines the type
+// of its elements
+func resourceTypeValidateSetElementType(name string, field map[string]interface{}) error {
+	fieldTypes, _ := field["type"].([]interface{})
+	if len(fieldTypes) == 0 || fieldTypes[0] == nil {
+		return nil
+	}
+	fieldType := fieldTypes[0].(map[string]interface{})
+	if fieldType["name"] != "Set" {
+		return nil
+	}
+	if elementTypes, _ := fieldType["element_type"].([]interface{}); len(elementTypes) == 0 {
+		return fmt.Errorf("Field '%s' is a Set without an element_type", name)
+	}
+	return nil
+}
+
+// resourceTypeValidateElementTypeChange checks that the type of the elements
+// of an existing Set field is not changed, the elements can't be converted
+func resourceTypeValidateElementTypeChange(name string, oldType map[string]interface{}, newType map[string]interface{}) error {
+	oldElementTypes, _ := oldType["element_type"].([]interface{})
+	newElementTypes, _ := newType["element_type"].([]interface{})
+	if len(oldElementTypes) == 0 || len(newElementTypes) == 0 {
+		return nil
+	}
+	oldElementType, _ := oldElementTypes[0].(map[string]interface{})
+	newElementType, _ := newElementTypes[0].(map[string]interface{})
+	if oldElementType == nil || newElementType == nil {
+		return nil
+	}
+
+	if oldElementType["name"] != newElementType["name"] {
+		return fmt.Errorf(
+			"Field '%s' element_type changed from %s to %s. Changing types is not supported; please remove the field first and re-define it later",
+			name, oldElementType["name"], newElementType["name"])
+	}
+	if oldElementType["name"] == "Reference" && oldElementType["reference_type_id"] != newElementType["reference_type_id"] {
+		return fmt.Errorf(
+			"Field '%s' element_type reference_type_id changed from %s to %s. Changing types is not supported; please remove the field first and re-define it later",
+			name, oldElementType["reference_type_id"], newElementType["reference_type_id"])
+	}
+	return nil
+}
+
+// resourceTypePlanWarnings warns when changing the fields removes values from
+// objects or results in a large number of update actions
+func resourceTypePlanWarnings(d *schema.ResourceDiff, m interface{}) ([]string, error) {
+	if d.Id() == "" || !d.HasChange("field") || !d.NewValueKnown("field") {
+		return nil, nil
+	}
+
+	old, new := d.GetChange("field")
+	actions, err := resourceTypeFieldChangeActions(old.([]interface{}), new.([]interface{}))
+	if err != nil {
+		// Invalid changes are reported when applying the change
+		return nil, nil
+	}
+	warnings := []string{}
+	for _, action := range actions {
+		if remove, ok := action.(commercetools.TypeRemoveFieldDefinitionAction); ok {
+			warnings = append(warnings, fmt.Sprintf(
+				"removing field %s removes the values of the field from all objects using the type",
+				remove.FieldName))
+		}
+	}
+	return append(warnings, updateActionCountWarning(len(actions), m)...), nil
+}
+
 func localizedValueElement() *schema.Resource {
 	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
@@ -189,8 +378,9 @@ func fieldTypeElement(setsAllowed bool) *schema.Resource {
 			Elem:     localizedValueElement(),
 		},
 		"reference_type_id": {
-			Type:     schema.TypeString,
-			Optional: true,
+			Type:         schema.TypeString,
+			Optional:     true,
+			ValidateFunc: validation.StringInSlice(fieldReferenceTypeIDs, false),
 		},
 	}
 
@@ -210,30 +400,11 @@ func resourceTypeCreate(d *schema.ResourceData, m interface{}) error {
 	client := getClient(m)
 	var ctType *commercetools.Type
 
-	name := commercetools.LocalizedString(
-		expandStringMap(d.Get("name").(map[string]interface{})))
-	description := commercetools.LocalizedString(
-		expandStringMap(d.Get("description").(map[string]interface{})))
-
-	resourceTypeIds := []commercetools.ResourceTypeID{}
-	for _, item := range expandStringArray(d.Get("resource_type_ids").([]interface{})) {
-		resourceTypeIds = append(resourceTypeIds, commercetools.ResourceTypeID(item))
-
-	}
-	fields, err := resourceTypeGetFieldDefinitions(d)
-
+	draft, err := resourceTypeDraft(d)
 	if err != nil {
 		return err
 	}
 
-	draft := &commercetools.TypeDraft{
-		Key:              d.Get("key").(string),
-		Name:             &name,
-		Description:      &description,
-		ResourceTypeIds:  resourceTypeIds,
-		FieldDefinitions: fields,
-	}
-
 	err = resource.Retry(1*time.Minute, func() *resource.RetryError {
 		var err error
 
@@ -258,6 +429,31 @@ func resourceTypeCreate(d *schema.ResourceData, m interface{}) error {
 	return resourceTypeRead(d, m)
 }
 
+func resourceTypeDraft(d *schema.ResourceData) (*commercetools.TypeDraft, error) {
+	name := commercetools.LocalizedString(
+		expandStringMap(d.Get("name").(map[string]interface{})))
+	description := commercetools.LocalizedString(
+		expandStringMap(d.Get("description").(map[string]interface{})))
+
+	resourceTypeIds := []commercetools.ResourceTypeID{}
+	for _, item := range expandStringArray(d.Get("resource_type_ids").([]interface{})) {
+		resourceTypeIds = append(resourceTypeIds, commercetools.ResourceTypeID(item))
+	}
+
+	fields, err := resourceTypeGetFieldDefinitions(d)
+	if err != nil {
+		return nil, err
+	}
+
+	return &commercetools.TypeDraft{
+		Key:              d.Get("key").(string),
+		Name:             &name,
+		Description:      &description,
+		ResourceTypeIds:  resourceTypeIds,
+		FieldDefinitions: fields,
+	}, nil
+}
+
 func resourceTypeRead(d *schema.ResourceData, m interface{}) error {
 	log.Print("[DEBUG] Reading type from commercetools")
 	client := getClient(m)
@@ -293,7 +489,7 @@ func resourceTypeRead(d *schema.ResourceData, m interface{}) error {
 			fieldData["name"] = fieldDef.Name
 			fieldData["label"] = *fieldDef.Label
 			fieldData["required"] = fieldDef.Required
-			fieldData["input_hint"] = fieldDef.InputHint
+			fieldData["input_hint"] = string(fieldDef.InputHint)
 
 			fields[i] = fieldData
 		}
@@ -341,7 +537,7 @@ func resourceTypeReadFieldType(fieldType commercetools.FieldType, setsAllowed bo
 		typeData["name"] = "DateTime"
 	} else if f, ok := fieldType.(commercetools.CustomFieldReferenceType); ok {
 		typeData["name"] = "Reference"
-		typeData["reference_type_id"] = f.ReferenceTypeID
+		typeData["reference_type_id"] = string(f.ReferenceTypeID)
 	} else if f, ok := fieldType.(commercetools.CustomFieldSetType); ok {
 		typeData["name"] = "Set"
 		if setsAllowed {
@@ -361,32 +557,68 @@ func resourceTypeReadFieldType(fieldType commercetools.FieldType, setsAllowed bo
 func resourceTypeUpdate(d *schema.ResourceData, m interface{}) error {
 	client := getClient(m)
 
+	if d.HasChange("resource_type_ids") {
+		// The replacement type is created from the complete configuration,
+		// so the other changes don't need to be applied separately
+		if err := resourceTypeReplace(d, m); err != nil {
+			return err
+		}
+		return resourceTypeRead(d, m)
+	}
+
+	actions, err := resourceTypeUpdateActions(d)
+	if err != nil {
+		return err
+	}
+
 	input := &commercetools.TypeUpdateWithIDInput{
 		ID:      d.Id(),
 		Version: d.Get("version").(int),
-		Actions: []commercetools.TypeUpdateAction{},
+		Actions: actions,
 	}
 
+	log.Printf(
+		"[DEBUG] Will perform update operation with the following actions:\n%s",
+		stringFormatActions(input.Actions))
+
+	_, err = client.TypeUpdateWithID(context.Background(), input)
+	if err != nil {
+		if ctErr, ok := err.(commercetools.ErrorResponse); ok {
+			log.Printf("[DEBUG] %v: %v", ctErr, stringFormatErrorExtras(ctErr))
+		}
+		return err
+	}
+
+	return resourceTypeRead(d, m)
+}
+
+// resourceTypeUpdateActions returns the update actions for the changes, both
+// when applying the changes and for the planned_actions during the plan.
+// Changes of the resource types replace the type instead, see
+// resourceTypeReplace.
+func resourceTypeUpdateActions(d resourceChange) ([]commercetools.TypeUpdateAction, error) {
+	actions := []commercetools.TypeUpdateAction{}
+
 	if d.HasChange("key") {
 		newKey := d.Get("key").(string)
-		input.Actions = append(
-			input.Actions,
+		actions = append(
+			actions,
 			&commercetools.TypeChangeKeyAction{Key: newKey})
 	}
 
 	if d.HasChange("name") {
 		newName := commercetools.LocalizedString(
 			expandStringMap(d.Get("name").(map[string]interface{})))
-		input.Actions = append(
-			input.Actions,
+		actions = append(
+			actions,
 			&commercetools.TypeChangeNameAction{Name: &newName})
 	}
 
 	if d.HasChange("description") {
 		newDescr := commercetools.LocalizedString(
 			expandStringMap(d.Get("description").(map[string]interface{})))
-		input.Actions = append(
-			input.Actions,
+		actions = append(
+			actions,
 			&commercetools.TypeSetDescriptionAction{
 				Description: &newDescr})
 	}
@@ -395,23 +627,11 @@ func resourceTypeUpdate(d *schema.ResourceData, m interface{}) error {
 		old, new := d.GetChange("field")
 		fieldChangeActions, err := resourceTypeFieldChangeActions(old.([]interface{}), new.([]interface{}))
 		if err != nil {
-			return err
-		}
-		input.Actions = append(input.Actions, fieldChangeActions...)
-	}
-	log.Printf(
-		"[DEBUG] Will perform update operation with the following actions:\n%s",
-		stringFormatActions(input.Actions))
-
-	_, err := client.TypeUpdateWithID(context.Background(), input)
-	if err != nil {
-		if ctErr, ok := err.(commercetools.ErrorResponse); ok {
-			log.Printf("[DEBUG] %v: %v", ctErr, stringFormatErrorExtras(ctErr))
+			return nil, err
 		}
-		return err
+		actions = append(actions, fieldChangeActions...)
 	}
-
-	return resourceTypeRead(d, m)
+	return actions, nil
 }
 
 // Generate a list of actions needed for updating the fields value in
@@ -420,16 +640,16 @@ func resourceTypeFieldChangeActions(oldValues []interface{}, newValues []interfa
 	oldLookup := createLookup(oldValues, "name")
 	newLookup := createLookup(newValues, "name")
 	actions := []commercetools.TypeUpdateAction{}
-	checkAttributeOrder := true
 
 	log.Printf("[DEBUG] Construction Field change actions")
 
-	// Check if we have fields which are removed
-	for name := range oldLookup {
+	// Check if we have fields which are removed, in the order of the old
+	// fields so the actions are the same on every plan
+	for _, value := range oldValues {
+		name := value.(map[string]interface{})["name"].(string)
 		if _, ok := newLookup[name]; !ok {
 			log.Printf("[DEBUG] Field deleted: %s", name)
 			actions = append(actions, commercetools.TypeRemoveFieldDefinitionAction{FieldName: name})
-			checkAttributeOrder = false
 		}
 	}
 
@@ -450,7 +670,6 @@ func resourceTypeFieldChangeActions(oldValues []interface{}, newValues []interfa
 			actions = append(
 				actions,
 				commercetools.TypeAddFieldDefinitionAction{FieldDefinition: fieldDef})
-			checkAttributeOrder = false
 			continue
 		}
 
@@ -466,17 +685,12 @@ func resourceTypeFieldChangeActions(oldValues []interface{}, newValues []interfa
 
 		// Update the input hint if this is changed
 		if !reflect.DeepEqual(oldV["input_hint"], newV["input_hint"]) {
-			var newInputHint commercetools.TypeTextInputHint
-			switch newV["input_hint"].(string) {
-			case "SingleLine":
-				newInputHint = commercetools.TypeTextInputHintSingleLine
-			case "MultiLine":
-				newInputHint = commercetools.TypeTextInputHintMultiLine
-			}
-
 			actions = append(
 				actions,
-				commercetools.TypeChangeInputHintAction{FieldName: name, InputHint: newInputHint})
+				commercetools.TypeChangeInputHintAction{
+					FieldName: name,
+					InputHint: commercetools.TypeTextInputHint(newV["input_hint"].(string)),
+				})
 		}
 
 		newFieldType := fieldDef.Type
@@ -494,19 +708,26 @@ func resourceTypeFieldChangeActions(oldValues []interface{}, newValues []interfa
 		actions = resourceTypeHandleEnumTypeChanges(newFieldType, oldFieldType, actions, name)
 	}
 
-	oldNames := make([]string, len(oldValues))
-	newNames := make([]string, len(newValues))
-
-	for i, value := range oldValues {
-		v := value.(map[string]interface{})
-		oldNames[i] = v["name"].(string)
+	// Removed fields disappear from the order and added fields are appended,
+	// the order is only changed when the result differs from the
+	// configuration. This way reordering never removes and re-adds fields,
+	// which would lose the values of the field.
+	currentNames := []string{}
+	for _, value := range oldValues {
+		name := value.(map[string]interface{})["name"].(string)
+		if _, ok := newLookup[name]; ok {
+			currentNames = append(currentNames, name)
+		}
 	}
+	newNames := make([]string, len(newValues))
 	for i, value := range newValues {
-		v := value.(map[string]interface{})
-		newNames[i] = v["name"].(string)
+		newNames[i] = value.(map[string]interface{})["name"].(string)
+		if _, ok := oldLookup[newNames[i]]; !ok {
+			currentNames = append(currentNames, newNames[i])
+		}
 	}
 
-	if checkAttributeOrder && !reflect.DeepEqual(oldNames, newNames) {
+	if !reflect.DeepEqual(currentNames, newNames) {
 		actions = append(
 			actions,
 			commercetools.TypeChangeFieldDefinitionOrderAction{
@@ -565,11 +786,54 @@ func resourceTypeHandleEnumTypeChanges(newFieldType commercetools.FieldType, old
 						FieldName: name,
 						Value:     &enumType.Values[i],
 					})
+				continue
+			}
+
+			oldLabel := oldEnumKeys[enumValue.Key]["label"].(map[string]interface{})
+			if !localizedStringCompare(*enumValue.Label, oldLabel) {
+				//label for this key is changed
+				actions = append(
+					actions,
+					commercetools.TypeChangeLocalizedEnumValueLabelAction{
+						FieldName: name,
+						Value:     &enumType.Values[i],
+					})
 			}
 		}
 
 		// Action: changeLocalizedEnumValueOrder
-		// TODO: Change the order of LocalizedEnumValues: https://docs.commercetools.com/http-api-projects-types.html#change-the-order-of-localizedenumvalues
+		// The added values are appended, so the order only needs to be
+		// changed when it differs from the existing values followed by the
+		// added ones. The order can only be changed when all existing values
+		// are still configured.
+		newKeys := make([]string, len(enumType.Values))
+		for i, enumValue := range enumType.Values {
+			newKeys[i] = enumValue.Key
+		}
+		expectedKeys := []string{}
+		for _, value := range oldEnumV {
+			key := value.(map[string]interface{})["key"].(string)
+			if !stringInSlice(key, newKeys) {
+				expectedKeys = nil
+				break
+			}
+			expectedKeys = append(expectedKeys, key)
+		}
+		if expectedKeys != nil {
+			for _, key := range newKeys {
+				if _, ok := oldEnumKeys[key]; !ok {
+					expectedKeys = append(expectedKeys, key)
+				}
+			}
+			if !reflect.DeepEqual(expectedKeys, newKeys) {
+				actions = append(
+					actions,
+					commercetools.TypeChangeLocalizedEnumValueOrderAction{
+						FieldName: name,
+						Keys:      newKeys,
+					})
+			}
+		}
 	}
 	return actions
 }
@@ -677,7 +941,7 @@ func getFieldType(input interface{}) (commercetools.FieldType, error) {
 		return commercetools.CustomFieldDateTimeType{}, nil
 	case "Reference":
 		refTypeID, refTypeIDOk := config["reference_type_id"].(string)
-		if !refTypeIDOk {
+		if !refTypeIDOk || refTypeID == "" {
 			return nil, fmt.Errorf("No reference_type_id specified for Reference type")
 		}
 		return commercetools.CustomFieldReferenceType{
@@ -709,9 +973,13 @@ func getFieldType(input interface{}) (commercetools.FieldType, error) {
 func readCustomFieldLocalizedEnum(values []commercetools.CustomFieldLocalizedEnumValue) []interface{} {
 	enumValues := make([]interface{}, len(values))
 	for i, value := range values {
+		label := map[string]string{}
+		if value.Label != nil {
+			label = localizedStringToMap(*value.Label)
+		}
 		enumValues[i] = map[string]interface{}{
 			"key":   value.Key,
-			"label": &value.Label,
+			"label": label,
 		}
 	}
 	return enumValues
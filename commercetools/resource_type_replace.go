@@ -0,0 +1,181 @@
+package commercetools
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/labd/commercetools-go-sdk/commercetools"
+)
+
+const (
+	typeReplaceRecreate = "recreate"
+	typeReplaceMigrate  = "migrate"
+)
+
+// typeMigrationEndpoints maps the resource type ids of types to the
+// endpoints of the objects which can use the type. Types for nested objects
+// (for example line items or assets) are not supported, since these can't be
+// queried by their type.
+var typeMigrationEndpoints = map[string][]string{
+	"cart-discount":   {"cart-discounts"},
+	"category":        {"categories"},
+	"channel":         {"channels"},
+	"customer":        {"customers"},
+	"customer-group":  {"customer-groups"},
+	"discount-code":   {"discount-codes"},
+	"inventory-entry": {"inventory"},
+	"order":           {"carts", "orders"},
+	"payment":         {"payments"},
+	"review":          {"reviews"},
+	"shipping-method": {"shipping-methods"},
+	"shopping-list":   {"shopping-lists"},
+	"store":           {"stores"},
+}
+
+// resourceTypeValidateReplacement checks that the objects of all resource
+// types of a type can be migrated when the type is replaced with the migrate
+// strategy
+func resourceTypeValidateReplacement(d *schema.ResourceDiff, m interface{}) error {
+	if d.Id() == "" || !d.HasChange("resource_type_ids") || d.Get("replace_strategy").(string) != typeReplaceMigrate {
+		return nil
+	}
+	old, _ := d.GetChange("resource_type_ids")
+	return validateTypeMigration(expandStringArray(old.([]interface{})))
+}
+
+func validateTypeMigration(resourceTypeIDs []string) error {
+	unsupported := []string{}
+	for _, resourceTypeID := range resourceTypeIDs {
+		if _, ok := typeMigrationEndpoints[resourceTypeID]; !ok {
+			unsupported = append(unsupported, resourceTypeID)
+		}
+	}
+	if len(unsupported) > 0 {
+		sort.Strings(unsupported)
+		return fmt.Errorf(
+			"the objects using the type can't be migrated for the resource types %s, use replace_strategy = %q instead",
+			strings.Join(unsupported, ", "), typeReplaceRecreate)
+	}
+	return nil
+}
+
+// resourceTypeReplace replaces the type without removing the custom fields
+// from the objects using it:
+//
+//  1. the replacement type is created with a temporary key
+//  2. all objects using the current type are moved to the replacement type
+//     with setCustomType, keeping the values of the fields
+//  3. the current type is deleted
+//  4. the key of the replacement type is changed to the configured key
+//
+// The temporary key is derived from the configured key, so when one of the
+// steps fails the next apply continues with the existing replacement type.
+func resourceTypeReplace(d *schema.ResourceData, m interface{}) error {
+	client := getClient(m)
+	ctx := context.Background()
+	key := d.Get("key").(string)
+	replacementKey := fmt.Sprintf("%s-replacement", key)
+
+	// Objects can only be moved to the replacement type when it supports
+	// their resource type
+	old, new := d.GetChange("resource_type_ids")
+	oldResourceTypeIDs := expandStringArray(old.([]interface{}))
+	for _, resourceTypeID := range removedStrings(old.([]interface{}), new.([]interface{})) {
+		for _, endpoint := range typeMigrationEndpoints[resourceTypeID] {
+			inUse, err := customTypeInUse(getRestClient(m), endpoint, d.Id())
+			if err != nil {
+				return err
+			}
+			if inUse {
+				return fmt.Errorf(
+					"the type is still used by %s, which can't be moved to the replacement type without the resource type %s",
+					endpoint, resourceTypeID)
+			}
+		}
+	}
+
+	replacement, err := client.TypeGetWithKey(ctx, replacementKey)
+	if err != nil {
+		if ctErr, ok := err.(commercetools.ErrorResponse); !ok || ctErr.StatusCode != 404 {
+			return err
+		}
+		draft, err := resourceTypeDraft(d)
+		if err != nil {
+			return err
+		}
+		draft.Key = replacementKey
+		replacement, err = client.TypeCreate(ctx, draft)
+		if err != nil {
+			return err
+		}
+	} else {
+		log.Printf("[DEBUG] Continuing the replacement of type %s with existing type %s", d.Id(), replacement.ID)
+	}
+
+	migrated := 0
+	for _, resourceTypeID := range oldResourceTypeIDs {
+		for _, endpoint := range typeMigrationEndpoints[resourceTypeID] {
+			count, err := migrateCustomType(getRestClient(m), endpoint, d.Id(), replacement.ID)
+			migrated += count
+			if err != nil {
+				return fmt.Errorf(
+					"failed to move the %s to replacement type %s (%d objects moved), apply again to continue: %w",
+					endpoint, replacementKey, migrated, err)
+			}
+		}
+	}
+	log.Printf("[DEBUG] Moved %d objects from type %s to type %s", migrated, d.Id(), replacement.ID)
+
+	current, err := client.TypeGetWithID(ctx, d.Id())
+	if err != nil {
+		return err
+	}
+	if _, err := client.TypeDeleteWithID(ctx, current.ID, current.Version); err != nil {
+		return err
+	}
+
+	// The replacement type is now the type managed by this resource
+	d.SetId(replacement.ID)
+	_, err = client.TypeUpdateWithID(ctx, &commercetools.TypeUpdateWithIDInput{
+		ID:      replacement.ID,
+		Version: replacement.Version,
+		Actions: []commercetools.TypeUpdateAction{
+			&commercetools.TypeChangeKeyAction{Key: key},
+		},
+	})
+	return err
+}
+
+// customTypeInUse returns whether any object of the endpoint uses the type
+func customTypeInUse(client *restClient, endpoint string, typeID string) (bool, error) {
+	query := url.Values{}
+	query.Set("where", fmt.Sprintf("custom(type(id = %q))", typeID))
+	query.Set("limit", "1")
+
+	page := &referenceMigrationPage{}
+	if err := client.get(context.Background(), endpoint, query, page); err != nil {
+		return false, err
+	}
+	return len(page.Results) > 0, nil
+}
+
+// migrateCustomType moves all objects of the endpoint from the old type to
+// the new type, keeping the values of their custom fields
+func migrateCustomType(client *restClient, endpoint string, oldTypeID string, newTypeID string) (int, error) {
+	where := fmt.Sprintf("custom(type(id = %q))", oldTypeID)
+	return migrateReferences(client, endpoint, where, func(object referenceMigrationObject) map[string]interface{} {
+		action := map[string]interface{}{
+			"action": "setCustomType",
+			"type":   map[string]string{"typeId": "type", "id": newTypeID},
+		}
+		if object.Custom != nil && len(object.Custom.Fields) > 0 {
+			action["fields"] = object.Custom.Fields
+		}
+		return action
+	})
+}
@@ -0,0 +1,65 @@
+package commercetools
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateTypeMigration(t *testing.T) {
+	assert.NoError(t, validateTypeMigration([]string{"order", "customer"}))
+	assert.EqualError(t,
+		validateTypeMigration([]string{"order", "line-item", "asset"}),
+		`the objects using the type can't be migrated for the resource types asset, line-item, use replace_strategy = "recreate" instead`)
+}
+
+func TestMigrateCustomType(t *testing.T) {
+	// The server keeps the type of the customers, updating a customer moves
+	// it to the new type
+	types := map[string]string{"1": "old", "2": "old", "3": "other"}
+	fields := map[string]interface{}{}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			assert.Equal(t, `custom(type(id = "old"))`, r.URL.Query().Get("where"))
+			results := []string{}
+			for _, id := range []string{"1", "2", "3"} {
+				if types[id] == "old" {
+					results = append(results, fmt.Sprintf(
+						`{"id": %q, "version": 1, "custom": {"fields": {"loyalty": %q}}}`, id, "gold-"+id))
+				}
+			}
+			w.Write([]byte(fmt.Sprintf(`{"results": [%s]}`, strings.Join(results, ","))))
+			return
+		}
+
+		id := strings.TrimPrefix(r.URL.Path, "/my-project/customers/")
+		body, _ := ioutil.ReadAll(r.Body)
+		input := struct {
+			Actions []struct {
+				Action string                 `json:"action"`
+				Type   map[string]string      `json:"type"`
+				Fields map[string]interface{} `json:"fields"`
+			} `json:"actions"`
+		}{}
+		assert.NoError(t, json.Unmarshal(body, &input))
+		assert.Equal(t, "setCustomType", input.Actions[0].Action)
+		types[id] = input.Actions[0].Type["id"]
+		fields[id] = input.Actions[0].Fields["loyalty"]
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := newRestClient(server.Client(), server.URL, "my-project")
+	migrated, err := migrateCustomType(client, "customers", "old", "new")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, migrated)
+	assert.Equal(t, map[string]string{"1": "new", "2": "new", "3": "other"}, types)
+	assert.Equal(t, map[string]interface{}{"1": "gold-1", "2": "gold-2"}, fields)
+}
@@ -3,6 +3,7 @@ package commercetools
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 
@@ -129,6 +130,265 @@ func TestGetFieldType(t *testing.T) {
 	}
 }
 
+func TestResourceTypeFieldChangeActionsOrder(t *testing.T) {
+	field := func(name string) interface{} {
+		return map[string]interface{}{
+			"name":       name,
+			"label":      map[string]interface{}{"en": name},
+			"required":   false,
+			"input_hint": "SingleLine",
+			"type": []interface{}{
+				map[string]interface{}{"name": "String"},
+			},
+		}
+	}
+
+	// Only the order changes
+	actions, err := resourceTypeFieldChangeActions(
+		[]interface{}{field("a"), field("b"), field("c")},
+		[]interface{}{field("c"), field("a"), field("b")})
+	assert.NoError(t, err)
+	assert.Equal(t, []commercetools.TypeUpdateAction{
+		commercetools.TypeChangeFieldDefinitionOrderAction{FieldNames: []string{"c", "a", "b"}},
+	}, actions)
+
+	// A field is added at the end and a field is removed, the order of the
+	// remaining fields is kept
+	actions, err = resourceTypeFieldChangeActions(
+		[]interface{}{field("a"), field("b")},
+		[]interface{}{field("b"), field("c")})
+	assert.NoError(t, err)
+	assert.Len(t, actions, 2)
+	assert.IsType(t, commercetools.TypeRemoveFieldDefinitionAction{}, actions[0])
+	assert.IsType(t, commercetools.TypeAddFieldDefinitionAction{}, actions[1])
+
+	// A field is added in the middle, so the fields are reordered afterwards
+	actions, err = resourceTypeFieldChangeActions(
+		[]interface{}{field("a"), field("b")},
+		[]interface{}{field("a"), field("c"), field("b")})
+	assert.NoError(t, err)
+	assert.Len(t, actions, 2)
+	assert.IsType(t, commercetools.TypeAddFieldDefinitionAction{}, actions[0])
+	assert.Equal(t,
+		commercetools.TypeChangeFieldDefinitionOrderAction{FieldNames: []string{"a", "c", "b"}},
+		actions[1])
+}
+
+func TestResourceTypeFieldChangeActionsEnumLabels(t *testing.T) {
+	enumField := func(label string) interface{} {
+		return map[string]interface{}{
+			"name":       "size",
+			"label":      map[string]interface{}{"en": "Size"},
+			"required":   false,
+			"input_hint": "SingleLine",
+			"type": []interface{}{
+				map[string]interface{}{
+					"name":   "Enum",
+					"values": map[string]interface{}{"s": label, "m": "Medium"},
+				},
+			},
+		}
+	}
+	localizedEnumField := func(label string) interface{} {
+		return map[string]interface{}{
+			"name":       "color",
+			"label":      map[string]interface{}{"en": "Color"},
+			"required":   false,
+			"input_hint": "SingleLine",
+			"type": []interface{}{
+				map[string]interface{}{
+					"name": "LocalizedEnum",
+					"localized_value": []interface{}{
+						map[string]interface{}{
+							"key":   "red",
+							"label": map[string]interface{}{"en": label},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	actions, err := resourceTypeFieldChangeActions(
+		[]interface{}{enumField("Smal"), localizedEnumField("Rood")},
+		[]interface{}{enumField("Small"), localizedEnumField("Red")})
+	assert.NoError(t, err)
+	assert.Equal(t, []commercetools.TypeUpdateAction{
+		commercetools.TypeChangeEnumValueLabelAction{
+			FieldName: "size",
+			Value:     &commercetools.CustomFieldEnumValue{Key: "s", Label: "Small"},
+		},
+		commercetools.TypeChangeLocalizedEnumValueLabelAction{
+			FieldName: "color",
+			Value: &commercetools.CustomFieldLocalizedEnumValue{
+				Key:   "red",
+				Label: &commercetools.LocalizedString{"en": "Red"},
+			},
+		},
+	}, actions)
+}
+
+func TestResourceTypeFieldChangeActionsLocalizedEnumOrder(t *testing.T) {
+	field := func(keys ...string) interface{} {
+		values := []interface{}{}
+		for _, key := range keys {
+			values = append(values, map[string]interface{}{
+				"key":   key,
+				"label": map[string]interface{}{"en": key},
+			})
+		}
+		return map[string]interface{}{
+			"name":       "color",
+			"label":      map[string]interface{}{"en": "Color"},
+			"required":   false,
+			"input_hint": "SingleLine",
+			"type": []interface{}{
+				map[string]interface{}{
+					"name":            "LocalizedEnum",
+					"localized_value": values,
+				},
+			},
+		}
+	}
+
+	// Appending a value doesn't change the order
+	actions, err := resourceTypeFieldChangeActions(
+		[]interface{}{field("red", "green")},
+		[]interface{}{field("red", "green", "blue")})
+	assert.NoError(t, err)
+	assert.Equal(t, []commercetools.TypeUpdateAction{
+		commercetools.TypeAddLocalizedEnumValueAction{
+			FieldName: "color",
+			Value: &commercetools.CustomFieldLocalizedEnumValue{
+				Key:   "blue",
+				Label: &commercetools.LocalizedString{"en": "blue"},
+			},
+		},
+	}, actions)
+
+	actions, err = resourceTypeFieldChangeActions(
+		[]interface{}{field("red", "green")},
+		[]interface{}{field("blue", "green", "red")})
+	assert.NoError(t, err)
+	assert.Equal(t, []commercetools.TypeUpdateAction{
+		commercetools.TypeAddLocalizedEnumValueAction{
+			FieldName: "color",
+			Value: &commercetools.CustomFieldLocalizedEnumValue{
+				Key:   "blue",
+				Label: &commercetools.LocalizedString{"en": "blue"},
+			},
+		},
+		commercetools.TypeChangeLocalizedEnumValueOrderAction{
+			FieldName: "color",
+			Keys:      []string{"blue", "green", "red"},
+		},
+	}, actions)
+
+	// The order can't be changed when a value was removed
+	actions, err = resourceTypeFieldChangeActions(
+		[]interface{}{field("red", "green")},
+		[]interface{}{field("green")})
+	assert.NoError(t, err)
+	assert.Empty(t, actions)
+}
+
+func TestReadCustomFieldLocalizedEnum(t *testing.T) {
+	red := commercetools.LocalizedString{"en": "Red", "nl": "Rood"}
+	values := readCustomFieldLocalizedEnum([]commercetools.CustomFieldLocalizedEnumValue{
+		{Key: "red", Label: &red},
+		{Key: "green"},
+	})
+
+	d := schema.TestResourceDataRaw(t, resourceType().Schema, map[string]interface{}{})
+	err := d.Set("field", []interface{}{
+		map[string]interface{}{
+			"name": "color",
+			"type": []interface{}{
+				map[string]interface{}{
+					"name":            "LocalizedEnum",
+					"localized_value": values,
+				},
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Rood", d.Get("field.0.type.0.localized_value.0.label.nl"))
+	assert.Equal(t, "green", d.Get("field.0.type.0.localized_value.1.key"))
+}
+
+func TestResourceTypeFieldChangeActionsInputHint(t *testing.T) {
+	field := func(inputHint string) interface{} {
+		return map[string]interface{}{
+			"name":       "note",
+			"label":      map[string]interface{}{"en": "Note"},
+			"required":   false,
+			"input_hint": inputHint,
+			"type": []interface{}{
+				map[string]interface{}{"name": "String"},
+			},
+		}
+	}
+
+	actions, err := resourceTypeFieldChangeActions(
+		[]interface{}{field("SingleLine")},
+		[]interface{}{field("MultiLine")})
+	assert.NoError(t, err)
+	assert.Equal(t, []commercetools.TypeUpdateAction{
+		commercetools.TypeChangeInputHintAction{
+			FieldName: "note",
+			InputHint: commercetools.TypeTextInputHintMultiLine,
+		},
+	}, actions)
+}
+
+func TestValidateTypeResourceTypeID(t *testing.T) {
+	_, errs := validateTypeResourceTypeID("shipping-method", "resource_type_ids.0")
+	assert.Empty(t, errs)
+
+	_, errs = validateTypeResourceTypeID("shipping_method", "resource_type_ids.0")
+	assert.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], `"shipping_method" is not a valid resource type id, did you mean "shipping-method"?`)
+
+	_, errs = validateTypeResourceTypeID("product", "resource_type_ids.0")
+	assert.Len(t, errs, 1)
+
+	// The objects of all resource types which can be migrated can use a type
+	for resourceTypeID := range typeMigrationEndpoints {
+		assert.Contains(t, typeResourceTypeIDs, resourceTypeID)
+	}
+}
+
+func TestResourceTypeReferenceFieldType(t *testing.T) {
+	input := map[string]interface{}{
+		"name": "Set",
+		"element_type": []interface{}{
+			map[string]interface{}{
+				"name":              "Reference",
+				"reference_type_id": "channel",
+			},
+		},
+	}
+	fieldType, err := getFieldType(input)
+	assert.NoError(t, err)
+	assert.Equal(t, commercetools.CustomFieldSetType{
+		ElementType: commercetools.CustomFieldReferenceType{
+			ReferenceTypeID: commercetools.ReferenceTypeIDChannel,
+		},
+	}, fieldType)
+
+	// Reading the type results in the configured values
+	result, err := resourceTypeReadFieldType(fieldType, true)
+	assert.NoError(t, err)
+	elementType := result[0].(map[string]interface{})["element_type"].([]interface{})[0]
+	assert.Equal(t, map[string]interface{}{
+		"name":              "Reference",
+		"reference_type_id": "channel",
+	}, elementType)
+
+	_, err = getFieldType(map[string]interface{}{"name": "Reference", "reference_type_id": ""})
+	assert.Error(t, err)
+}
+
 func TestAccTypes_basic(t *testing.T) {
 	name := "acctest_type"
 	resource.Test(t, resource.TestCase{
@@ -380,3 +640,175 @@ func testAccCheckTypesDestroy(s *terraform.State) error {
 	// TODO: Implement
 	return nil
 }
+
+func TestResourceTypeRemoveFieldDiff(t *testing.T) {
+	resource := Provider().(*schema.Provider).ResourcesMap["commercetools_type"]
+	state := &terraform.InstanceState{
+		ID: "1234",
+		Attributes: map[string]string{
+			"id":                  "1234",
+			"key":                 "order",
+			"name.en":             "Order",
+			"name.%":              "1",
+			"resource_type_ids.#": "1",
+			"resource_type_ids.0": "order",
+			"version":             "1",
+			"field.#":             "2",
+			"field.0.name":        "loyalty",
+			"field.0.label.%":     "1",
+			"field.0.label.en":    "Loyalty",
+			"field.0.required":    "false",
+			"field.0.input_hint":  "SingleLine",
+			"field.0.type.#":      "1",
+			"field.0.type.0.name": "String",
+			"field.1.name":        "note",
+			"field.1.label.%":     "1",
+			"field.1.label.en":    "Note",
+			"field.1.required":    "false",
+			"field.1.input_hint":  "SingleLine",
+			"field.1.type.#":      "1",
+			"field.1.type.0.name": "String",
+			// Set by reading the resource
+			"planned_actions.#": "0",
+			"plan_warnings.#":   "0",
+			"mc_url":            "",
+		},
+	}
+	meta := &providerMeta{updateActionWarningThreshold: defaultUpdateActionWarningThreshold}
+
+	diff, err := resource.Diff(state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"key":               "order",
+		"name":              map[string]interface{}{"en": "Order"},
+		"resource_type_ids": []interface{}{"order"},
+		"field": []interface{}{
+			map[string]interface{}{
+				"name":  "note",
+				"label": map[string]interface{}{"en": "Note"},
+				"type":  []interface{}{map[string]interface{}{"name": "String"}},
+			},
+		},
+	}), meta)
+	assert.NoError(t, err)
+
+	// The field is removed without replacing the type
+	assert.False(t, diff.RequiresNew())
+	assert.Equal(t, "1", diff.Attributes["planned_actions.#"].New)
+	assert.Equal(t,
+		`{"action":"removeFieldDefinition","fieldName":"loyalty"}`,
+		diff.Attributes["planned_actions.0"].New)
+	assert.Equal(t, "1", diff.Attributes["plan_warnings.#"].New)
+	assert.Equal(t,
+		"removing field loyalty removes the values of the field from all objects using the type",
+		diff.Attributes["plan_warnings.0"].New)
+}
+
+func TestResourceTypeChangeKeyDiff(t *testing.T) {
+	resource := Provider().(*schema.Provider).ResourcesMap["commercetools_type"]
+	state := &terraform.InstanceState{
+		ID: "1234",
+		Attributes: map[string]string{
+			"id":                  "1234",
+			"key":                 "order",
+			"name.en":             "Order",
+			"name.%":              "1",
+			"resource_type_ids.#": "1",
+			"resource_type_ids.0": "order",
+			"version":             "1",
+			"field.#":             "0",
+			// Set by reading the resource
+			"planned_actions.#": "0",
+			"plan_warnings.#":   "0",
+			"mc_url":            "",
+		},
+	}
+	meta := &providerMeta{updateActionWarningThreshold: defaultUpdateActionWarningThreshold}
+
+	diff, err := resource.Diff(state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"key":               "order-fields",
+		"name":              map[string]interface{}{"en": "Order"},
+		"resource_type_ids": []interface{}{"order"},
+	}), meta)
+	assert.NoError(t, err)
+
+	// The key is changed without replacing the type, which can't be deleted
+	// while it's used by carts and orders
+	assert.False(t, diff.RequiresNew())
+	assert.Equal(t, "1", diff.Attributes["planned_actions.#"].New)
+	assert.Equal(t,
+		`{"action":"changeKey","key":"order-fields"}`,
+		diff.Attributes["planned_actions.0"].New)
+}
+
+func TestResourceTypeSetElementTypes(t *testing.T) {
+	elementTypes := []map[string]interface{}{
+		{"name": "Enum", "values": map[string]interface{}{"s": "Small"}},
+		{
+			"name": "LocalizedEnum",
+			"localized_value": []interface{}{
+				map[string]interface{}{"key": "red", "label": map[string]interface{}{"en": "Red"}},
+			},
+		},
+		{"name": "Reference", "reference_type_id": "category"},
+		{"name": "Money"},
+	}
+
+	for _, elementType := range elementTypes {
+		d := schema.TestResourceDataRaw(t, resourceType().Schema, map[string]interface{}{
+			"field": []interface{}{
+				map[string]interface{}{
+					"name":  "values",
+					"label": map[string]interface{}{"en": "Values"},
+					"type": []interface{}{
+						map[string]interface{}{
+							"name":         "Set",
+							"element_type": []interface{}{elementType},
+						},
+					},
+				},
+			},
+		})
+		fieldType, err := getFieldType(d.Get("field.0.type.0"))
+		assert.NoError(t, err)
+
+		// Read the field type back as returned by the API
+		data, err := json.Marshal(commercetools.FieldDefinition{Name: "values", Type: fieldType})
+		assert.NoError(t, err)
+		fieldDef := commercetools.FieldDefinition{}
+		assert.NoError(t, json.Unmarshal(data, &fieldDef))
+
+		typeData, err := resourceTypeReadFieldType(fieldDef.Type, true)
+		assert.NoError(t, err)
+		result := schema.TestResourceDataRaw(t, resourceType().Schema, map[string]interface{}{})
+		assert.NoError(t, result.Set("field", []interface{}{
+			map[string]interface{}{"name": "values", "type": typeData},
+		}))
+		assert.Equal(t, d.Get("field.0.type"), result.Get("field.0.type"), elementType["name"])
+	}
+}
+
+func TestResourceTypeValidateSetElementType(t *testing.T) {
+	set := func(elementType map[string]interface{}) map[string]interface{} {
+		fieldType := map[string]interface{}{"name": "Set", "element_type": []interface{}{}}
+		if elementType != nil {
+			fieldType["element_type"] = []interface{}{elementType}
+		}
+		return fieldType
+	}
+
+	assert.NoError(t, resourceTypeValidateSetElementType("values", map[string]interface{}{
+		"type": []interface{}{set(map[string]interface{}{"name": "Money"})},
+	}))
+	assert.EqualError(t, resourceTypeValidateSetElementType("values", map[string]interface{}{
+		"type": []interface{}{set(nil)},
+	}), "Field 'values' is a Set without an element_type")
+
+	assert.NoError(t, resourceTypeValidateElementTypeChange("values",
+		set(map[string]interface{}{"name": "Reference", "reference_type_id": "category"}),
+		set(map[string]interface{}{"name": "Reference", "reference_type_id": "category"})))
+	assert.Error(t, resourceTypeValidateElementTypeChange("values",
+		set(map[string]interface{}{"name": "Reference", "reference_type_id": "category"}),
+		set(map[string]interface{}{"name": "Reference", "reference_type_id": "product"})))
+	assert.Error(t, resourceTypeValidateElementTypeChange("values",
+		set(map[string]interface{}{"name": "Enum"}),
+		set(map[string]interface{}{"name": "LocalizedEnum"})))
+}
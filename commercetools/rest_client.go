@@ -0,0 +1,115 @@
+package commercetools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/labd/commercetools-go-sdk/commercetools"
+)
+
+// restClient performs requests against endpoints of the commercetools API
+// which are not (yet) supported by the commercetools-go-sdk. It uses the same
+// authenticated http client as the SDK and returns errors as
+// commercetools.ErrorResponse so they can be handled the same way.
+type restClient struct {
+	httpClient *http.Client
+	url        string
+	projectKey string
+}
+
+func newRestClient(httpClient *http.Client, apiURL string, projectKey string) *restClient {
+	return &restClient{
+		httpClient: httpClient,
+		url:        strings.TrimRight(apiURL, "/"),
+		projectKey: projectKey,
+	}
+}
+
+func (c *restClient) get(ctx context.Context, endpoint string, queryParams url.Values, output interface{}) error {
+	return c.doRequest(ctx, "GET", endpoint, queryParams, nil, output)
+}
+
+func (c *restClient) create(ctx context.Context, endpoint string, queryParams url.Values, input interface{}, output interface{}) error {
+	return c.doRequest(ctx, "POST", endpoint, queryParams, input, output)
+}
+
+func (c *restClient) update(ctx context.Context, endpoint string, queryParams url.Values, version int, actions interface{}, output interface{}) error {
+	input := map[string]interface{}{
+		"version": version,
+		"actions": actions,
+	}
+	return c.doRequest(ctx, "POST", endpoint, queryParams, input, output)
+}
+
+func (c *restClient) delete(ctx context.Context, endpoint string, queryParams url.Values, output interface{}) error {
+	return c.doRequest(ctx, "DELETE", endpoint, queryParams, nil, output)
+}
+
+func (c *restClient) doRequest(ctx context.Context, method string, endpoint string, queryParams url.Values, input interface{}, output interface{}) error {
+	var body io.Reader
+	if input != nil {
+		data, err := json.Marshal(input)
+		if err != nil {
+			return fmt.Errorf("unable to serialize content: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	// An empty endpoint refers to the project itself
+	requestURL := fmt.Sprintf("%s/%s", c.url, c.projectKey)
+	if endpoint != "" {
+		requestURL = fmt.Sprintf("%s/%s", requestURL, endpoint)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, requestURL, body)
+	if err != nil {
+		return err
+	}
+	if queryParams != nil {
+		req.URL.RawQuery = queryParams.Encode()
+	}
+	req.Header.Set("Accept", "application/json; charset=utf-8")
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	switch resp.StatusCode {
+	case 200, 201:
+		if output == nil {
+			return nil
+		}
+		return json.Unmarshal(data, output)
+	case 404:
+		if len(data) == 0 {
+			return commercetools.ErrorResponse{
+				StatusCode: resp.StatusCode,
+				Message:    "Not Found (404): ResourceNotFound",
+			}
+		}
+	}
+
+	ctErr := commercetools.ErrorResponse{}
+	if err := json.Unmarshal(data, &ctErr); err != nil {
+		return err
+	}
+	if ctErr.StatusCode == 0 {
+		ctErr.StatusCode = resp.StatusCode
+	}
+	return ctErr
+}
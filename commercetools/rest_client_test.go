@@ -0,0 +1,60 @@
+package commercetools
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labd/commercetools-go-sdk/commercetools"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRestClientUpdate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/my-project/things/1234", r.URL.Path)
+
+		body, _ := ioutil.ReadAll(r.Body)
+		input := map[string]interface{}{}
+		assert.NoError(t, json.Unmarshal(body, &input))
+		assert.Equal(t, float64(2), input["version"])
+
+		w.Write([]byte(`{"id": "1234", "version": 3}`))
+	}))
+	defer server.Close()
+
+	client := newRestClient(server.Client(), server.URL+"/", "my-project")
+	output := map[string]interface{}{}
+	err := client.update(context.Background(), "things/1234", nil, 2, []interface{}{}, &output)
+	assert.NoError(t, err)
+	assert.Equal(t, float64(3), output["version"])
+}
+
+func TestRestClientErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/my-project/things/missing":
+			w.WriteHeader(404)
+		default:
+			w.WriteHeader(409)
+			w.Write([]byte(`{"statusCode": 409, "message": "Version mismatch"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := newRestClient(server.Client(), server.URL, "my-project")
+
+	err := client.get(context.Background(), "things/missing", nil, nil)
+	ctErr, ok := err.(commercetools.ErrorResponse)
+	assert.True(t, ok)
+	assert.Equal(t, 404, ctErr.StatusCode)
+
+	err = client.get(context.Background(), "things/conflict", nil, nil)
+	ctErr, ok = err.(commercetools.ErrorResponse)
+	assert.True(t, ok)
+	assert.Equal(t, 409, ctErr.StatusCode)
+	assert.Equal(t, "Version mismatch", ctErr.Message)
+}
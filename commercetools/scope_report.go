@@ -0,0 +1,155 @@
+package commercetools
+
+import (
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// scopeReportEndpoints maps the endpoints of the API to the name of the scope
+// which grants access to them. A request with the GET method requires the
+// view_ scope, all other requests the manage_ scope.
+var scopeReportEndpoints = map[string]string{
+	"":                    "project_settings",
+	"api-clients":         "api_clients",
+	"cart-discounts":      "cart_discounts",
+	"categories":          "categories",
+	"channels":            "channels",
+	"custom-objects":      "key_value_documents",
+	"customer-groups":     "customer_groups",
+	"customers":           "customers",
+	"discount-codes":      "discount_codes",
+	"extensions":          "extensions",
+	"orders":              "orders",
+	"product-discounts":   "products",
+	"product-projections": "products",
+	"product-tailoring":   "products",
+	"product-types":       "products",
+	"products":            "products",
+	"shipping-methods":    "shipping_methods",
+	"states":              "states",
+	"stores":              "stores",
+	"subscriptions":       "subscriptions",
+	"tax-categories":      "tax_categories",
+	"types":               "types",
+	"zones":               "shipping_methods",
+}
+
+// scopeReportRunPrefix starts the first line of the report, which identifies
+// the Terraform run that wrote it
+const scopeReportRunPrefix = "# terraform run "
+
+// scopeReportTransport records the scopes needed for the requests performed
+// by the provider. Terraform starts a new provider process for the refresh,
+// plan and apply of a single run, so the scopes are merged with the scopes in
+// the file when it was written by the same run, otherwise the file is
+// overwritten. The provider processes of a run share the Terraform process as
+// their parent, which identifies the run. After an apply the file contains the
+// scopes the API client of the provider needs for that run.
+type scopeReportTransport struct {
+	base       http.RoundTripper
+	filename   string
+	projectKey string
+	run        string
+
+	mu     sync.Mutex
+	scopes map[string]bool
+}
+
+func newScopeReportTransport(base http.RoundTripper, filename string, projectKey string) *scopeReportTransport {
+	return &scopeReportTransport{
+		base:       base,
+		filename:   filename,
+		projectKey: projectKey,
+		run:        strconv.Itoa(os.Getppid()),
+		scopes:     map[string]bool{},
+	}
+}
+
+func (t *scopeReportTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+
+	scope, ok := requiredScope(req.Method, auditLogResource(req.URL.Path))
+	if !ok {
+		log.Printf("[DEBUG] No scope known for %s %s", req.Method, req.URL.Path)
+		return resp, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.scopes[scope] {
+		t.scopes[scope] = true
+		if writeErr := t.write(); writeErr != nil {
+			log.Printf("[WARN] Unable to write the scope report: %s", writeErr)
+		}
+	}
+	return resp, err
+}
+
+// write merges the recorded scopes with the scopes in the file written by the
+// same run, the scopes of other projects in the file are kept as is. A file
+// written by another run is overwritten.
+func (t *scopeReportTransport) write() error {
+	scopes := map[string]bool{}
+	for scope := range t.scopes {
+		scopes[scope] = true
+	}
+
+	other := []string{}
+	existing, err := ioutil.ReadFile(t.filename)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	lines := strings.SplitN(string(existing), "\n", 2)
+	if len(lines) == 2 && lines[0] == scopeReportRunPrefix+t.run {
+		for _, item := range strings.Fields(lines[1]) {
+			if strings.HasSuffix(item, ":"+t.projectKey) {
+				scopes[strings.TrimSuffix(item, ":"+t.projectKey)] = true
+			} else {
+				other = append(other, item)
+			}
+		}
+	}
+
+	report := append(minimalScopes(scopes, t.projectKey), other...)
+	sort.Strings(report)
+	content := scopeReportRunPrefix + t.run + "\n" + strings.Join(report, " ") + "\n"
+	return ioutil.WriteFile(t.filename, []byte(content), 0600)
+}
+
+// requiredScope returns the scope needed for a request on the given endpoint,
+// the endpoint of the project itself is empty
+func requiredScope(method string, endpoint string) (string, bool) {
+	if endpoint == "project" {
+		endpoint = ""
+	}
+	name, ok := scopeReportEndpoints[endpoint]
+	if !ok {
+		return "", false
+	}
+
+	if method == "GET" || method == "HEAD" {
+		return fmt.Sprintf("view_%s", name), true
+	}
+	return fmt.Sprintf("manage_%s", name), true
+}
+
+// minimalScopes returns the sorted scopes for the project, leaving out view_
+// scopes which are implied by the corresponding manage_ scope
+func minimalScopes(scopes map[string]bool, projectKey string) []string {
+	result := []string{}
+	for scope := range scopes {
+		if strings.HasPrefix(scope, "view_") && scopes["manage_"+strings.TrimPrefix(scope, "view_")] {
+			continue
+		}
+		result = append(result, fmt.Sprintf("%s:%s", scope, projectKey))
+	}
+	sort.Strings(result)
+	return result
+}
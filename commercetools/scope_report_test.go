@@ -0,0 +1,91 @@
+package commercetools
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequiredScope(t *testing.T) {
+	scope, ok := requiredScope("GET", "product-types")
+	assert.True(t, ok)
+	assert.Equal(t, "view_products", scope)
+
+	scope, ok = requiredScope("POST", "zones")
+	assert.True(t, ok)
+	assert.Equal(t, "manage_shipping_methods", scope)
+
+	scope, ok = requiredScope("GET", "project")
+	assert.True(t, ok)
+	assert.Equal(t, "view_project_settings", scope)
+
+	scope, ok = requiredScope("POST", "project")
+	assert.True(t, ok)
+	assert.Equal(t, "manage_project_settings", scope)
+
+	_, ok = requiredScope("GET", "unknown")
+	assert.False(t, ok)
+}
+
+func TestMinimalScopes(t *testing.T) {
+	scopes := map[string]bool{
+		"view_products":           true,
+		"manage_products":         true,
+		"view_types":              true,
+		"view_project_settings":   true,
+		"manage_project_settings": true,
+	}
+	assert.Equal(t,
+		[]string{"manage_products:my-project", "manage_project_settings:my-project", "view_types:my-project"},
+		minimalScopes(scopes, "my-project"))
+}
+
+func TestScopeReportTransportMerge(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "scope-report")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	filename := filepath.Join(dir, "scopes.txt")
+
+	// The scopes of the plan are written by a previous provider process of
+	// the same run
+	run := strconv.Itoa(os.Getppid())
+	err = ioutil.WriteFile(
+		filename, []byte("# terraform run "+run+"\nview_types:my-project view_products:other-project\n"), 0600)
+	assert.NoError(t, err)
+
+	client := &http.Client{Transport: newScopeReportTransport(http.DefaultTransport, filename, "my-project")}
+	resp, err := client.Post(server.URL+"/my-project/products/1234", "application/json", nil)
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	data, err := ioutil.ReadFile(filename)
+	assert.NoError(t, err)
+	assert.Equal(t,
+		"# terraform run "+run+"\nmanage_products:my-project view_products:other-project view_types:my-project\n",
+		string(data))
+
+	// The report of a previous run is overwritten
+	err = ioutil.WriteFile(filename, []byte("# terraform run 1\nview_types:my-project\n"), 0600)
+	assert.NoError(t, err)
+
+	client = &http.Client{Transport: newScopeReportTransport(http.DefaultTransport, filename, "my-project")}
+	resp, err = client.Post(server.URL+"/my-project/products/1234", "application/json", nil)
+	assert.NoError(t, err)
+	resp.Body.Close()
+
+	data, err = ioutil.ReadFile(filename)
+	assert.NoError(t, err)
+	assert.Equal(t, "# terraform run "+run+"\nmanage_products:my-project\n", string(data))
+}
@@ -0,0 +1,50 @@
+package commercetools
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// applySerialization wraps the create, update and delete functions of the
+// resources, so the writes of a resource type listed in the
+// serialize_resource_types attribute of the provider are performed one at a
+// time. Some endpoints (for example the project settings and types) respond
+// with ConcurrentModification errors when Terraform updates multiple
+// resources of the type in parallel.
+func applySerialization(resources map[string]*schema.Resource) {
+	for name, resource := range resources {
+		resource.Create = serializeResourceFunc(name, resource.Create)
+		resource.Update = serializeResourceFunc(name, resource.Update)
+		resource.Delete = serializeResourceFunc(name, resource.Delete)
+	}
+}
+
+func serializeResourceFunc(name string, f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+	if f == nil {
+		return nil
+	}
+	return func(d *schema.ResourceData, m interface{}) error {
+		if meta, ok := m.(*providerMeta); ok && meta.serializedResourceTypes[name] {
+			lockKey := fmt.Sprintf("resource-type:%s", name)
+			ctMutexKV.Lock(lockKey)
+			defer ctMutexKV.Unlock(lockKey)
+		}
+		return f(d, m)
+	}
+}
+
+// expandSerializedResourceTypes returns the resource types of the
+// serialize_resource_types attribute, all of them need to be resources of
+// the provider
+func expandSerializedResourceTypes(input []interface{}, resources map[string]*schema.Resource) (map[string]bool, error) {
+	result := make(map[string]bool, len(input))
+	for _, raw := range input {
+		name := raw.(string)
+		if _, ok := resources[name]; !ok {
+			return nil, fmt.Errorf("serialize_resource_types: %s is not a resource of the provider", name)
+		}
+		result[name] = true
+	}
+	return result, nil
+}
@@ -0,0 +1,52 @@
+package commercetools
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSerializeResourceFunc(t *testing.T) {
+	var running, maxRunning int32
+	f := serializeResourceFunc("commercetools_type", func(d *schema.ResourceData, m interface{}) error {
+		current := atomic.AddInt32(&running, 1)
+		if current > atomic.LoadInt32(&maxRunning) {
+			atomic.StoreInt32(&maxRunning, current)
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return nil
+	})
+
+	run := func(meta *providerMeta) int32 {
+		atomic.StoreInt32(&maxRunning, 0)
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.NoError(t, f(nil, meta))
+			}()
+		}
+		wg.Wait()
+		return atomic.LoadInt32(&maxRunning)
+	}
+
+	assert.Equal(t, int32(1), run(&providerMeta{serializedResourceTypes: map[string]bool{"commercetools_type": true}}))
+	assert.True(t, run(&providerMeta{}) > 1)
+}
+
+func TestExpandSerializedResourceTypes(t *testing.T) {
+	resources := Provider().(*schema.Provider).ResourcesMap
+
+	result, err := expandSerializedResourceTypes([]interface{}{"commercetools_type"}, resources)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]bool{"commercetools_type": true}, result)
+
+	_, err = expandSerializedResourceTypes([]interface{}{"commercetools_unknown"}, resources)
+	assert.EqualError(t, err, "serialize_resource_types: commercetools_unknown is not a resource of the provider")
+}
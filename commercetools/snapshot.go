@@ -0,0 +1,357 @@
+package commercetools
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// snapshotWriteInterval is the minimum time between two writes of the
+// snapshot while resources are read or changed
+const snapshotWriteInterval = 10 * time.Second
+
+// snapshot is the document written to the snapshot file. The objects are
+// stored per endpoint and id, exactly as returned by commercetools.
+type snapshot struct {
+	ProjectKey string                                `json:"project_key"`
+	CreatedAt  string                                `json:"created_at"`
+	Project    json.RawMessage                       `json:"project,omitempty"`
+	Resources  map[string]map[string]json.RawMessage `json:"resources"`
+}
+
+// snapshotSecrets are the paths of the secrets in the objects per endpoint,
+// these are redacted in the snapshot the same way they are left out of the
+// audit log
+var snapshotSecrets = map[string][][]string{
+	"project": {
+		{"externalOAuth", "authorizationHeader"},
+	},
+	"api-clients": {
+		{"secret"},
+	},
+	"extensions": {
+		{"destination", "authentication", "headerValue"},
+		{"destination", "accessKey"},
+		{"destination", "accessSecret"},
+	},
+	"subscriptions": {
+		{"destination", "accessKey"},
+		{"destination", "accessSecret"},
+		{"destination", "connectionString"},
+		{"destination", "apiSecret"},
+	},
+}
+
+// snapshotTransport keeps the latest remote representation of every object
+// the resources read, create or update. Deleted objects are removed from the
+// snapshot. The transport doesn't know which resource sends a request, so the
+// objects are kept aside until a resource with the same id finished, objects
+// which are only looked up (for example during the plan) are left out. The
+// file is written when no resource is in progress, and at most every
+// snapshotWriteInterval in between.
+type snapshotTransport struct {
+	base     http.RoundTripper
+	filename string
+
+	mu          sync.Mutex
+	snapshot    snapshot
+	pending     map[string]map[string]json.RawMessage
+	resourceIDs map[string]bool
+	inFlight    int
+	dirty       bool
+	lastWrite   time.Time
+}
+
+func newSnapshotTransport(base http.RoundTripper, filename string, projectKey string) (*snapshotTransport, error) {
+	t := &snapshotTransport{
+		base:     base,
+		filename: filename,
+		snapshot: snapshot{
+			ProjectKey: projectKey,
+			CreatedAt:  time.Now().UTC().Format(time.RFC3339),
+			Resources:  map[string]map[string]json.RawMessage{},
+		},
+		pending:     map[string]map[string]json.RawMessage{},
+		resourceIDs: map[string]bool{},
+	}
+	// Terraform starts a new provider process for the refresh, plan and
+	// apply, so the objects of the previous runs are kept. Otherwise the
+	// snapshot only contains the objects changed by the apply.
+	if err := t.load(); err != nil {
+		return nil, err
+	}
+	if err := t.write(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *snapshotTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.base.RoundTrip(req)
+	if err != nil || resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp, err
+	}
+
+	parts := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	switch {
+	case len(parts) == 1:
+		// The project itself
+	case len(parts) == 2 && req.Method == "POST":
+		// A created object
+	case len(parts) == 3:
+		// A single object by id or key
+	default:
+		// Queries and other requests don't return a single object
+		return resp, err
+	}
+
+	body, readErr := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+	if readErr != nil {
+		return nil, readErr
+	}
+
+	t.record(req.Method, parts, body)
+	return resp, err
+}
+
+func (t *snapshotTransport) record(method string, parts []string, body []byte) {
+	var object struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(body, &object); err != nil {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(parts) == 1 {
+		t.snapshot.Project = redactSnapshotObject("project", body)
+		t.dirty = true
+		t.writeIfDue()
+		return
+	}
+	if object.ID == "" {
+		return
+	}
+
+	endpoint := parts[1]
+	switch {
+	case method == "DELETE":
+		delete(t.snapshot.Resources[endpoint], object.ID)
+		delete(t.pending[endpoint], object.ID)
+		t.dirty = true
+	case t.resourceIDs[object.ID]:
+		snapshotObjects(t.snapshot.Resources, endpoint)[object.ID] = redactSnapshotObject(endpoint, body)
+		t.dirty = true
+	default:
+		snapshotObjects(t.pending, endpoint)[object.ID] = redactSnapshotObject(endpoint, body)
+	}
+	t.writeIfDue()
+}
+
+// begin is called when a resource is read or changed
+func (t *snapshotTransport) begin() {
+	t.mu.Lock()
+	t.inFlight++
+	t.mu.Unlock()
+}
+
+// end is called when a resource is read or changed, the objects with the
+// ids of the resource are added to the snapshot
+func (t *snapshotTransport) end(ids []string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.inFlight--
+	for _, id := range ids {
+		t.resourceIDs[id] = true
+		for endpoint, objects := range t.pending {
+			if object, ok := objects[id]; ok {
+				snapshotObjects(t.snapshot.Resources, endpoint)[id] = object
+				delete(objects, id)
+				t.dirty = true
+			}
+		}
+	}
+	if t.inFlight == 0 {
+		t.flush()
+	} else {
+		t.writeIfDue()
+	}
+}
+
+// writeIfDue writes the snapshot when it changed and wasn't written during
+// the last snapshotWriteInterval, the lock must be held
+func (t *snapshotTransport) writeIfDue() {
+	if time.Since(t.lastWrite) >= snapshotWriteInterval {
+		t.flush()
+	}
+}
+
+// flush writes the snapshot when it changed, the lock must be held
+func (t *snapshotTransport) flush() {
+	if !t.dirty {
+		return
+	}
+	if err := t.write(); err != nil {
+		log.Printf("[WARN] Unable to write the snapshot: %s", err)
+		return
+	}
+	t.dirty = false
+}
+
+func snapshotObjects(resources map[string]map[string]json.RawMessage, endpoint string) map[string]json.RawMessage {
+	objects, ok := resources[endpoint]
+	if !ok {
+		objects = map[string]json.RawMessage{}
+		resources[endpoint] = objects
+	}
+	return objects
+}
+
+// load reads the objects of an existing snapshot of the same project
+func (t *snapshotTransport) load() error {
+	data, err := ioutil.ReadFile(t.filename)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	existing := snapshot{}
+	if err := json.Unmarshal(data, &existing); err != nil {
+		return fmt.Errorf("unable to read the snapshot %s: %s", t.filename, err)
+	}
+	if existing.ProjectKey != t.snapshot.ProjectKey {
+		return nil
+	}
+
+	t.snapshot.CreatedAt = existing.CreatedAt
+	if existing.Project != nil {
+		t.snapshot.Project = redactSnapshotObject("project", existing.Project)
+	}
+	for endpoint, objects := range existing.Resources {
+		t.snapshot.Resources[endpoint] = map[string]json.RawMessage{}
+		for id, object := range objects {
+			t.snapshot.Resources[endpoint][id] = redactSnapshotObject(endpoint, object)
+			t.resourceIDs[id] = true
+		}
+	}
+	return nil
+}
+
+// redactSnapshotObject replaces the secrets of the object with a placeholder,
+// objects without secrets are returned as is
+func redactSnapshotObject(endpoint string, body []byte) json.RawMessage {
+	paths, ok := snapshotSecrets[endpoint]
+	if !ok {
+		return json.RawMessage(body)
+	}
+
+	var object map[string]interface{}
+	if err := json.Unmarshal(body, &object); err != nil {
+		return json.RawMessage(body)
+	}
+
+	redacted := false
+	for _, path := range paths {
+		parent := object
+		for _, name := range path[:len(path)-1] {
+			parent, _ = parent[name].(map[string]interface{})
+		}
+		if _, ok := parent[path[len(path)-1]]; ok {
+			parent[path[len(path)-1]] = "<redacted>"
+			redacted = true
+		}
+	}
+	if !redacted {
+		return json.RawMessage(body)
+	}
+
+	data, err := json.Marshal(object)
+	if err != nil {
+		return json.RawMessage(body)
+	}
+	return json.RawMessage(data)
+}
+
+// write replaces the snapshot file, the new document is written to a
+// temporary file first so the file is never incomplete
+func (t *snapshotTransport) write() error {
+	data, err := json.MarshalIndent(t.snapshot, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := ioutil.TempFile(filepath.Dir(t.filename), ".snapshot-")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	t.lastWrite = time.Now()
+	return os.Rename(tmp.Name(), t.filename)
+}
+
+// applySnapshot wraps the functions of the resources, so the snapshot only
+// contains the objects of resources
+func applySnapshot(resources map[string]*schema.Resource) {
+	for _, resource := range resources {
+		resource.Create = snapshotFunc(resource.Create)
+		resource.Read = snapshotFunc(resource.Read)
+		resource.Update = snapshotFunc(resource.Update)
+		resource.Delete = snapshotFunc(resource.Delete)
+	}
+}
+
+func snapshotFunc(f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+	if f == nil {
+		return nil
+	}
+	return func(d *schema.ResourceData, m interface{}) error {
+		meta, ok := m.(*providerMeta)
+		if !ok || meta.snapshot == nil {
+			return f(d, m)
+		}
+
+		meta.snapshot.begin()
+		err := f(d, m)
+		meta.snapshot.end(snapshotResourceIDs(d))
+		return err
+	}
+}
+
+// snapshotResourceIDs returns the ids of the objects of a resource, the
+// category tree manages many categories in a single resource
+func snapshotResourceIDs(d *schema.ResourceData) []string {
+	ids := []string{}
+	if d.Id() != "" {
+		ids = append(ids, d.Id())
+	}
+	if categories, ok := d.Get("ids").(map[string]interface{}); ok {
+		for _, id := range categories {
+			ids = append(ids, id.(string))
+		}
+	}
+	return ids
+}
@@ -0,0 +1,166 @@
+package commercetools
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshotTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/my-project":
+			w.Write([]byte(`{"key": "my-project", "version": 3}`))
+		case "/my-project/types":
+			if r.Method == "GET" {
+				w.Write([]byte(`{"results": [{"id": "queried"}]}`))
+				return
+			}
+			w.Write([]byte(`{"id": "1234", "version": 1, "key": "loyalty"}`))
+		case "/my-project/types/1234", "/my-project/types/key=loyalty":
+			w.Write([]byte(`{"id": "1234", "version": 2, "key": "loyalty"}`))
+		case "/my-project/channels/5678":
+			w.Write([]byte(`{"id": "5678", "version": 1}`))
+		case "/my-project/cart-discounts/key=summer":
+			w.Write([]byte(`{"id": "9012", "version": 1, "key": "summer"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"statusCode": 404}`))
+		}
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "snapshot")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	filename := filepath.Join(dir, "snapshot.json")
+	transport, err := newSnapshotTransport(http.DefaultTransport, filename, "my-project")
+	assert.NoError(t, err)
+	client := &http.Client{Transport: transport}
+
+	// The requests of the resources
+	transport.begin()
+	_, err = client.Get(server.URL + "/my-project")
+	assert.NoError(t, err)
+	_, err = client.Post(server.URL+"/my-project/types", "application/json", strings.NewReader(`{}`))
+	assert.NoError(t, err)
+	_, err = client.Get(server.URL + "/my-project/types")
+	assert.NoError(t, err)
+	_, err = client.Get(server.URL + "/my-project/types/key=loyalty")
+	assert.NoError(t, err)
+	_, err = client.Get(server.URL + "/my-project/channels/5678")
+	assert.NoError(t, err)
+	_, err = client.Get(server.URL + "/my-project/channels/unknown")
+	assert.NoError(t, err)
+
+	// A lookup during the plan, which isn't an object of a resource
+	_, err = client.Get(server.URL + "/my-project/cart-discounts/key=summer")
+	assert.NoError(t, err)
+
+	// The snapshot is only written once no resource is in progress
+	data, err := ioutil.ReadFile(filename)
+	assert.NoError(t, err)
+	result := snapshot{}
+	assert.NoError(t, json.Unmarshal(data, &result))
+	assert.Empty(t, result.Resources)
+
+	transport.end([]string{"1234", "5678"})
+
+	transport.begin()
+	req, _ := http.NewRequest("DELETE", server.URL+"/my-project/channels/5678", nil)
+	resp, err := client.Do(req)
+	assert.NoError(t, err)
+	// The response body is still available to the caller
+	body, _ := ioutil.ReadAll(resp.Body)
+	assert.Equal(t, `{"id": "5678", "version": 1}`, string(body))
+	transport.end(nil)
+
+	data, err = ioutil.ReadFile(filename)
+	assert.NoError(t, err)
+	result = snapshot{}
+	assert.NoError(t, json.Unmarshal(data, &result))
+
+	assert.Equal(t, "my-project", result.ProjectKey)
+	assert.JSONEq(t, `{"key": "my-project", "version": 3}`, string(result.Project))
+	assert.Len(t, result.Resources["types"], 1)
+	assert.JSONEq(t, `{"id": "1234", "version": 2, "key": "loyalty"}`, string(result.Resources["types"]["1234"]))
+	assert.Empty(t, result.Resources["channels"])
+	assert.Empty(t, result.Resources["cart-discounts"])
+}
+
+func TestSnapshotTransportMerge(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id": "1234", "version": 2, "key": "loyalty"}`))
+	}))
+	defer server.Close()
+
+	dir, err := ioutil.TempDir("", "snapshot")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	// The snapshot written by the refresh, which runs in another process
+	filename := filepath.Join(dir, "snapshot.json")
+	err = ioutil.WriteFile(filename, []byte(`{
+		"project_key": "my-project",
+		"created_at": "2020-11-02T10:00:00Z",
+		"resources": {"channels": {"5678": {"id": "5678", "version": 1}}}
+	}`), 0600)
+	assert.NoError(t, err)
+
+	transport, err := newSnapshotTransport(http.DefaultTransport, filename, "my-project")
+	assert.NoError(t, err)
+	client := &http.Client{Transport: transport}
+	transport.begin()
+	_, err = client.Post(server.URL+"/my-project/types/1234", "application/json", strings.NewReader(`{}`))
+	assert.NoError(t, err)
+	transport.end([]string{"1234"})
+
+	data, err := ioutil.ReadFile(filename)
+	assert.NoError(t, err)
+	result := snapshot{}
+	assert.NoError(t, json.Unmarshal(data, &result))
+
+	assert.Equal(t, "2020-11-02T10:00:00Z", result.CreatedAt)
+	assert.JSONEq(t, `{"id": "5678", "version": 1}`, string(result.Resources["channels"]["5678"]))
+	assert.JSONEq(t, `{"id": "1234", "version": 2, "key": "loyalty"}`, string(result.Resources["types"]["1234"]))
+}
+
+func TestRedactSnapshotObject(t *testing.T) {
+	assert.JSONEq(t,
+		`{"id": "1234", "secret": "<redacted>"}`,
+		string(redactSnapshotObject("api-clients", []byte(`{"id": "1234", "secret": "abc"}`))))
+	assert.JSONEq(t,
+		`{"id": "1234", "destination": {"type": "HTTP", "authentication": {"type": "AuthorizationHeader", "headerValue": "<redacted>"}}}`,
+		string(redactSnapshotObject("extensions", []byte(
+			`{"id": "1234", "destination": {"type": "HTTP", "authentication": {"type": "AuthorizationHeader", "headerValue": "Bearer abc"}}}`))))
+	assert.JSONEq(t,
+		`{"id": "1234", "destination": {"type": "SQS", "queueUrl": "https://sqs", "accessKey": "<redacted>", "accessSecret": "<redacted>"}}`,
+		string(redactSnapshotObject("subscriptions", []byte(
+			`{"id": "1234", "destination": {"type": "SQS", "queueUrl": "https://sqs", "accessKey": "key", "accessSecret": "secret"}}`))))
+
+	// Objects without secrets are kept as is
+	assert.Equal(t,
+		`{"id": "1234", "destination": {"type": "GoogleCloudPubSub"}}`,
+		string(redactSnapshotObject("subscriptions", []byte(`{"id": "1234", "destination": {"type": "GoogleCloudPubSub"}}`))))
+	assert.Equal(t, `{"id": "1234", "secret": "abc"}`, string(redactSnapshotObject("types", []byte(`{"id": "1234", "secret": "abc"}`))))
+}
+
+func TestSnapshotResourceIDs(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceType().Schema, map[string]interface{}{})
+	d.SetId("1234")
+	assert.Equal(t, []string{"1234"}, snapshotResourceIDs(d))
+
+	d = schema.TestResourceDataRaw(t, resourceCategoryTree().Schema, map[string]interface{}{})
+	d.SetId("category-tree-1")
+	d.Set("ids", map[string]string{"men": "5678"})
+	assert.Equal(t, []string{"category-tree-1", "5678"}, snapshotResourceIDs(d))
+}
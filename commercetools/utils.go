@@ -2,8 +2,11 @@ package commercetools
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"math"
+	"reflect"
 	"strings"
 	"time"
 
@@ -18,15 +21,37 @@ import (
 const TypeLocalizedString = schema.TypeMap
 
 func getClient(m interface{}) *commercetools.Client {
-	client := m.(*commercetools.Client)
-	return client
+	return m.(*providerMeta).client
 }
 
+func getRestClient(m interface{}) *restClient {
+	return m.(*providerMeta).rest
+}
+
+// defaultUpdateActionWarningThreshold is the number of update actions for a
+// single resource above which the plan shows a warning
+const defaultUpdateActionWarningThreshold = 100
+
 func handleCommercetoolsError(err error) *resource.RetryError {
 	if ctErr, ok := err.(commercetools.ErrorResponse); ok {
 		return resource.NonRetryableError(ctErr)
 	}
 
+	var circuitErr *circuitOpenError
+	if errors.As(err, &circuitErr) {
+		return resource.NonRetryableError(err)
+	}
+
+	var validationErr *requestValidationError
+	if errors.As(err, &validationErr) {
+		return resource.NonRetryableError(err)
+	}
+
+	var readOnlyErr *readOnlyError
+	if errors.As(err, &readOnlyErr) {
+		return resource.NonRetryableError(err)
+	}
+
 	log.Printf("[DEBUG] Received error: %s", err)
 	return resource.RetryableError(err)
 }
@@ -64,6 +89,45 @@ func localizedStringToMap(input commercetools.LocalizedString) map[string]string
 	return result
 }
 
+// flattenInt returns a number as int. Numbers decoded from JSON without a
+// struct (for example via the restClient or untyped SDK fields) are float64,
+// storing them as int keeps the state stable when the representation changes.
+func flattenInt(value interface{}) int {
+	switch v := value.(type) {
+	case int:
+		return v
+	case int64:
+		return int(v)
+	case float64:
+		return int(math.Round(v))
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return int(i)
+		}
+		if f, err := v.Float64(); err == nil {
+			return int(math.Round(f))
+		}
+	}
+	return 0
+}
+
+// diffSuppressEquivalentJSON suppresses the diff of a string attribute
+// containing JSON when the old and new value decode to the same value, so
+// 1500 and 1500.0 or a different formatting don't result in a change
+func diffSuppressEquivalentJSON(k, old, new string, d *schema.ResourceData) bool {
+	if old == new {
+		return true
+	}
+	var oldValue, newValue interface{}
+	if err := json.Unmarshal([]byte(old), &oldValue); err != nil {
+		return false
+	}
+	if err := json.Unmarshal([]byte(new), &newValue); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(oldValue, newValue)
+}
+
 func stringFormatObject(object interface{}) string {
 	data, err := json.MarshalIndent(object, "", "    ")
 
@@ -111,6 +175,16 @@ func createLookup(objects []interface{}, key string) map[string]interface{} {
 	return lookup
 }
 
+// copyStringInterfaceMap returns a shallow copy of the map, so values can be
+// replaced without modifying the map read from the resource data
+func copyStringInterfaceMap(input map[string]interface{}) map[string]interface{} {
+	result := make(map[string]interface{}, len(input))
+	for key, value := range input {
+		result[key] = value
+	}
+	return result
+}
+
 func stringInSlice(a string, list []string) bool {
 	for _, b := range list {
 		if b == a {
@@ -120,6 +194,16 @@ func stringInSlice(a string, list []string) bool {
 	return false
 }
 
+// quotePredicateValues returns the values quoted and comma separated, so they
+// can be used in a query predicate like `key in (...)`
+func quotePredicateValues(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = fmt.Sprintf("%q", value)
+	}
+	return strings.Join(quoted, ", ")
+}
+
 var currencyCodes = map[string]bool{
 	"AED": true,
 	"AFN": true,
@@ -312,3 +396,57 @@ func ValidateCurrencyCode(val interface{}, key string) (warns []string, errs []e
 func expandDate(input string) (time.Time, error) {
 	return time.Parse(time.RFC3339, input)
 }
+
+func flattenDate(input *time.Time) string {
+	if input == nil {
+		return ""
+	}
+	return input.UTC().Format(time.RFC3339Nano)
+}
+
+// diffSuppressEquivalentDate suppresses the diff between dates which refer to
+// the same moment, commercetools returns the dates in UTC with milliseconds
+func diffSuppressEquivalentDate(k, old, new string, d *schema.ResourceData) bool {
+	oldDate, err := expandDate(old)
+	if err != nil {
+		return false
+	}
+	newDate, err := expandDate(new)
+	if err != nil {
+		return false
+	}
+	return oldDate.Equal(newDate)
+}
+
+// validFromApply is the value of valid_from which sets it to the moment the
+// resource is created (or the first update without a valid_from)
+const validFromApply = "apply"
+
+func validateValidFrom(val interface{}, key string) (warns []string, errs []error) {
+	value := val.(string)
+	if value == "" || value == validFromApply {
+		return
+	}
+	if _, err := expandDate(value); err != nil {
+		errs = append(errs, fmt.Errorf("%q must be %q or a RFC3339 date, got: %s", key, validFromApply, value))
+	}
+	return
+}
+
+// expandValidFrom returns the date of valid_from, which is the current time
+// when it is set to apply
+func expandValidFrom(input string) (time.Time, error) {
+	if input == validFromApply {
+		return time.Now().UTC(), nil
+	}
+	return expandDate(input)
+}
+
+// diffSuppressValidFrom suppresses the diff when valid_from is set to apply and
+// the date was already set, so the moment of the apply is kept afterwards
+func diffSuppressValidFrom(k, old, new string, d *schema.ResourceData) bool {
+	if new == validFromApply && old != "" {
+		return true
+	}
+	return diffSuppressEquivalentDate(k, old, new, d)
+}
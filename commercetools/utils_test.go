@@ -1,6 +1,12 @@
 package commercetools
 
-import "testing"
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
 
 func TestCreateLookup(t *testing.T) {
 	input := []interface{}{
@@ -21,3 +27,61 @@ func TestCreateLookup(t *testing.T) {
 		t.Error("Could not lookup name1")
 	}
 }
+
+func TestQuotePredicateValues(t *testing.T) {
+	assert.Equal(t, `"a", "b"`, quotePredicateValues([]string{"a", "b"}))
+	assert.Equal(t, `"with \"quote\""`, quotePredicateValues([]string{`with "quote"`}))
+	assert.Equal(t, "", quotePredicateValues([]string{}))
+}
+
+func TestFlattenInt(t *testing.T) {
+	assert.Equal(t, 1500, flattenInt(1500))
+	assert.Equal(t, 1500, flattenInt(1500.0))
+	assert.Equal(t, 1500, flattenInt(int64(1500)))
+	assert.Equal(t, 1500, flattenInt(json.Number("1500")))
+	assert.Equal(t, 1500, flattenInt(json.Number("1500.0")))
+	assert.Equal(t, 0, flattenInt(nil))
+}
+
+func TestDiffSuppressEquivalentJSON(t *testing.T) {
+	assert.True(t, diffSuppressEquivalentJSON("value", "1500", "1500.0", nil))
+	assert.True(t, diffSuppressEquivalentJSON("value", `{"amount":1500}`, `{ "amount": 1500.0 }`, nil))
+	assert.True(t, diffSuppressEquivalentJSON("value", "plain", "plain", nil))
+	assert.False(t, diffSuppressEquivalentJSON("value", "1500", "1501", nil))
+	assert.False(t, diffSuppressEquivalentJSON("value", "plain", "other", nil))
+}
+
+func TestFlattenDate(t *testing.T) {
+	date := time.Date(2026, 10, 15, 12, 30, 0, 250000000, time.FixedZone("CEST", 2*60*60))
+	assert.Equal(t, "2026-10-15T10:30:00.25Z", flattenDate(&date))
+	assert.Equal(t, "", flattenDate(nil))
+}
+
+func TestDiffSuppressEquivalentDate(t *testing.T) {
+	assert.True(t, diffSuppressEquivalentDate("valid_until", "2026-10-15T10:30:00.000Z", "2026-10-15T12:30:00+02:00", nil))
+	assert.False(t, diffSuppressEquivalentDate("valid_until", "2026-10-15T10:30:00.000Z", "2026-10-16T10:30:00Z", nil))
+	assert.False(t, diffSuppressEquivalentDate("valid_until", "", "2026-10-16T10:30:00Z", nil))
+}
+
+func TestValidFromApply(t *testing.T) {
+	_, errs := validateValidFrom("apply", "valid_from")
+	assert.Empty(t, errs)
+	_, errs = validateValidFrom("2026-10-15T10:30:00Z", "valid_from")
+	assert.Empty(t, errs)
+	_, errs = validateValidFrom("tomorrow", "valid_from")
+	assert.Len(t, errs, 1)
+
+	before := time.Now()
+	date, err := expandValidFrom("apply")
+	assert.NoError(t, err)
+	assert.False(t, date.Before(before.Truncate(time.Second)))
+
+	date, err = expandValidFrom("2026-10-15T10:30:00Z")
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 15, 10, 30, 0, 0, time.UTC), date)
+
+	// Once applied the moment of the apply is kept
+	assert.True(t, diffSuppressValidFrom("valid_from", "2026-10-15T10:30:00.000Z", "apply", nil))
+	assert.False(t, diffSuppressValidFrom("valid_from", "", "apply", nil))
+	assert.False(t, diffSuppressValidFrom("valid_from", "2026-10-15T10:30:00.000Z", "2026-10-16T10:30:00Z", nil))
+}
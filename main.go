@@ -6,7 +6,15 @@ import (
 	"github.com/labd/terraform-provider-commercetools/commercetools"
 )
 
+// These values are set by goreleaser during the build
+var (
+	version = "dev"
+	commit  = "snapshot"
+)
+
 func main() {
+	commercetools.SetBuildInfo(version, commit)
+
 	plugin.Serve(&plugin.ServeOpts{
 		ProviderFunc: func() terraform.ResourceProvider {
 			return commercetools.Provider()